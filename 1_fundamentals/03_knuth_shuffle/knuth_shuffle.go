@@ -8,10 +8,12 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 )
 
-
+// shuffles the characters of a line of standard input; a seed as the first argument
+// replays the same shuffle
 func main() {
 	reader := bufio.NewReader(os.Stdin)
 	line, err := reader.ReadString('\n')
@@ -22,7 +24,16 @@ func main() {
 		log.Fatal(err)
 	}
 	a := strings.Split(line[:len(line)-1], "")
-	util.ShuffleStringSlice(a)
+
+	random := util.Default()
+	if len(os.Args) > 1 {
+		seed, err := strconv.ParseInt(os.Args[1], 10, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+		random = util.NewRandom(seed)
+	}
+	random.ShuffleStringSlice(a)
 
 	for i := 0; i < len(a); i++ {
 		fmt.Println(a[i])
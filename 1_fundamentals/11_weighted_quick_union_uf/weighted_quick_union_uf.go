@@ -1,77 +1,20 @@
 package main
 
 import (
+	UF "github.com/lee-hen/Algorithms/1_fundamentals/12_uf"
+
 	"fmt"
-	"log"
 )
 
-type WeightedQuickUnionUF struct {
-	count int
-	parent, size []int
-}
-
-func NewWeightedQuickUnionUF(n int) *WeightedQuickUnionUF {
-	parent := make([]int, n, n)
-	size := make([]int, n, n)
-	for i := 0; i < n; i++ {
-		parent[i] = i
-		size[i] = 1
-	}
-	return &WeightedQuickUnionUF {
-		count: n,
-		parent: parent,
-		size: size,
-	}
-}
-
-func (uf *WeightedQuickUnionUF) Count() int {
-	return uf.count
-}
-
-func (uf *WeightedQuickUnionUF) Find(p int) int {
-	uf.validate(p)
-	for p != uf.parent[p] {
-		p = uf.parent[p]
-	}
-	return p
-}
-
-func (uf *WeightedQuickUnionUF) validate (p int) {
-	n := len(uf.parent)
-	if p < 0 || p >= n {
-		log.Fatalf("index %d is not between 0 and %d", p, n-1 )
-	}
-}
-
-func (uf *WeightedQuickUnionUF) Connected(p, q int) bool {
-	return uf.Find(p) == uf.Find(q)
-}
-
-func (uf *WeightedQuickUnionUF) Union(p, q int) {
-	rootP := uf.Find(p)
-	rootQ := uf.Find(q)
-
-	if rootP == rootQ {
-		return
-	}
-
-	if uf.size[rootP] < uf.size[rootQ] {
-		uf.parent[rootP] = rootQ
-		uf.size[rootQ] += uf.size[rootP]
-	} else {
-		uf.parent[rootQ] = rootP
-		uf.size[rootP] += uf.size[rootQ]
-	}
-	uf.count--
-}
-
+// Weighted quick-union is the union-find linking by size without path compression,
+// so that every Find takes O(log n).
 func main() {
 	var n int
 	_, err := fmt.Scan(&n)
 	if err != nil {
 		fmt.Println(err)
 	}
-	uf := NewWeightedQuickUnionUF(n)
+	uf := UF.NewUF(n, UF.WithLinking(UF.BySize), UF.WithCompression(UF.NoCompression))
 
 	for {
 		var p, q int
@@ -89,6 +32,6 @@ func main() {
 		}
 		uf.Union(p, q)
 		fmt.Println(p, q, uf.Count())
-		fmt.Println(uf.parent)
+		fmt.Println(uf.Components())
 	}
 }
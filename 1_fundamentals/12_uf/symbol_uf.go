@@ -0,0 +1,140 @@
+package UF
+
+// SymbolUF
+// Union-find over keys of any comparable type. A symbol table assigns each key an index the
+// first time it is seen, in the manner of a symbol graph, and a UF over the indices does the
+// work. Only Add and Union add keys; the queries treat a key that was never added as a set of
+// its own.
+type SymbolUF[K comparable] struct {
+	st   map[K]int // key -> index
+	keys []K       // index -> key
+	uf   *UF
+}
+
+// NewSymbolUF
+// Initializes an empty union-find data structure over keys of type K. The options are
+// those of NewUF.
+func NewSymbolUF[K comparable](opts ...Option) *SymbolUF[K] {
+	return &SymbolUF[K]{
+		st: make(map[K]int),
+		uf: NewUF(0, opts...),
+	}
+}
+
+// Add
+// Adds the key in a set of its own, if it is not already there, and returns its index.
+func (s *SymbolUF[K]) Add(key K) int {
+	if i, ok := s.st[key]; ok {
+		return i
+	}
+
+	i := len(s.keys)
+	s.st[key] = i
+	s.keys = append(s.keys, key)
+	s.uf.grow()
+	return i
+}
+
+// Contains
+// Returns true if the key was ever added.
+func (s *SymbolUF[K]) Contains(key K) bool {
+	_, ok := s.st[key]
+	return ok
+}
+
+// Len
+// Returns the number of keys added.
+func (s *SymbolUF[K]) Len() int {
+	return len(s.keys)
+}
+
+// Count
+// Returns the number of sets among the keys added.
+func (s *SymbolUF[K]) Count() int {
+	return s.uf.Count()
+}
+
+// Find
+// Returns the canonical key of the set containing the key.
+func (s *SymbolUF[K]) Find(key K) K {
+	i, ok := s.st[key]
+	if !ok {
+		return key
+	}
+	return s.keys[s.uf.Find(i)]
+}
+
+// Connected
+// Returns true if the two keys are in the same set.
+func (s *SymbolUF[K]) Connected(p, q K) bool {
+	i, okP := s.st[p]
+	j, okQ := s.st[q]
+	if !okP || !okQ {
+		return p == q
+	}
+	return s.uf.Connected(i, j)
+}
+
+// Union
+// Merges the set containing key p with the set containing key q, and returns false if
+// they were already the same set.
+func (s *SymbolUF[K]) Union(p, q K) bool {
+	return s.uf.Union(s.Add(p), s.Add(q))
+}
+
+// Size
+// Returns the number of keys in the set containing the key.
+func (s *SymbolUF[K]) Size(key K) int {
+	i, ok := s.st[key]
+	if !ok {
+		return 1
+	}
+	return s.uf.Size(i)
+}
+
+// Members
+// Returns the keys of the set containing the key, starting with the key.
+func (s *SymbolUF[K]) Members(key K) []K {
+	i, ok := s.st[key]
+	if !ok {
+		return []K{key}
+	}
+	return s.symbols(s.uf.Members(i))
+}
+
+// Components
+// Returns the sets, each as the list of its keys, in order of first appearance.
+func (s *SymbolUF[K]) Components() [][]K {
+	components := s.uf.Components()
+	symbols := make([][]K, len(components))
+	for i, members := range components {
+		symbols[i] = s.symbols(members)
+	}
+	return symbols
+}
+
+// Checkpoint
+// Returns the number of unions that can be undone, as UF.Checkpoint.
+func (s *SymbolUF[K]) Checkpoint() int {
+	return s.uf.Checkpoint()
+}
+
+// Undo
+// Undoes the last union that merged two sets, as UF.Undo; the keys stay added.
+func (s *SymbolUF[K]) Undo() bool {
+	return s.uf.Undo()
+}
+
+// RollbackTo
+// Undoes the unions done since the checkpoint, as UF.RollbackTo.
+func (s *SymbolUF[K]) RollbackTo(checkpoint int) {
+	s.uf.RollbackTo(checkpoint)
+}
+
+func (s *SymbolUF[K]) symbols(indices []int) []K {
+	keys := make([]K, len(indices))
+	for i, index := range indices {
+		keys[i] = s.keys[index]
+	}
+	return keys
+}
@@ -4,65 +4,315 @@ import (
 	"log"
 )
 
+// Union-find over the sites 0 through n-1: a forest in which each tree is a component and
+// its root the canonical element. Linking the smaller tree (by rank or by size) under the
+// larger keeps every tree O(log n) high; shortening paths during Find as well makes the
+// amortized cost of an operation O(α(n)), the inverse Ackermann function, which is at most 4
+// for any practical n (Tarjan). Each component is also a circular linked list of its members,
+// spliced in constant time by Union, so that Members lists a component in time proportional
+// to its size.
+// In rollback mode every Union is recorded and can be undone in reverse order, as needed by
+// offline dynamic connectivity and backtracking searches. Undoing a union must restore the
+// paths it created, so rollback mode does not compress them and Find takes O(log n).
+
+// Linking
+// The rule that decides which root goes under the other in Union.
+type Linking int
+
+const (
+	ByRank Linking = iota // the root of lower rank, an upper bound on the height, goes under
+	BySize                // the root of the smaller component goes under
+)
+
+// Compression
+// The path shortening done by Find.
+type Compression int
+
+const (
+	PathHalving     Compression = iota // every other node on the path skips to its grandparent
+	PathSplitting                      // every node on the path skips to its grandparent
+	PathCompression                    // every node on the path points to the root
+	NoCompression
+)
+
 type UF struct {
-	count int
-	rank []byte
-	parent []int
+	count  int
+	rank   []byte // rank[i] = rank of subtree rooted at i (never more than 31)
+	size   []int  // size[i] = number of sites in subtree rooted at i
+	parent []int  // parent[i] = parent of i
+	next   []int  // next[i] = next member of the component of i, circularly
+
+	linking     Linking
+	compression Compression
+	rollback    bool
+	history     []link // the unions done, in rollback mode
+}
+
+// what a union changed, for Undo
+type link struct {
+	child, root int
+	rankRaised  bool
+}
+
+// Option
+// Configures a UF at construction.
+type Option func(*options)
+
+type options struct {
+	linking     Linking
+	compression Compression
+	rollback    bool
+	compressing bool // was a compression asked for?
+}
+
+// WithLinking
+// Sets the linking rule (default ByRank).
+func WithLinking(linking Linking) Option {
+	return func(o *options) {
+		o.linking = linking
+	}
+}
+
+// WithCompression
+// Sets the path shortening done by Find (default PathHalving).
+func WithCompression(compression Compression) Option {
+	return func(o *options) {
+		o.compression = compression
+		o.compressing = compression != NoCompression
+	}
 }
 
-func NewUF(n int) *UF {
+// WithRollback
+// Records the unions so that they can be undone; Find then does no path shortening.
+func WithRollback() Option {
+	return func(o *options) {
+		o.rollback = true
+	}
+}
+
+// NewUF
+// Initializes an empty union-find data structure with n elements 0 through n-1.
+// Initially, each element is in its own set.
+func NewUF(n int, opts ...Option) *UF {
+	if n < 0 {
+		log.Fatalln("number of elements must be nonnegative")
+	}
+
+	o := options{linking: ByRank, compression: PathHalving}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.rollback {
+		if o.compressing {
+			log.Fatalln("rollback cannot undo path compression")
+		}
+		o.compression = NoCompression
+	}
+
 	parent := make([]int, n, n)
 	rank := make([]byte, n, n)
+	size := make([]int, n, n)
+	next := make([]int, n, n)
 	for i := 0; i < n; i++ {
 		parent[i] = i
 		rank[i] = 0
+		size[i] = 1
+		next[i] = i
 	}
-	return &UF {
-		count: n,
-		parent: parent,
-		rank: rank,
+	return &UF{
+		count:       n,
+		parent:      parent,
+		rank:        rank,
+		size:        size,
+		next:        next,
+		linking:     o.linking,
+		compression: o.compression,
+		rollback:    o.rollback,
 	}
 }
 
+// Find
+// Returns the canonical element of the set containing element p.
 func (uf *UF) Find(p int) int {
 	uf.validate(p)
-	for p != uf.parent[p] {
-		uf.parent[p] = uf.parent[uf.parent[p]]  // path compression by halving
-		p = uf.parent[p]
+
+	switch uf.compression {
+	case PathHalving:
+		for p != uf.parent[p] {
+			uf.parent[p] = uf.parent[uf.parent[p]] // path compression by halving
+			p = uf.parent[p]
+		}
+	case PathSplitting:
+		for p != uf.parent[p] {
+			next := uf.parent[p]
+			uf.parent[p] = uf.parent[next]
+			p = next
+		}
+	case PathCompression:
+		root := p
+		for root != uf.parent[root] {
+			root = uf.parent[root]
+		}
+		for p != root {
+			next := uf.parent[p]
+			uf.parent[p] = root
+			p = next
+		}
+	default:
+		for p != uf.parent[p] {
+			p = uf.parent[p]
+		}
 	}
 	return p
 }
 
+// Count
+// Returns the number of sets.
 func (uf *UF) Count() int {
 	return uf.count
 }
 
+// Connected
+// Returns true if the two elements are in the same set.
 func (uf *UF) Connected(p, q int) bool {
 	return uf.Find(p) == uf.Find(q)
 }
 
-func (uf *UF) Union(p, q int) {
+// Size
+// Returns the number of elements in the set containing element p.
+func (uf *UF) Size(p int) int {
+	return uf.size[uf.Find(p)]
+}
+
+// Members
+// Returns the elements of the set containing element p, starting with p.
+func (uf *UF) Members(p int) []int {
+	uf.validate(p)
+
+	members := make([]int, 0, uf.Size(p))
+	for q := p; ; {
+		members = append(members, q)
+		if q = uf.next[q]; q == p {
+			return members
+		}
+	}
+}
+
+// Components
+// Returns the sets, each as the list of its elements, in order of their smallest elements.
+func (uf *UF) Components() [][]int {
+	components := make([][]int, 0, uf.count)
+	seen := make([]bool, len(uf.parent))
+	for p := range uf.parent {
+		if seen[p] {
+			continue
+		}
+		members := uf.Members(p)
+		for _, q := range members {
+			seen[q] = true
+		}
+		components = append(components, members)
+	}
+	return components
+}
+
+// Union
+// Merges the set containing element p with the set containing element q, and returns
+// false if they were already the same set.
+func (uf *UF) Union(p, q int) bool {
 	rootP := uf.Find(p)
 	rootQ := uf.Find(q)
 
 	if rootP == rootQ {
-		return
+		return false
 	}
 
-	if uf.rank[rootP] < uf.rank[rootQ] {
-		uf.parent[rootP] = rootQ
-	} else if uf.rank[rootP] > uf.rank[rootQ] {
-		uf.parent[rootQ] = rootP
-	} else {
-		uf.parent[rootQ] = rootP
+	// make rootP the root that stays on top
+	rankRaised := false
+	if uf.linking == BySize {
+		if uf.size[rootP] < uf.size[rootQ] {
+			rootP, rootQ = rootQ, rootP
+		}
+	} else if uf.rank[rootP] < uf.rank[rootQ] {
+		rootP, rootQ = rootQ, rootP
+	} else if uf.rank[rootP] == uf.rank[rootQ] {
 		uf.rank[rootP]++
+		rankRaised = true
 	}
+
+	uf.parent[rootQ] = rootP
+	uf.size[rootP] += uf.size[rootQ]
+	// splice the two member lists into one
+	uf.next[rootP], uf.next[rootQ] = uf.next[rootQ], uf.next[rootP]
 	uf.count--
+
+	if uf.rollback {
+		uf.history = append(uf.history, link{child: rootQ, root: rootP, rankRaised: rankRaised})
+	}
+	return true
+}
+
+// Checkpoint
+// Returns the number of unions that can be undone, to pass to RollbackTo later.
+func (uf *UF) Checkpoint() int {
+	uf.checkRollback()
+	return len(uf.history)
+}
+
+// Undo
+// Undoes the last union that merged two sets, and returns false if there is none.
+func (uf *UF) Undo() bool {
+	uf.checkRollback()
+	if len(uf.history) == 0 {
+		return false
+	}
+
+	l := uf.history[len(uf.history)-1]
+	uf.history = uf.history[:len(uf.history)-1]
+
+	uf.parent[l.child] = l.child
+	uf.size[l.root] -= uf.size[l.child]
+	if l.rankRaised {
+		uf.rank[l.root]--
+	}
+	// splicing again splits the member lists
+	uf.next[l.root], uf.next[l.child] = uf.next[l.child], uf.next[l.root]
+	uf.count++
+	return true
+}
+
+// RollbackTo
+// Undoes the unions done since the checkpoint.
+func (uf *UF) RollbackTo(checkpoint int) {
+	uf.checkRollback()
+	if checkpoint < 0 || checkpoint > len(uf.history) {
+		log.Fatalf("checkpoint %d is not between 0 and %d", checkpoint, len(uf.history))
+	}
+
+	for len(uf.history) > checkpoint {
+		uf.Undo()
+	}
+}
+
+// adds a new element n in a set of its own, n being the old number of elements
+func (uf *UF) grow() {
+	n := len(uf.parent)
+	uf.parent = append(uf.parent, n)
+	uf.rank = append(uf.rank, 0)
+	uf.size = append(uf.size, 1)
+	uf.next = append(uf.next, n)
+	uf.count++
+}
+
+func (uf *UF) checkRollback() {
+	if !uf.rollback {
+		log.Fatalln("union-find was not created with rollback")
+	}
 }
 
-func (uf *UF) validate (p int) {
+func (uf *UF) validate(p int) {
 	n := len(uf.parent)
 	if p < 0 || p >= n {
-		log.Fatalf("index %d is not between 0 and %d", p, n-1 )
+		log.Fatalf("index %d is not between 0 and %d", p, n-1)
 	}
 }
@@ -0,0 +1,235 @@
+package UF
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"os"
+	"os/exec"
+	"sort"
+	"testing"
+)
+
+// the components of the graph on n vertices with the given edges, found by breadth-first
+// search: component[v] is the smallest vertex of the component of v
+func components(n int, edges [][2]int) []int {
+	adj := make([][]int, n)
+	for _, e := range edges {
+		adj[e[0]] = append(adj[e[0]], e[1])
+		adj[e[1]] = append(adj[e[1]], e[0])
+	}
+
+	component := make([]int, n)
+	for v := range component {
+		component[v] = -1
+	}
+	for s := 0; s < n; s++ {
+		if component[s] != -1 {
+			continue
+		}
+		component[s] = s
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range adj[v] {
+				if component[w] == -1 {
+					component[w] = s
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+	return component
+}
+
+// the members of every component, in increasing order, by smallest member
+func membersOf(component []int) map[int][]int {
+	members := make(map[int][]int)
+	for v, c := range component {
+		members[c] = append(members[c], v)
+	}
+	return members
+}
+
+func sorted(a []int) []int {
+	b := append([]int(nil), a...)
+	sort.Ints(b)
+	return b
+}
+
+// check every query of uf against the components
+func requireComponents(t *testing.T, uf *UF, component []int) {
+	members := membersOf(component)
+	require.Equal(t, len(members), uf.Count())
+
+	for p := range component {
+		require.Equal(t, len(members[component[p]]), uf.Size(p))
+		require.Equal(t, uf.Find(component[p]), uf.Find(p))
+
+		m := uf.Members(p)
+		require.Equal(t, p, m[0])
+		require.Equal(t, members[component[p]], sorted(m))
+	}
+
+	components := uf.Components()
+	require.Len(t, components, len(members))
+	for i, c := range components {
+		if i > 0 {
+			require.Less(t, components[i-1][0], c[0])
+		}
+		require.Equal(t, c[0], component[c[0]])
+		require.Equal(t, members[c[0]], sorted(c))
+	}
+}
+
+func TestCase1(t *testing.T) {
+	// every linking rule with every path shortening, against breadth-first search
+	const n = 60
+	for _, linking := range []Linking{ByRank, BySize} {
+		for _, compression := range []Compression{PathHalving, PathSplitting, PathCompression, NoCompression} {
+			r := rand.New(rand.NewSource(int64(linking)*10 + int64(compression)))
+			uf := NewUF(n, WithLinking(linking), WithCompression(compression))
+			var edges [][2]int
+			for i := 0; i < 2*n; i++ {
+				p, q := r.Intn(n), r.Intn(n)
+				before := components(n, edges)
+				edges = append(edges, [2]int{p, q})
+				require.Equal(t, before[p] != before[q], uf.Union(p, q))
+
+				component := components(n, edges)
+				for j := 0; j < 20; j++ {
+					v, w := r.Intn(n), r.Intn(n)
+					require.Equal(t, component[v] == component[w], uf.Connected(v, w))
+				}
+				if i%10 == 0 {
+					requireComponents(t, uf, component)
+				}
+			}
+			requireComponents(t, uf, components(n, edges))
+		}
+	}
+}
+
+func TestCase2(t *testing.T) {
+	uf := NewUF(5)
+	require.Equal(t, [][]int{{0}, {1}, {2}, {3}, {4}}, uf.Components())
+	require.True(t, uf.Union(3, 1))
+	require.True(t, uf.Union(1, 4))
+	require.False(t, uf.Union(4, 3))
+	require.Equal(t, 3, uf.Count())
+	require.Equal(t, 3, uf.Size(4))
+	require.Equal(t, 1, uf.Size(2))
+	require.Equal(t, []int{1, 3, 4}, sorted(uf.Members(4)))
+	components := uf.Components()
+	require.Len(t, components, 3)
+	require.Equal(t, []int{0}, components[0])
+	require.Equal(t, []int{1, 3, 4}, sorted(components[1]))
+	require.Equal(t, []int{2}, components[2])
+
+	empty := NewUF(0)
+	require.Equal(t, 0, empty.Count())
+	require.Empty(t, empty.Components())
+}
+
+func TestCase3(t *testing.T) {
+	// undoing restores the count, the sizes and the member lists of every checkpoint
+	const n = 40
+	for _, linking := range []Linking{ByRank, BySize} {
+		r := rand.New(rand.NewSource(int64(linking)))
+		uf := NewUF(n, WithLinking(linking), WithRollback())
+		require.False(t, uf.Undo())
+
+		var edges [][2]int
+		var checkpoints []int
+		var saved [][][2]int
+		for i := 0; i < 3*n; i++ {
+			if r.Intn(10) == 0 {
+				checkpoints = append(checkpoints, uf.Checkpoint())
+				saved = append(saved, append([][2]int(nil), edges...))
+			}
+			p, q := r.Intn(n), r.Intn(n)
+			if uf.Union(p, q) {
+				// only unions that merged two sets are undone
+				edges = append(edges, [2]int{p, q})
+			}
+		}
+		requireComponents(t, uf, components(n, edges))
+
+		// undo one union, then roll back to every checkpoint in turn
+		require.True(t, uf.Undo())
+		edges = edges[:len(edges)-1]
+		requireComponents(t, uf, components(n, edges))
+		for i := len(checkpoints) - 1; i >= 0; i-- {
+			if checkpoints[i] > uf.Checkpoint() {
+				continue
+			}
+			uf.RollbackTo(checkpoints[i])
+			requireComponents(t, uf, components(n, saved[i]))
+		}
+
+		uf.RollbackTo(0)
+		require.Equal(t, n, uf.Count())
+		require.False(t, uf.Undo())
+	}
+}
+
+func TestCase4(t *testing.T) {
+	// rollback cannot undo path compression: NewUF exits, which only a separate process can see
+	if os.Getenv("UF_FATAL") == "1" {
+		NewUF(1, WithCompression(PathHalving), WithRollback())
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCase4$")
+	cmd.Env = append(os.Environ(), "UF_FATAL=1")
+	out, err := cmd.CombinedOutput()
+	require.IsType(t, (*exec.ExitError)(nil), err)
+	require.Contains(t, string(out), "rollback cannot undo path compression")
+
+	// without compression asked for, rollback is fine
+	uf := NewUF(2, WithCompression(NoCompression), WithRollback())
+	require.True(t, uf.Union(0, 1))
+	require.True(t, uf.Undo())
+}
+
+func TestCase5(t *testing.T) {
+	s := NewSymbolUF[string]()
+
+	// queries do not add keys
+	require.Equal(t, "a", s.Find("a"))
+	require.True(t, s.Connected("a", "a"))
+	require.False(t, s.Connected("a", "b"))
+	require.Equal(t, 1, s.Size("a"))
+	require.Equal(t, []string{"a"}, s.Members("a"))
+	require.Equal(t, 0, s.Len())
+	require.False(t, s.Contains("a"))
+
+	require.True(t, s.Union("a", "b"))
+	require.True(t, s.Union("c", "b"))
+	require.Equal(t, 0, s.Add("a"))
+	require.Equal(t, 3, s.Add("d"))
+	require.Equal(t, 4, s.Len())
+	require.Equal(t, 2, s.Count())
+	require.True(t, s.Connected("a", "c"))
+	require.False(t, s.Connected("a", "d"))
+	require.False(t, s.Connected("a", "e"))
+	require.Equal(t, 3, s.Size("c"))
+	require.Equal(t, s.Find("a"), s.Find("c"))
+	require.Equal(t, "c", s.Members("c")[0])
+	require.ElementsMatch(t, []string{"a", "b", "c"}, s.Members("c"))
+	require.Len(t, s.Components(), 2)
+	require.Equal(t, 4, s.Len())
+
+	r := NewSymbolUF[int](WithRollback())
+	checkpoint := r.Checkpoint()
+	r.Union(1, 2)
+	r.Union(3, 4)
+	r.Union(2, 3)
+	require.Equal(t, 4, r.Size(1))
+	require.True(t, r.Undo())
+	require.False(t, r.Connected(1, 4))
+	r.RollbackTo(checkpoint)
+	require.Equal(t, 4, r.Count())
+	require.Equal(t, 4, r.Len())
+}
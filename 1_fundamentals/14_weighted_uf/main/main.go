@@ -0,0 +1,47 @@
+package main
+
+import (
+	weightedUF "github.com/lee-hen/Algorithms/1_fundamentals/14_weighted_uf"
+
+	"fmt"
+)
+
+// reads n, then triples p q d, each the measured offset x[p] - x[q] = d between the clocks
+// of machines p and q, and prints the offsets from machine 0 that are known so far
+// 5
+// 1 0 3
+// 2 1 -5
+// 3 4 7
+// 2 0 -2
+// 2 0 4
+// 4 0 1
+func main() {
+	var n int
+	_, err := fmt.Scan(&n)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	uf := weightedUF.NewWeightedUF(n)
+
+	for {
+		var p, q int
+		var d int64
+		if _, err = fmt.Scan(&p, &q, &d); err != nil {
+			break
+		}
+
+		if _, err = uf.Union(p, q, d); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		for v := 0; v < n; v++ {
+			if diff, ok := uf.Diff(v, 0); ok {
+				fmt.Printf("%d:%+d ", v, diff)
+			} else {
+				fmt.Printf("%d:? ", v)
+			}
+		}
+		fmt.Println()
+	}
+}
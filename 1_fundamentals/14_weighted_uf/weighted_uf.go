@@ -0,0 +1,150 @@
+package weighted_uf
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Weighted (potential) union-find over the sites 0 through n-1, for systems of constraints
+// x[p] - x[q] = d, such as the offsets between the clocks of machines. Every site keeps the
+// difference between its value and the value of its parent, so that the difference from a site
+// to its root is the sum along its path, and two sites in the same tree have a known
+// difference. Path compression rewrites those sums as it points the path at the root, and
+// linking by rank keeps the trees low, so an operation takes O(α(n)) amortized, as in UF.
+// A constraint between two sites already in the same tree either agrees with the known
+// difference, and adds nothing, or conflicts with it, and is rejected.
+
+var ErrConflict = errors.New("constraint conflicts with the constraints already added")
+
+type WeightedUF struct {
+	count     int
+	rank      []byte  // rank[i] = rank of subtree rooted at i (never more than 31)
+	size      []int   // size[i] = number of sites in subtree rooted at i
+	parent    []int   // parent[i] = parent of i
+	potential []int64 // potential[i] = x[i] - x[parent[i]]
+}
+
+// NewWeightedUF
+// Initializes an empty weighted union-find data structure with n elements 0 through n-1.
+// Initially, each element is in its own set, with no constraints.
+func NewWeightedUF(n int) *WeightedUF {
+	if n < 0 {
+		log.Fatalln("number of elements must be nonnegative")
+	}
+
+	parent := make([]int, n, n)
+	rank := make([]byte, n, n)
+	size := make([]int, n, n)
+	for i := 0; i < n; i++ {
+		parent[i] = i
+		size[i] = 1
+	}
+	return &WeightedUF{
+		count:     n,
+		parent:    parent,
+		rank:      rank,
+		size:      size,
+		potential: make([]int64, n, n),
+	}
+}
+
+// Find
+// Returns the canonical element of the set containing element p.
+func (uf *WeightedUF) Find(p int) int {
+	root, _ := uf.find(p)
+	return root
+}
+
+// returns the root of p and x[p] - x[root], pointing the path from p at the root
+func (uf *WeightedUF) find(p int) (int, int64) {
+	uf.validate(p)
+
+	root, sum := p, int64(0)
+	for root != uf.parent[root] {
+		sum += uf.potential[root]
+		root = uf.parent[root]
+	}
+
+	// path compression: each site on the path gets the rest of the sum
+	rest := sum
+	for p != root {
+		next, potential := uf.parent[p], uf.potential[p]
+		uf.parent[p], uf.potential[p] = root, rest
+		rest -= potential
+		p = next
+	}
+	return root, sum
+}
+
+// Count
+// Returns the number of sets.
+func (uf *WeightedUF) Count() int {
+	return uf.count
+}
+
+// Connected
+// Returns true if the two elements are in the same set.
+func (uf *WeightedUF) Connected(p, q int) bool {
+	return uf.Find(p) == uf.Find(q)
+}
+
+// Size
+// Returns the number of elements in the set containing element p.
+func (uf *WeightedUF) Size(p int) int {
+	return uf.size[uf.Find(p)]
+}
+
+// Diff
+// Returns x[p] - x[q] as implied by the constraints, and false if the constraints do not
+// determine it because the two elements are in different sets.
+func (uf *WeightedUF) Diff(p, q int) (int64, bool) {
+	rootP, diffP := uf.find(p)
+	rootQ, diffQ := uf.find(q)
+	if rootP != rootQ {
+		return 0, false
+	}
+	return diffP - diffQ, true
+}
+
+// Union
+// Adds the constraint x[p] - x[q] = d, merging the set containing element p with the set
+// containing element q, and returns false if they were already the same set. If they were,
+// and the constraint conflicts with the known difference, it returns ErrConflict and the
+// constraint is not added.
+func (uf *WeightedUF) Union(p, q int, d int64) (bool, error) {
+	rootP, diffP := uf.find(p)
+	rootQ, diffQ := uf.find(q)
+
+	if rootP == rootQ {
+		if diffP-diffQ != d {
+			return false, fmt.Errorf("%w: x[%d] - x[%d] = %d, not %d", ErrConflict, p, q, diffP-diffQ, d)
+		}
+		return false, nil
+	}
+
+	// x[p] = x[rootP] + diffP and x[q] = x[rootQ] + diffQ, so x[p] - x[q] = d gives the offset
+	// x[rootQ] - x[rootP]
+	offset := diffP - diffQ - d
+	if uf.rank[rootP] < uf.rank[rootQ] {
+		uf.parent[rootP] = rootQ
+		uf.potential[rootP] = -offset
+		uf.size[rootQ] += uf.size[rootP]
+	} else {
+		uf.parent[rootQ] = rootP
+		uf.potential[rootQ] = offset
+		uf.size[rootP] += uf.size[rootQ]
+		if uf.rank[rootP] == uf.rank[rootQ] {
+			uf.rank[rootP]++
+		}
+	}
+	uf.count--
+	return true, nil
+}
+
+func (uf *WeightedUF) validate(p int) {
+	n := len(uf.parent)
+	if p < 0 || p >= n {
+		log.Fatalf("index %d is not between 0 and %d", p, n-1)
+	}
+}
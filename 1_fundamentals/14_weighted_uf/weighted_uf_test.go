@@ -0,0 +1,100 @@
+package weighted_uf
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	uf := NewWeightedUF(3)
+	merged, err := uf.Union(1, 0, 3)
+	require.True(t, merged)
+	require.NoError(t, err)
+	merged, err = uf.Union(2, 1, -5)
+	require.True(t, merged)
+	require.NoError(t, err)
+
+	diff, ok := uf.Diff(2, 0)
+	require.True(t, ok)
+	require.Equal(t, int64(-2), diff)
+	diff, ok = uf.Diff(0, 2)
+	require.True(t, ok)
+	require.Equal(t, int64(2), diff)
+
+	merged, err = uf.Union(2, 0, -2)
+	require.False(t, merged)
+	require.NoError(t, err)
+	merged, err = uf.Union(2, 0, 4)
+	require.False(t, merged)
+	require.ErrorIs(t, err, ErrConflict)
+	require.Equal(t, 1, uf.Count())
+}
+
+func TestCase2(t *testing.T) {
+	// random constraints on hidden values: the consistent ones are taken, and the ones that
+	// contradict what is known are rejected
+	const n = 100
+	for seed := int64(1); seed <= 5; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		x := make([]int64, n)
+		for i := range x {
+			x[i] = r.Int63n(2000) - 1000
+		}
+
+		// label[p] = the set of p, relabelled in full on every merge
+		label := make([]int, n)
+		for i := range label {
+			label[i] = i
+		}
+		count := n
+
+		uf := NewWeightedUF(n)
+		for i := 0; i < 1000; i++ {
+			p, q := r.Intn(n), r.Intn(n)
+			d := x[p] - x[q]
+			connected := label[p] == label[q]
+
+			// a wrong difference can only be caught between sites already in the same set
+			if connected && r.Intn(3) == 0 {
+				_, err := uf.Union(p, q, d+1+r.Int63n(50))
+				require.ErrorIs(t, err, ErrConflict)
+			} else {
+				merged, err := uf.Union(p, q, d)
+				require.NoError(t, err)
+				require.Equal(t, !connected, merged)
+				if !connected {
+					old := label[q]
+					for v := range label {
+						if label[v] == old {
+							label[v] = label[p]
+						}
+					}
+					count--
+				}
+			}
+			require.Equal(t, count, uf.Count())
+
+			for j := 0; j < 10; j++ {
+				v, w := r.Intn(n), r.Intn(n)
+				diff, ok := uf.Diff(v, w)
+				require.Equal(t, label[v] == label[w], ok)
+				require.Equal(t, label[v] == label[w], uf.Connected(v, w))
+				if ok {
+					require.Equal(t, x[v]-x[w], diff)
+				} else {
+					require.Zero(t, diff)
+				}
+			}
+		}
+
+		sizes := make(map[int]int)
+		for _, l := range label {
+			sizes[l]++
+		}
+		for v := 0; v < n; v++ {
+			require.Equal(t, sizes[label[v]], uf.Size(v))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package percolation
+
+import (
+	UF "github.com/lee-hen/Algorithms/1_fundamentals/12_uf"
+	"github.com/lee-hen/Algorithms/util"
+
+	"log"
+	"math"
+)
+
+// Site percolation on an n-by-n grid whose sites are open independently with probability p
+// has a threshold p* ≈ 0.5927: below it the system almost never percolates for large n, and
+// above it almost always does. Opening the sites one at a time in random order until the
+// system percolates, the fraction of sites open estimates p*.
+// In the random graph with n vertices and cn/2 random edges (Erdős–Rényi), the largest
+// component has O(log n) vertices for average degree c < 1, and a fraction s of them for
+// c > 1, where s is the positive root of s = 1 - e^(-cs), the giant component. The graph
+// becomes connected after about ½ n ln n random edges.
+
+// PercolationThreshold
+// Estimates the percolation threshold of the n-by-n grid over the given number of trials.
+func PercolationThreshold(n, trials int, opts ...Option) Stats {
+	return Run(trials, func(r *util.Random) float64 {
+		return Threshold(n, r)
+	}, opts...)
+}
+
+// Threshold
+// Opens random sites of an n-by-n grid until it percolates, and returns the fraction of
+// sites open.
+func Threshold(n int, r *util.Random) float64 {
+	p := NewPercolation(n)
+	for _, site := range r.Perm(n * n) {
+		p.Open(site/n, site%n)
+		if p.Percolates() {
+			break
+		}
+	}
+	return float64(p.NumberOfOpenSites()) / float64(n*n)
+}
+
+// ErdosRenyiConnections
+// Estimates the number of random connections between n sites it takes to connect them all,
+// over the given number of trials.
+func ErdosRenyiConnections(n, trials int, opts ...Option) Stats {
+	return Run(trials, func(r *util.Random) float64 {
+		return float64(Connections(n, r))
+	}, opts...)
+}
+
+// Connections
+// Connects random pairs of the n sites, as in exercise 1.5.17, until all are connected, and
+// returns the number of pairs generated.
+func Connections(n int, r *util.Random) int {
+	if n <= 0 {
+		log.Fatalln("number of sites must be positive")
+	}
+
+	edges := 0
+	uf := UF.NewUF(n)
+	for uf.Count() > 1 {
+		uf.Union(r.Intn(n), r.Intn(n))
+		edges++
+	}
+	return edges
+}
+
+// GiantComponent
+// Estimates the fraction of the n vertices in the largest component of the random graph
+// with average degree c, over the given number of trials.
+func GiantComponent(n int, c float64, trials int, opts ...Option) Stats {
+	return Run(trials, func(r *util.Random) float64 {
+		return LargestComponent(n, c, r)
+	}, opts...)
+}
+
+// LargestComponent
+// Adds round(cn/2) random edges to n isolated vertices, and returns the fraction of the
+// vertices in the largest component.
+func LargestComponent(n int, c float64, r *util.Random) float64 {
+	if n <= 0 {
+		log.Fatalln("number of vertices must be positive")
+	}
+	if c < 0 {
+		log.Fatalln("average degree must be nonnegative")
+	}
+
+	uf := UF.NewUF(n)
+	largest := 1
+	for e := int(math.Round(c * float64(n) / 2)); e > 0; e-- {
+		v := r.Intn(n)
+		if uf.Union(v, r.Intn(n)) {
+			largest = max(largest, uf.Size(v))
+		}
+	}
+	return float64(largest) / float64(n)
+}
+
+// GiantComponentFraction
+// Returns the fraction of the vertices in the giant component of a large random graph with
+// average degree c: the positive root of s = 1 - e^(-cs), or 0 if c <= 1.
+func GiantComponentFraction(c float64) float64 {
+	if c <= 1 {
+		return 0
+	}
+	s := 1.0
+	for i := 0; i < 1000; i++ {
+		next := 1 - math.Exp(-c*s)
+		if math.Abs(next-s) < 1e-15 {
+			return next
+		}
+		s = next
+	}
+	return s
+}
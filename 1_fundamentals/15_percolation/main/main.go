@@ -0,0 +1,52 @@
+package main
+
+import (
+	percolation "github.com/lee-hen/Algorithms/1_fundamentals/15_percolation"
+
+	"fmt"
+	"os"
+)
+
+// reads a seed and a number of trials, and writes the results of the experiments as CSV
+// 1
+// 100
+// the percolation threshold comes out near 0.593 for all n, the connections near ½ n ln n,
+// and the giant component near GiantComponentFraction(c): 0 for c <= 1, then 0.314 0.583
+// 0.797 0.940
+func main() {
+	var seed int64
+	var trials int
+	if _, err := fmt.Scan(&seed, &trials); err != nil {
+		fmt.Println(err)
+		return
+	}
+	opts := []percolation.Option{percolation.WithSeed(seed)}
+
+	var results []percolation.Result
+	for _, n := range []int{10, 20, 50, 100} {
+		results = append(results, percolation.Result{
+			Experiment: "percolation threshold",
+			N:          n,
+			Stats:      percolation.PercolationThreshold(n, trials, opts...),
+		})
+	}
+	for _, n := range []int{100, 1000, 10000} {
+		results = append(results, percolation.Result{
+			Experiment: "erdos-renyi connections",
+			N:          n,
+			Stats:      percolation.ErdosRenyiConnections(n, trials, opts...),
+		})
+	}
+	for _, c := range []float64{0.5, 1, 1.2, 1.5, 2, 3} {
+		results = append(results, percolation.Result{
+			Experiment: "giant component",
+			N:          10000,
+			Parameter:  c,
+			Stats:      percolation.GiantComponent(10000, c, trials, opts...),
+		})
+	}
+
+	if err := percolation.WriteCSV(os.Stdout, results); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,146 @@
+package percolation
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"encoding/csv"
+	"io"
+	"log"
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// A Monte Carlo experiment repeats a random trial T times and estimates the mean of its
+// outcome by the sample mean x̄, with the sample standard deviation s and the 95% confidence
+// interval x̄ ± 1.96 s / √T, which holds for large T by the central limit theorem.
+// The trials run in parallel goroutines, each with its own source of randomness seeded from
+// the seed of the experiment and the number of the trial, so that the outcome of an
+// experiment depends on its seed alone, and not on how the trials were scheduled.
+
+// Stats
+// The outcome of a Monte Carlo experiment.
+type Stats struct {
+	Trials int
+	Seed   int64   // the seed that replays the experiment
+	Mean   float64 // sample mean
+	StdDev float64 // sample standard deviation
+	Lo, Hi float64 // the 95% confidence interval of the mean
+}
+
+// Option
+// Configures a Monte Carlo experiment.
+type Option func(*options)
+
+type options struct {
+	seed    int64
+	workers int
+}
+
+// WithSeed
+// Seeds the experiment, so that its outcome is reproducible (default drawn from util.Default()).
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithWorkers
+// Sets the number of goroutines that run the trials (default GOMAXPROCS).
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		if workers < 1 {
+			log.Fatalln("number of workers must be at least 1")
+		}
+		o.workers = workers
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{seed: util.Default().Int63(), workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Run
+// Runs trials independent trials and returns the statistics of their outcomes.
+func Run(trials int, trial func(r *util.Random) float64, opts ...Option) Stats {
+	if trials < 2 {
+		log.Fatalln("number of trials must be at least 2")
+	}
+	o := newOptions(opts)
+
+	outcomes := make([]float64, trials)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(o.workers, trials); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := int(next.Add(1) - 1); t < trials; t = int(next.Add(1) - 1) {
+				outcomes[t] = trial(util.NewRandom(trialSeed(o.seed, t)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := Stats{Trials: trials, Seed: o.seed}
+	for _, x := range outcomes {
+		stats.Mean += x
+	}
+	stats.Mean /= float64(trials)
+	for _, x := range outcomes {
+		stats.StdDev += (x - stats.Mean) * (x - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(stats.StdDev / float64(trials-1))
+	halfWidth := 1.96 * stats.StdDev / math.Sqrt(float64(trials))
+	stats.Lo, stats.Hi = stats.Mean-halfWidth, stats.Mean+halfWidth
+	return stats
+}
+
+// the seed of trial t, scrambled (splitmix64) so that nearby seeds give unrelated streams
+func trialSeed(seed int64, t int) int64 {
+	z := uint64(seed) + uint64(t+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
+
+// Result
+// The outcome of one experiment of a series, for WriteCSV.
+type Result struct {
+	Experiment string
+	N          int
+	Parameter  float64 // the parameter of the experiment besides N, if any
+	Stats
+}
+
+// WriteCSV
+// Writes the results as CSV, one per line after a header line.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"experiment", "n", "parameter", "trials", "seed", "mean", "stddev", "lo", "hi"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		record := []string{
+			r.Experiment,
+			strconv.Itoa(r.N),
+			strconv.FormatFloat(r.Parameter, 'g', -1, 64),
+			strconv.Itoa(r.Trials),
+			strconv.FormatInt(r.Seed, 10),
+		}
+		for _, x := range []float64{r.Mean, r.StdDev, r.Lo, r.Hi} {
+			record = append(record, strconv.FormatFloat(x, 'g', 8, 64))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
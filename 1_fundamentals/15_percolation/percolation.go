@@ -0,0 +1,109 @@
+package percolation
+
+import (
+	UF "github.com/lee-hen/Algorithms/1_fundamentals/12_uf"
+
+	"log"
+)
+
+// An n-by-n grid of sites, each open or blocked, percolates if some open site in the bottom
+// row is full, that is, connected to an open site in the top row through a chain of open
+// sites, each next to the one before. Union-find keeps the open sites connected to their open
+// neighbors, with a virtual site above the top row and one below the bottom row, so that the
+// system percolates exactly when the two virtual sites are connected.
+// The virtual bottom site would make an open bottom site full as soon as any bottom site is
+// (backwash), so a second union-find, without the bottom site, answers IsFull.
+
+type Percolation struct {
+	n         int
+	open      []bool // open[row*n+col] = is the site open?
+	openSites int
+	uf        *UF.UF // the sites, the virtual top site n*n and the virtual bottom site n*n+1
+	full      *UF.UF // the sites and the virtual top site, without backwash
+}
+
+// NewPercolation
+// Initializes an n-by-n grid with all sites blocked.
+func NewPercolation(n int) *Percolation {
+	if n <= 0 {
+		log.Fatalln("grid size must be positive")
+	}
+	return &Percolation{
+		n:    n,
+		open: make([]bool, n*n),
+		uf:   UF.NewUF(n*n + 2),
+		full: UF.NewUF(n*n + 1),
+	}
+}
+
+// N
+// Returns the size of the grid.
+func (p *Percolation) N() int {
+	return p.n
+}
+
+// Open
+// Opens the site (row, col), rows and columns counting from 0 at the top left, if it is not
+// open already.
+func (p *Percolation) Open(row, col int) {
+	p.validate(row, col)
+	site := p.site(row, col)
+	if p.open[site] {
+		return
+	}
+	p.open[site] = true
+	p.openSites++
+
+	top, bottom := p.n*p.n, p.n*p.n+1
+	if row == 0 {
+		p.uf.Union(site, top)
+		p.full.Union(site, top)
+	}
+	if row == p.n-1 {
+		p.uf.Union(site, bottom)
+	}
+	for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		r, c := row+d[0], col+d[1]
+		if r < 0 || r >= p.n || c < 0 || c >= p.n || !p.open[p.site(r, c)] {
+			continue
+		}
+		p.uf.Union(site, p.site(r, c))
+		p.full.Union(site, p.site(r, c))
+	}
+}
+
+// IsOpen
+// Returns true if the site (row, col) is open.
+func (p *Percolation) IsOpen(row, col int) bool {
+	p.validate(row, col)
+	return p.open[p.site(row, col)]
+}
+
+// IsFull
+// Returns true if the site (row, col) is open and connected to an open site in the top row.
+func (p *Percolation) IsFull(row, col int) bool {
+	p.validate(row, col)
+	return p.full.Connected(p.site(row, col), p.n*p.n)
+}
+
+// NumberOfOpenSites
+// Returns the number of open sites.
+func (p *Percolation) NumberOfOpenSites() int {
+	return p.openSites
+}
+
+// Percolates
+// Returns true if the system percolates.
+func (p *Percolation) Percolates() bool {
+	return p.uf.Connected(p.n*p.n, p.n*p.n+1)
+}
+
+func (p *Percolation) site(row, col int) int {
+	return row*p.n + col
+}
+
+func (p *Percolation) validate(row, col int) {
+	if row < 0 || row >= p.n || col < 0 || col >= p.n {
+		log.Fatalf("site (%d, %d) is not in the %d-by-%d grid", row, col, p.n, p.n)
+	}
+}
@@ -0,0 +1,91 @@
+package percolation
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"strings"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	p := NewPercolation(1)
+	require.False(t, p.Percolates())
+	require.False(t, p.IsFull(0, 0))
+	p.Open(0, 0)
+	require.True(t, p.IsOpen(0, 0))
+	require.True(t, p.IsFull(0, 0))
+	require.True(t, p.Percolates())
+	require.Equal(t, 1, p.NumberOfOpenSites())
+}
+
+func TestCase2(t *testing.T) {
+	// an open column percolates, from the bottom up as well
+	const n = 5
+	p := NewPercolation(n)
+	for row := n - 1; row >= 0; row-- {
+		require.False(t, p.Percolates())
+		p.Open(row, 2)
+		p.Open(row, 2)
+	}
+	require.True(t, p.Percolates())
+	require.Equal(t, n, p.NumberOfOpenSites())
+	for row := 0; row < n; row++ {
+		for col := 0; col < n; col++ {
+			require.Equal(t, col == 2, p.IsOpen(row, col))
+			require.Equal(t, col == 2, p.IsFull(row, col))
+		}
+	}
+}
+
+func TestCase3(t *testing.T) {
+	// an open bottom site is not full through the bottom row once the system percolates
+	p := NewPercolation(3)
+	for row := 0; row < 3; row++ {
+		p.Open(row, 0)
+	}
+	p.Open(2, 2)
+	p.Open(1, 2)
+	require.True(t, p.Percolates())
+	require.True(t, p.IsFull(2, 0))
+	require.True(t, p.IsOpen(2, 2))
+	require.False(t, p.IsFull(2, 2))
+	require.False(t, p.IsFull(1, 2))
+
+	// until a path from the top reaches it
+	p.Open(0, 2)
+	require.True(t, p.IsFull(2, 2))
+}
+
+func TestCase4(t *testing.T) {
+	// the outcome depends on the seed alone, not on the number of workers
+	one := PercolationThreshold(10, 40, WithSeed(7), WithWorkers(1))
+	many := PercolationThreshold(10, 40, WithSeed(7), WithWorkers(8))
+	require.Equal(t, one, many)
+	require.Equal(t, int64(7), one.Seed)
+	require.Equal(t, 40, one.Trials)
+	require.Less(t, one.Lo, one.Mean)
+	require.Less(t, one.Mean, one.Hi)
+	require.NotEqual(t, one, PercolationThreshold(10, 40, WithSeed(8), WithWorkers(8)))
+
+	one = GiantComponent(1000, 2, 20, WithSeed(1), WithWorkers(1))
+	require.Equal(t, one, GiantComponent(1000, 2, 20, WithSeed(1), WithWorkers(3)))
+	require.InDelta(t, GiantComponentFraction(2), one.Mean, 0.05)
+
+	// a trial sees the same stream of random numbers under any schedule
+	trial := func(r *util.Random) float64 { return r.Float64() }
+	require.Equal(t, Run(100, trial, WithSeed(3), WithWorkers(1)), Run(100, trial, WithSeed(3), WithWorkers(16)))
+}
+
+func TestCase5(t *testing.T) {
+	var b strings.Builder
+	err := WriteCSV(&b, []Result{{
+		Experiment: "giant component",
+		N:          100,
+		Parameter:  1.5,
+		Stats:      Stats{Trials: 10, Seed: -7, Mean: 0.5, StdDev: 0.25, Lo: 1.0 / 3, Hi: 0.75},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "experiment,n,parameter,trials,seed,mean,stddev,lo,hi\n"+
+		"giant component,100,1.5,10,-7,0.5,0.25,0.33333333,0.75\n", b.String())
+}
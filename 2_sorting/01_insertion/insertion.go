@@ -1,13 +1,7 @@
-package main
+package insertion
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // Proposition B. Insertion sort uses ~n2/4 compares and ~n2/4 exchanges to sort a randomly ordered array of length n
@@ -38,38 +32,19 @@ import (
 // as command-line arguments to perform the given number of experiments (sorting arrays of the given size) and prints
 // the ratio of the observed running times of the algorithms.
 
-func Sort(a []int, less func (i, j int) bool) {
-	n := len(a)
-	for i := 1; i < n; i++ {
-		for j := i; j > 0 && less(j, j-1); j-- {
-			exchange(a, j, j-1)
-		}
-	}
-}
-
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
+// Sort
+// Rearranges the array in ascending order as defined by less.
+func Sort(a []int, less func(i, j int) bool) {
+	SortArray(util.LessFunc(a, less))
 }
 
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	n := a.Len()
+	for i := 1; i < n; i++ {
+		for j := i; j > 0 && a.Less(j, j-1); j-- {
+			a.Exch(j, j-1)
 		}
 	}
-
-	Sort(nums, func(i, j int) bool{
-		return nums[i] < nums[j]
-	})
-	fmt.Println(nums)
 }
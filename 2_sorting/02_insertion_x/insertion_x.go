@@ -1,13 +1,7 @@
-package main
+package insertion_x
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // 2.1.23 Deck sort. Ask a few friends to sort a deck of cards (see EXERCISE 2.1.13).
@@ -20,16 +14,22 @@ import (
 // eliminated is known as a sentinel.
 
 // Sort
-// Sorts a sequence of strings from standard input using an optimized
+// Sorts the array using an optimized
 // version of insertion sort that uses half exchanges instead of
 // full exchanges to reduce data movement..
-func Sort(a []int, less func (i, j int) bool) {
-	n := len(a)
+func Sort(a []int, less func(i, j int) bool) {
+	SortArray(util.LessFunc(a, less))
+}
+
+// SortArray
+// As Sort, on an Array: the sentinel pass uses Less and the half exchanges LessValue.
+func SortArray(a util.Array) {
+	n := a.Len()
 	exchanges := 0
 
-	for i := n-1; i > 0; i-- {
-		if less(i, i-1) {
-			exchange(a, i, i-1)
+	for i := n - 1; i > 0; i-- {
+		if a.Less(i, i-1) {
+			a.Exch(i, i-1)
 			exchanges++
 		}
 	}
@@ -45,41 +45,12 @@ func Sort(a []int, less func (i, j int) bool) {
 	// ...
 	// insertion sort with half-exchanges
 	for i := 2; i < n; i++ {
-		v := a[i]
+		v := a.Get(i)
 		j := i
-		for v < a[j-1] {
-			// fmt.Println(a)
-			a[j] = a[j-1]
+		for a.LessValue(v, a.Get(j-1)) {
+			a.Set(j, a.Get(j-1))
 			j--
 		}
-		a[j] = v
+		a.Set(j, v)
 	}
 }
-
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums, func(i, j int) bool{
-		return nums[i] < nums[j]
-	})
-	fmt.Println(nums)
-}
-
@@ -1,58 +1,39 @@
-package main
+package binary_insertion
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
+// Sort
+// Rearranges the array in ascending order, using the natural order.
 func Sort(a []int) {
-	n := len(a)
+	SortArray(util.IntSlice(a))
+}
+
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	n := a.Len()
 
 	// find lo which a[lo] > a[i]
 	// binary search to determine index j at which to insert a[i]
 	for i := 1; i < n; i++ {
-		v := a[i]
+		v := a.Get(i)
 		lo, hi := 0, i
 		for lo < hi {
 			mid := lo + (hi-lo)/2
-			if v < a[mid] {
+			if a.LessValue(v, a.Get(mid)) {
 				hi = mid
 			} else {
-				lo = mid+1
+				lo = mid + 1
 			}
 		}
 
 		// insertion sort with "half exchanges"
 		// (insert a[i] at index j and shift a[j], ..., a[i-1] to right)
 		for j := i; j > lo; j-- {
-			a[j] = a[j-1]
+			a.Set(j, a.Get(j-1))
 		}
-		a[lo] = v
-	}
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
+		a.Set(lo, v)
 	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
 }
@@ -1,13 +1,7 @@
-package main
+package selection
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // Proposition A. Selection sort uses ~N2/2 compares and N exchanges to sort an array of length N.
@@ -18,37 +12,24 @@ import (
 // for each i from 0 to N − 1, there is one exchange and N − 1 − i compares,
 // so the totals are N exchanges and (N − 1) + (N − 2) + . . . + 2 + 1+ 0 = N(N − 1)/2 ~ N2/2 compares.
 
+// Sort
+// Rearranges the array in ascending order, using the natural order.
 func Sort(a []int) {
-	n := len(a)
+	SortArray(util.IntSlice(a))
+}
+
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	n := a.Len()
 	for i := 0; i < n; i++ {
 		min := i
 
-		for j := i + 1; i < n; j++ {
-			if a[min] > a[j] {
+		for j := i + 1; j < n; j++ {
+			if a.Less(j, min) {
 				min = j
 			}
 		}
-		a[min], a[i] = a[i], a[min]
-	}
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
+		a.Exch(i, min)
 	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
 }
@@ -1,13 +1,7 @@
-package main
+package shell
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // The study of the performance characteristics of ShellSort requires mathematical arguments that are beyond the scope of this book.
@@ -28,51 +22,31 @@ import (
 // Extensive experiments suggest that the average number of compares per increment might be N1/5, but it is quite difficult to discern the growth in that function unless N is huge.
 // This property also seems to be rather insensitive to the input model.
 
-func Sort(a []int, less func (i, j int) bool) {
-	n := len(a)
+// Sort
+// Rearranges the array in ascending order as defined by less.
+func Sort(a []int, less func(i, j int) bool) {
+	SortArray(util.LessFunc(a, less))
+}
+
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	n := a.Len()
 
 	h := 1
 	// 3x+1 increment sequence:  1, 4, 13, 40, 121, 364, 1093, ...
 	for h < n/3 {
-		h = 3*h+1
+		h = 3*h + 1
 	}
 
 	for h >= 1 {
 		// h-sort the array
 		for i := h; i < n; i++ {
-			for j := i; j >= h && less(j, j-h); j-=h {
-				exchange(a, j, j-h)
+			for j := i; j >= h && a.Less(j, j-h); j -= h {
+				a.Exch(j, j-h)
 			}
 		}
 
 		h /= 3
 	}
-
-}
-
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums, func(i, j int) bool{
-		return nums[i] < nums[j]
-	})
-	fmt.Println(nums)
 }
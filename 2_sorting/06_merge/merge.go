@@ -1,49 +1,37 @@
-package main
+package merge
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // Top-down mergesort
 
-type intSlice []int
-
-func (s intSlice) less (i, j int) bool {
-	return s[i] < s[j]
-}
-
-func merge(a, aux intSlice, lo, mid, hi int) {
+func merge(a util.Array, aux []int, lo, mid, hi int) {
 	// copy to aux[]
 	for k := lo; k <= hi; k++ {
-		aux[k] = a[k]
+		aux[k] = a.Get(k)
 	}
 
 	// merge back to a[]
 	i, j := lo, mid+1
 	for k := lo; k <= hi; k++ {
 		if i > mid {
-			a[k] = aux[j]
+			a.Set(k, aux[j])
 			j++
 		} else if j > hi {
-			a[k] = aux[i]
+			a.Set(k, aux[i])
 			i++
-		} else if aux.less(j, i) {
-			a[k] = aux[j]
+		} else if a.LessValue(aux[j], aux[i]) {
+			a.Set(k, aux[j])
 			j++
 		} else {
-			a[k] = aux[i]
+			a.Set(k, aux[i])
 			i++
 		}
 	}
 }
 
-func mergeSort(a, aux intSlice, lo, hi int) {
+func mergeSort(a util.Array, aux []int, lo, hi int) {
 	if hi <= lo {
 		return
 	}
@@ -58,27 +46,12 @@ func mergeSort(a, aux intSlice, lo, hi int) {
 // Proposition G. Top-down mergesort uses at most 6N lgN array accesses to sort an array of length N.
 // Proof: Each merge uses at most 6N array accesses (2N for the copy, 2N for the move back, and at most 2N for compares). The result follows from the same argument as for PROPOSITION F.
 func Sort(a []int) {
-	aux := make(intSlice, len(a), len(a))
-	mergeSort(a, aux, 0, len(a)-1)
+	SortArray(util.IntSlice(a))
 }
 
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	aux := make([]int, a.Len())
+	mergeSort(a, aux, 0, a.Len()-1)
 }
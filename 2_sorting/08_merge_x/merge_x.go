@@ -1,77 +1,57 @@
-package main
+package merge_x
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
-type intSlice []int
+// Top-down mergesort that eliminates the copy to the auxiliary array
+// by switching the roles of the input and auxiliary arrays at each level of the recursion.
 
-func (s intSlice) less (i, j int) bool {
-	return s[i] < s[j]
-}
-
-func merge(src, dst intSlice, lo, mid, hi int) {
+// merge src[lo..mid] with src[mid+1..hi] into dst[lo..hi]; compares go through a,
+// which is src or dst
+func merge(a, src, dst util.Array, lo, mid, hi int) {
 	i, j := lo, mid+1
 	for k := lo; k <= hi; k++ {
 		if i > mid {
-			dst[k] = src[j]
+			dst.Set(k, src.Get(j))
 			j++
 		} else if j > hi {
-			dst[k] = src[i]
+			dst.Set(k, src.Get(i))
 			i++
-		} else if src.less(j, i) {
-			dst[k] = src[j]
+		} else if a.LessValue(src.Get(j), src.Get(i)) {
+			dst.Set(k, src.Get(j))
 			j++
 		} else {
-			dst[k] = src[i]
+			dst.Set(k, src.Get(i))
 			i++
 		}
 	}
 }
 
-func mergeSort(src, dst intSlice, lo, hi int) {
+func mergeSort(a, src, dst util.Array, lo, hi int) {
 	if hi <= lo {
 		return
 	}
 
 	mid := lo + (hi-lo)/2
-	mergeSort(dst, src, lo, mid)
-	mergeSort(dst, src, mid+1, hi)
-	merge(src, dst, lo, mid, hi)
+	mergeSort(a, dst, src, lo, mid)
+	mergeSort(a, dst, src, mid+1, hi)
+	merge(a, src, dst, lo, mid, hi)
 }
 
 // Sort
 // Proposition G. Top-down mergesort uses at most 6N lgN array accesses to sort an array of length N.
 // Proof: Each merge uses at most 6N array accesses (2N for the copy, 2N for the move back, and at most 2N for compares). The result follows from the same argument as for PROPOSITION F.
 func Sort(a []int) {
-	aux := make([]int, len(a), len(a))
-	copy(aux, a)
-	mergeSort(aux, a, 0, len(a)-1)
+	SortArray(util.IntSlice(a))
 }
 
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	aux := make(util.IntSlice, a.Len())
+	for i := range aux {
+		aux[i] = a.Get(i)
 	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
+	mergeSort(a, aux, a, 0, a.Len()-1)
 }
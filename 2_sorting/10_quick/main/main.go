@@ -0,0 +1,44 @@
+package main
+
+import (
+	quick "github.com/lee-hen/Algorithms/2_sorting/10_quick"
+
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err == io.EOF {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	str := strings.Split(line[:len(line)-1], " ")
+	nums := make([]int, len(str))
+	for i, s := range str {
+		if nums[i], err = strconv.Atoi(s); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	k := rand.Intn(len(nums))
+
+	fmt.Printf("quick select with random idx %d\n", k)
+	fmt.Println(quick.Select(nums, k))
+
+	fmt.Println("----------------------------------------")
+
+	quick.Sort(nums)
+	fmt.Println(nums)
+}
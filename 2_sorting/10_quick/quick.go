@@ -1,17 +1,7 @@
-package main
+package quick
 
 import (
 	"github.com/lee-hen/Algorithms/util"
-
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"math/rand"
-	"os"
-	"strconv"
-	"strings"
-	"time"
 )
 
 // Sort
@@ -40,7 +30,14 @@ import (
 func Sort(a []int) {
 	util.ShuffleIntSlice(a)
 
-	sort(0, len(a)-1, a)
+	SortArray(util.IntSlice(a))
+}
+
+// SortArray
+// Rearranges the array in ascending order. Unlike Sort it does not shuffle the array first,
+// so the caller shuffles it to get the guarantee of Proposition L.
+func SortArray(a util.Array) {
+	sort(0, a.Len()-1, a)
 }
 
 func Select(a []int, k int) int {
@@ -49,7 +46,7 @@ func Select(a []int, k int) int {
 	lo, hi := 0, len(a)-1
 
 	for hi > lo {
-		pivot := partition(lo, hi, a)
+		pivot := partition(lo, hi, util.IntSlice(a))
 		if pivot > k {
 			hi = pivot-1
 		} else if pivot < k {
@@ -61,7 +58,7 @@ func Select(a []int, k int) int {
 	return a[lo]
 }
 
-func sort(lo, hi int, a []int) {
+func sort(lo, hi int, a util.Array) {
 	if lo >= hi {
 		return
 	}
@@ -71,53 +68,20 @@ func sort(lo, hi int, a []int) {
 	sort(pivot+1, hi, a)
 }
 
-func partition(pivot, hi int, a []int) int {
+func partition(pivot, hi int, a util.Array) int {
 	i, j := pivot, hi+1
-	v := a[pivot]
 
+	// a[pivot] stays in place until the final exchange
 	for {
-		for i = i+1; i < hi && util.Less(a[i], v); i++ {}
-		for j = j-1; j > pivot && util.Less(v, a[j]); j-- {}
+		for i = i+1; i < hi && a.Less(i, pivot); i++ {}
+		for j = j-1; j > pivot && a.Less(pivot, j); j-- {}
 
 		if i >= j {
 			break
 		}
-		exchange(a, i, j)
+		a.Exch(i, j)
 	}
-	exchange(a, pivot, j)
+	a.Exch(pivot, j)
 
 	return j
 }
-
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	k := rand.Intn(len(nums))
-
-	fmt.Printf("quick select with random idx %d\n", k)
-	fmt.Println(Select(nums, k))
-
-	fmt.Println("----------------------------------------")
-
-	Sort(nums)
-	fmt.Println(nums)
-}
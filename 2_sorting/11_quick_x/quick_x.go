@@ -1,22 +1,20 @@
-package main
+package quick_x
 
 import (
 	"github.com/lee-hen/Algorithms/util"
-
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
 )
 
 func Sort(a []int) {
-	sort(0, len(a)-1, a)
+	SortArray(util.IntSlice(a))
+}
+
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(a util.Array) {
+	sort(0, a.Len()-1, a)
 }
 
-func sort(lo, hi int, a []int) {
+func sort(lo, hi int, a util.Array) {
 	if lo >= hi {
 		return
 	}
@@ -26,81 +24,56 @@ func sort(lo, hi int, a []int) {
 	sort(pivot+1, hi, a)
 }
 
-func partition(lo, hi int, a []int) int {
+func partition(lo, hi int, a util.Array) int {
 	n := hi-lo+1
 
 	mid := median3(lo, lo + n/2, hi, a)
-	exchange(a, mid, lo)
+	a.Exch(mid, lo)
 
+	// the partitioning item a[lo] stays in place until the final exchange
 	i, j := lo, hi+1
-	v := a[lo]
 
-	for i = i+1; util.Less(a[i], v); i++ {
+	for i = i+1; a.Less(i, lo); i++ {
 		if i == hi {
-			exchange(a, lo, hi)
+			a.Exch(lo, hi)
 			return hi
 		}
 	}
 
-	for j = j-1; util.Less(v, a[j]); j-- {
+	for j = j-1; a.Less(lo, j); j-- {
 		if j == lo+1 {
 			return lo
 		}
 	}
 
 	for i < j {
-		exchange(a, i, j)
+		a.Exch(i, j)
 
-		for i = i+1; util.Less(a[i], v); i++ {}
-		for j = j-1; util.Less(v, a[j]); j-- {}
+		for i = i+1; a.Less(i, lo); i++ {}
+		for j = j-1; a.Less(lo, j); j-- {}
 	}
-	exchange(a, lo, j)
+	a.Exch(lo, j)
 
 	return j
 }
 
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
 // return the index of the median element among a[i], a[j], and a[k]
-func median3(i, j, k int, a []int) int {
-	if util.Less(a[i], a[j]) {
-		if util.Less(a[j], a[k]) {
+func median3(i, j, k int, a util.Array) int {
+	if a.Less(i, j) {
+		if a.Less(j, k) {
 			return j
-		} else if util.Less(a[i], a[k]) {
+		} else if a.Less(i, k) {
 			return k
 		} else {
 			return i
 		}
 	} else {
-		if util.Less(a[k], a[j]) {
+		if a.Less(k, j) {
 			return j
-		} else if util.Less(a[k], a[i]) {
+		} else if a.Less(k, i) {
 			return k
 		} else {
 			return i
 		}
 	}
 }
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
-}
@@ -1,38 +1,36 @@
-package main
+package quick_three_way
 
 import (
 	"github.com/lee-hen/Algorithms/util"
-
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
 )
 
 func Sort(a []int) {
 	util.ShuffleIntSlice(a)
 
-	sort(0, len(a)-1, a)
+	SortArray(util.IntSlice(a))
+}
+
+// SortArray
+// Rearranges the array in ascending order. Unlike Sort it does not shuffle the array first.
+func SortArray(a util.Array) {
+	sort(0, a.Len()-1, a)
 }
 
-func sort(lo, hi int, a []int) {
+func sort(lo, hi int, a util.Array) {
 	if hi <= lo {
 		return
 	}
 
-	pv := a[lo]
+	pv := a.Get(lo)
 	lt, mid, gt := lo, lo+1, hi
 
 	for mid <= gt {
-		if a[mid] < pv {
-			exchange(a, mid, lt)
+		if a.LessValue(a.Get(mid), pv) {
+			a.Exch(mid, lt)
 			mid++
 			lt++
-		} else if a[mid] > pv {
-			exchange(a, mid, gt)
+		} else if a.LessValue(pv, a.Get(mid)) {
+			a.Exch(mid, gt)
 			gt--
 		} else {
 			mid++
@@ -42,28 +40,3 @@ func sort(lo, hi int, a []int) {
 	sort(lo, lt-1, a)
 	sort(gt+1, hi, a)
 }
-
-func exchange(a []int, i, j int) {
-	a[i], a[j] = a[j], a[i]
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
-}
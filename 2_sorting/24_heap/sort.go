@@ -1,13 +1,7 @@
-package main
+package heap
 
 import (
-	"bufio"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"github.com/lee-hen/Algorithms/util"
 )
 
 // Sort
@@ -15,24 +9,30 @@ import (
 // Proof: The 2 N term covers the cost of heap construction (see PROPOSITION R). The 2 N lg N term follows from bounding the cost of each sink operation during the sortdown by 2lg N (see PROPOSITION PQ).
 // Rearranges the array in ascending order, using the natural order.
 func Sort(pq []int) []int {
+	SortArray(util.IntSlice(pq))
+	return pq
+}
+
+// SortArray
+// Rearranges the array in ascending order.
+func SortArray(pq util.Array) {
 	// heapify phase
-	for k := len(pq)/2; k >= 1; k-- {
-		sink(k, len(pq), pq)
+	for k := pq.Len()/2; k >= 1; k-- {
+		sink(k, pq.Len(), pq)
 	}
 
 	// sortdown phase
-	k := len(pq)
+	k := pq.Len()
 	for k > 1 {
 		exchange(1, k, pq)
 		k--
 		sink(1, k, pq)
 	}
-	return pq
 }
 
 // Helper functions to restore the heap invariant.
 
-func sink(k, n int, pq []int) {
+func sink(k, n int, pq util.Array) {
 	for 2*k <= n {
 		j := 2*k
 		if j < n && less(j, j+1, pq) {
@@ -49,31 +49,10 @@ func sink(k, n int, pq []int) {
 // * Helper functions for comparisons and swaps.
 // * Indices are "off-by-one" to support 1-based indexing.
 
-func less(i, j int, pq []int) bool {
-	return pq[i-1] < pq[j-1]
-}
-
-func exchange(i, j int, pq []int) {
-	pq[i-1], pq[j-1] = pq[j-1], pq[i-1]
+func less(i, j int, pq util.Array) bool {
+	return pq.Less(i-1, j-1)
 }
 
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	line, err := reader.ReadString('\n')
-	if err == io.EOF {
-		return
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-	str := strings.Split(line[:len(line)-1], " ")
-	nums := make([]int, len(str))
-	for i, s := range str {
-		if nums[i], err = strconv.Atoi(s); err != nil {
-			log.Fatal(err)
-		}
-	}
-
-	Sort(nums)
-	fmt.Println(nums)
+func exchange(i, j int, pq util.Array) {
+	pq.Exch(i-1, j-1)
 }
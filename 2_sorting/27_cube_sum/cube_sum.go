@@ -1,13 +1,13 @@
 package main
 
 import (
-	minPQ "github.com/lee-hen/Algorithms/2_sorting/27_cube_sum/min_pq"
+	pq "github.com/lee-hen/Algorithms/2_sorting/34_priority_queue"
 
 	"fmt"
 )
 
 /******************************************************************************
- *  Dependencies: min_pq.go
+ *  Dependencies: 34_priority_queue
  *
  *  Print out integers of the form a^3 + b^3 in sorted order, where
  *  0 <= a <= b <= n.
@@ -32,10 +32,14 @@ import (
  *
  ******************************************************************************/
 
-func CubeSum(i, j int) minPQ.CubeSum {
-	return minPQ.CubeSum {
-		I:i,
-		J: j,
+type cubeSum struct {
+	I, J, Sum int
+}
+
+func CubeSum(i, j int) cubeSum {
+	return cubeSum{
+		I:   i,
+		J:   j,
 		Sum: i*i*i + j*j*j,
 	}
 }
@@ -47,19 +51,19 @@ func main() {
 		fmt.Println(err)
 	}
 
-	cubeSums := make([]minPQ.CubeSum, 0)
+	cubeSums := make([]cubeSum, 0)
 
 	for idx := 0; idx <= n; idx++ {
 		cubeSums = append(cubeSums, CubeSum(idx, idx))
 	}
 
-	pq := minPQ.NewMinPQ(cubeSums)
+	minPQ := pq.Heapify(cubeSums, func(v, w cubeSum) bool { return v.Sum > w.Sum })
 
-	for !pq.IsEmpty() {
-		s := pq.DelMin()
-		fmt.Printf( "%d = %d^3 + %d^3\n", s.Sum, s.I, s.J)
+	for !minPQ.IsEmpty() {
+		s := minPQ.DelTop()
+		fmt.Printf("%d = %d^3 + %d^3\n", s.Sum, s.I, s.J)
 		if s.J < n {
-			pq.Insert(CubeSum(s.I, s.J+1))
+			minPQ.Insert(CubeSum(s.I, s.J+1))
 		}
 	}
 }
@@ -1,141 +1,63 @@
 package stable_min_pq
 
-import "github.com/lee-hen/Algorithms/util"
+import priorityQueue "github.com/lee-hen/Algorithms/2_sorting/34_priority_queue"
 
+// StableMinPQ
+// A min-oriented priority queue of tuples ordered by name that returns tuples with equal
+// names in the order they were inserted: each tuple is stamped on insertion, and the
+// stamp breaks ties in the comparator of the underlying 34_priority_queue.
 type StableMinPQ struct {
-	*Heap
+	pq        *priorityQueue.PQ[entry]
 	timestamp int
 }
 
-func NewStableMinPQ(keys []Tuple) *StableMinPQ {
-	heap := NewHeap(MinHeapFunc, len(keys)+1)
-	heap.time[0] = 1
+type Tuple struct {
+	Id   int
+	Name string
+}
+
+type entry struct {
+	tuple     Tuple
+	timestamp int
+}
 
-	var t = 1
-	for i := 0; i < len(keys); i++ {
-		heap.items[i+1] = keys[i]
-		heap.time[i+1] = t
-		t++
+// the top of the queue is the entry with the smallest (name, timestamp)
+func greater(v, w entry) bool {
+	if v.tuple.Name == w.tuple.Name {
+		return v.timestamp > w.timestamp
 	}
+	return v.tuple.Name > w.tuple.Name
+}
 
-	for k := len(keys) / 2; k >= 1; k-- {
-		heap.Sink(k)
+func NewStableMinPQ(keys []Tuple) *StableMinPQ {
+	entries := make([]entry, len(keys))
+	for i := range keys {
+		entries[i] = entry{keys[i], i + 1}
 	}
 
 	return &StableMinPQ{
-		heap,
-		t,
+		pq:        priorityQueue.Heapify(entries, greater),
+		timestamp: len(keys),
 	}
 }
 
 func (pq *StableMinPQ) IsEmpty() bool {
-	return pq.length() == 0
+	return pq.pq.IsEmpty()
 }
 
 func (pq *StableMinPQ) Size() int {
-	return pq.length()
+	return pq.pq.Size()
 }
 
 func (pq *StableMinPQ) Min() Tuple {
-	return pq.items[1]
+	return pq.pq.Peek().tuple
 }
 
 func (pq *StableMinPQ) Insert(x Tuple) {
-	pq.items = append(pq.items, x)
 	pq.timestamp++
-	pq.time = append(pq.time, pq.timestamp)
-	pq.Swim(pq.length())
+	pq.pq.Insert(entry{x, pq.timestamp})
 }
 
 func (pq *StableMinPQ) DelMin() Tuple {
-	min := pq.items[1]
-	pq.exchange(1, pq.length())
-	n := pq.length()
-	pq.items = pq.items[:n]
-	pq.time = pq.time[:n]
-	pq.Sink(1)
-	return min
-}
-
-type Heap struct {
-	comp   HeapFunc
-	items []Tuple
-	time []int
-}
-
-type HeapFunc func(string, string) bool
-
-var MinHeapFunc = func(a, b string) bool { return a > b }
-
-func NewHeap(fn HeapFunc, n int) *Heap {
-	return &Heap{
-		comp:   fn,
-		items: make([]Tuple, n),
-		time: make([]int, n),
-	}
-}
-
-func (h *Heap) Swim(k int) {
-	for k > 1 && h.greater(k/2, k) {
-		h.exchange(k, k/2)
-		k = k/2
-	}
-}
-
-func (h *Heap) Sink(k int) {
-	for 2*k <= h.length() {
-		j := 2*k
-		if j < h.length() && h.greater(j, j+1) {
-			j++
-		}
-		if !h.greater(k, j) {
-			break
-		}
-		h.exchange(k, j)
-		k = j
-	}
-}
-
-func (h *Heap) length() int {
-	return len(h.items)-1
-}
-
-func (h *Heap) greater(i, j int) bool {
-	if h.items[i].Name == h.items[j].Name {
-		return util.Greater(h.time[i], h.time[j])
-	}
-
-	return h.comp(h.items[i].Name, h.items[j].Name)
-}
-
-func (h *Heap) exchange(i, j int) {
-	h.items[i], h.items[j] = h.items[j], h.items[i]
-	h.time[i], h.time[j] = h.time[j], h.time[i]
-}
-
-type Tuple struct {
-	Id int
-	Name string
-}
-
-func (h *Heap) isMinHeap() bool {
-	return h.isMinHeapOrdered(1)
-}
-
-func (h *Heap) isMinHeapOrdered(k int) bool {
-	if k > h.length() {
-		return true
-	}
-
-	left, right := 2*k, 2*k + 1
-
-	if left  <= h.length() && h.greater(k, left)  {
-		return false
-	}
-
-	if right <= h.length() && h.greater(k, right) {
-		return false
-	}
-
-	return h.isMinHeapOrdered(left) && h.isMinHeapOrdered(right)
+	return pq.pq.DelTop().tuple
 }
@@ -0,0 +1,29 @@
+package stable_min_pq
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	// tuples with equal names come out in the order they went in
+	names := []string{"b", "a", "b", "c", "a", "b", "a"}
+	tuples := make([]Tuple, len(names))
+	for i, name := range names {
+		tuples[i] = Tuple{Id: i, Name: name}
+	}
+
+	pq := NewStableMinPQ(tuples[:4])
+	for _, tuple := range tuples[4:] {
+		pq.Insert(tuple)
+	}
+	require.Equal(t, 7, pq.Size())
+	require.Equal(t, Tuple{Id: 1, Name: "a"}, pq.Min())
+
+	var ids []int
+	for !pq.IsEmpty() {
+		ids = append(ids, pq.DelMin().Id)
+	}
+	require.Equal(t, []int{1, 4, 6, 0, 2, 5, 3}, ids)
+}
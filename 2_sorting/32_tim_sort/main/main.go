@@ -0,0 +1,118 @@
+package main
+
+import (
+	timSort "github.com/lee-hen/Algorithms/2_sorting/32_tim_sort"
+	"github.com/lee-hen/Algorithms/util"
+
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Sorts the integers on standard input with Timsort and, for comparison, with
+// bottom-up mergesort (07_merge_bu) and top-down mergesort that skips the merge
+// when the halves are already in order (08_merge_x), printing compares and moves for each.
+// % echo "1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31 32 33 34 35 36 37 38 39 40 2 17 5" | go run main.go
+// [1 2 2 3 4 5 5 6 ...]
+// timsort    compares:     72  moves:     46
+// merge_bu   compares:    133  moves:    486
+// merge_x    compares:     94  moves:    168
+
+// counter
+// counting copies of the merge sorts in 07_merge_bu and 08_merge_x,
+// which live in package main and cannot be imported.
+type counter struct {
+	timSort.Stats
+}
+
+func (c *counter) less(v, w int) bool {
+	c.Compares++
+	return v < w
+}
+
+func (c *counter) merge(a, aux []int, lo, mid, hi int) {
+	copy(aux[lo:hi+1], a[lo:hi+1])
+	c.Moves += hi - lo + 1
+
+	i, j := lo, mid+1
+	for k := lo; k <= hi; k++ {
+		if i > mid {
+			a[k] = aux[j]
+			j++
+		} else if j > hi {
+			a[k] = aux[i]
+			i++
+		} else if c.less(aux[j], aux[i]) {
+			a[k] = aux[j]
+			j++
+		} else {
+			a[k] = aux[i]
+			i++
+		}
+		c.Moves++
+	}
+}
+
+func (c *counter) mergeBU(a []int) {
+	aux := make([]int, len(a))
+	for ln := 1; ln < len(a); ln *= 2 {
+		for lo := 0; lo < len(a)-ln; lo += ln + ln {
+			c.merge(a, aux, lo, lo+ln-1, util.Min(lo+ln+ln-1, len(a)-1))
+		}
+	}
+}
+
+func (c *counter) mergeX(a, aux []int, lo, hi int) {
+	if hi <= lo {
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	c.mergeX(a, aux, lo, mid)
+	c.mergeX(a, aux, mid+1, hi)
+
+	// skip the merge if a[mid] <= a[mid+1]
+	if !c.less(a[mid+1], a[mid]) {
+		return
+	}
+	c.merge(a, aux, lo, mid, hi)
+}
+
+func main() {
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err == io.EOF {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	str := strings.Split(line[:len(line)-1], " ")
+	nums := make([]int, len(str))
+	for i, s := range str {
+		if nums[i], err = strconv.Atoi(s); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	bu := make([]int, len(nums))
+	copy(bu, nums)
+	x := make([]int, len(nums))
+	copy(x, nums)
+
+	stats := timSort.Sort(nums)
+	fmt.Println(nums)
+	fmt.Printf("timsort    compares: %6d  moves: %6d\n", stats.Compares, stats.Moves)
+
+	var c counter
+	c.mergeBU(bu)
+	fmt.Printf("merge_bu   compares: %6d  moves: %6d\n", c.Compares, c.Moves)
+
+	c = counter{}
+	c.mergeX(x, make([]int, len(x)), 0, len(x)-1)
+	fmt.Printf("merge_x    compares: %6d  moves: %6d\n", c.Compares, c.Moves)
+}
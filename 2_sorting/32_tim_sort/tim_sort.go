@@ -0,0 +1,668 @@
+package tim_sort
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+)
+
+// Timsort is a stable, natural mergesort. Instead of merging fixed power-of-two subarrays as bottom-up mergesort does,
+// it scans the input once for runs that are already ascending (or strictly descending, which it reverses in place),
+// extends short runs to a minimum length with binary insertion sort, and merges the runs kept on a stack whose lengths
+// satisfy the invariants
+//     runLen[i-2] > runLen[i-1] + runLen[i]
+//     runLen[i-1] > runLen[i]
+// so that merges stay balanced and the stack height is logarithmic.
+// When one run keeps winning during a merge the algorithm switches to galloping mode: exponential search followed by
+// binary search finds how many elements in a row come from the same run, and they are moved in one block.
+//
+// On an array that consists of k ascending runs Timsort uses ~N lg k compares in the worst case, so an already sorted
+// array takes N-1 compares and 0 moves, and data made of a long sorted prefix with a short appended tail
+// (the typical shape of an appended log) sorts in near-linear time.
+
+const (
+	// arrays shorter than this are sorted with binary insertion sort only
+	minMerge = 32
+	// initial threshold for entering galloping mode
+	minGallop = 7
+)
+
+// Stats
+// Counts the work done by one call to Sort.
+type Stats struct {
+	Compares int // number of calls to less
+	Moves    int // number of array element writes, into a[] or into the merge buffer
+}
+
+type timSort struct {
+	a    []int
+	less func(x, y int) bool
+
+	minGallop int   // adaptive threshold for galloping mode
+	tmp       []int // merge buffer, never longer than N/2
+
+	// stack of pending runs yet to be merged: run i starts at runBase[i] and extends for runLen[i] elements
+	runBase, runLen []int
+
+	stats Stats
+	step  func(lo, hi int) // if not nil, called whenever a[lo, hi) becomes a sorted run
+}
+
+// Sort
+// Rearranges the array in ascending order, using the natural order,
+// and returns the number of compares and moves it took.
+func Sort(a []int) Stats {
+	return SortFunc(a, util.Less)
+}
+
+// SortFunc
+// Rearranges the array in ascending order as defined by less.
+// The sort is stable: equal elements keep their original relative order,
+// so sorting a permutation of indices with a less that compares keys[x] and keys[y]
+// sorts any keyed data stably.
+func SortFunc(a []int, less func(x, y int) bool) Stats {
+	return SortFuncStep(a, less, nil)
+}
+
+// SortFuncStep
+// As SortFunc, and calls step(lo, hi) whenever a[lo, hi) becomes a sorted run:
+// once for every run found (and extended to the minimum run length),
+// and once for every merge of two adjacent runs, so that a caller can trace the sort.
+func SortFuncStep(a []int, less func(x, y int) bool, step func(lo, hi int)) Stats {
+	ts := &timSort{
+		a:         a,
+		less:      less,
+		minGallop: minGallop,
+		step:      step,
+	}
+
+	n := len(a)
+	if n < 2 {
+		return ts.stats
+	}
+
+	lo, hi := 0, n
+
+	// if array is small, do a "mini-Timsort" with no merges
+	if n < minMerge {
+		initRunLen := ts.countRunAndMakeAscending(lo, hi)
+		ts.binarySort(lo, hi, lo+initRunLen)
+		ts.sorted(lo, hi)
+		return ts.stats
+	}
+
+	// march over the array once, left to right, finding natural runs,
+	// extending short natural runs to minRun elements, and merging runs
+	// to maintain stack invariant.
+	minRun := minRunLength(n)
+	for remaining := n; remaining != 0; {
+		runLen := ts.countRunAndMakeAscending(lo, hi)
+
+		// if run is short, extend to min(minRun, remaining)
+		if runLen < minRun {
+			force := util.Min(remaining, minRun)
+			ts.binarySort(lo, lo+force, lo+runLen)
+			runLen = force
+		}
+		ts.sorted(lo, lo+runLen)
+
+		// push run onto pending-run stack, and maybe merge
+		ts.pushRun(lo, runLen)
+		ts.mergeCollapse()
+
+		lo += runLen
+		remaining -= runLen
+	}
+
+	// merge all remaining runs to complete sort
+	ts.mergeForceCollapse()
+	return ts.stats
+}
+
+// minRunLength
+// Returns the minimum acceptable run length for an array of length n.
+// If n < minMerge, it is n itself (the array is too small to bother).
+// Else if n is an exact power of 2, it is minMerge/2.
+// Else it is a k, minMerge/2 <= k <= minMerge, such that n/k is close to,
+// but strictly less than, an exact power of 2.
+func minRunLength(n int) int {
+	r := 0 // becomes 1 if any 1 bits are shifted off
+	for n >= minMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+func (ts *timSort) sorted(lo, hi int) {
+	if ts.step != nil {
+		ts.step(lo, hi)
+	}
+}
+
+func (ts *timSort) lessThan(x, y int) bool {
+	ts.stats.Compares++
+	return ts.less(x, y)
+}
+
+// copy src to dst, counting every element written
+func (ts *timSort) move(dst, src []int) {
+	ts.stats.Moves += copy(dst, src)
+}
+
+func (ts *timSort) set(dst []int, i, v int) {
+	ts.stats.Moves++
+	dst[i] = v
+}
+
+// binarySort
+// Sorts a[lo, hi) with binary insertion sort, given that a[lo, start) is already sorted.
+// It requires O(n lg n) compares, but O(n^2) data movement (worst case).
+func (ts *timSort) binarySort(lo, hi, start int) {
+	a := ts.a
+	if start == lo {
+		start++
+	}
+
+	for ; start < hi; start++ {
+		pivot := a[start]
+
+		// invariants: pivot >= all in [lo, left), pivot < all in [right, start)
+		left, right := lo, start
+		for left < right {
+			mid := left + (right-left)/2
+			if ts.lessThan(pivot, a[mid]) {
+				right = mid
+			} else {
+				left = mid + 1
+			}
+		}
+
+		// slide elements over to make room for pivot;
+		// equal elements stay to the left, which keeps the sort stable
+		ts.move(a[left+1:start+1], a[left:start])
+		ts.set(a, left, pivot)
+	}
+}
+
+// countRunAndMakeAscending
+// Returns the length of the run beginning at lo in a[lo, hi), and reverses the run if it is descending.
+// A run is the longest ascending sequence with a[lo] <= a[lo + 1] <= a[lo + 2] <= ...
+// or the longest descending sequence with a[lo] >  a[lo + 1] >  a[lo + 2] >  ...
+// The definition of "descending" is strict, so a descending run can be reversed
+// without violating stability.
+func (ts *timSort) countRunAndMakeAscending(lo, hi int) int {
+	a := ts.a
+	runHi := lo + 1
+	if runHi == hi {
+		return 1
+	}
+
+	// find end of run, and reverse range if descending
+	if ts.lessThan(a[runHi], a[lo]) {
+		runHi++
+		for runHi < hi && ts.lessThan(a[runHi], a[runHi-1]) {
+			runHi++
+		}
+		ts.reverseRange(lo, runHi)
+	} else {
+		runHi++
+		for runHi < hi && !ts.lessThan(a[runHi], a[runHi-1]) {
+			runHi++
+		}
+	}
+
+	return runHi - lo
+}
+
+// reverse a[lo, hi)
+func (ts *timSort) reverseRange(lo, hi int) {
+	for hi--; lo < hi; lo, hi = lo+1, hi-1 {
+		ts.a[lo], ts.a[hi] = ts.a[hi], ts.a[lo]
+		ts.stats.Moves += 2
+	}
+}
+
+func (ts *timSort) pushRun(runBase, runLen int) {
+	ts.runBase = append(ts.runBase, runBase)
+	ts.runLen = append(ts.runLen, runLen)
+}
+
+// mergeCollapse
+// Examines the stack of runs waiting to be merged and merges adjacent runs
+// until the stack invariants are reestablished.
+// The check looks three runs deep (not only at the top two), otherwise
+// the invariant can be broken further down the stack.
+func (ts *timSort) mergeCollapse() {
+	for len(ts.runLen) > 1 {
+		runLen := ts.runLen
+		n := len(runLen) - 2
+		if n > 0 && runLen[n-1] <= runLen[n]+runLen[n+1] || n > 1 && runLen[n-2] <= runLen[n]+runLen[n-1] {
+			if runLen[n-1] < runLen[n+1] {
+				n--
+			}
+		} else if runLen[n] > runLen[n+1] {
+			break // invariant is established
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// mergeForceCollapse
+// Merges all runs on the stack until only one remains.
+func (ts *timSort) mergeForceCollapse() {
+	for len(ts.runLen) > 1 {
+		n := len(ts.runLen) - 2
+		if n > 0 && ts.runLen[n-1] < ts.runLen[n+1] {
+			n--
+		}
+		ts.mergeAt(n)
+	}
+}
+
+// mergeAt
+// Merges the two runs at stack indices i and i+1.
+// Run i must be the penultimate or antepenultimate run on the stack.
+func (ts *timSort) mergeAt(i int) {
+	a := ts.a
+	base1, len1 := ts.runBase[i], ts.runLen[i]
+	base2, len2 := ts.runBase[i+1], ts.runLen[i+1]
+	defer ts.sorted(base1, base2+len2)
+
+	// record the length of the combined runs; if i is the 3rd-last
+	// run now, also slide over the last run (which isn't involved
+	// in this merge). The current run (i+1) goes away in any case.
+	ts.runLen[i] = len1 + len2
+	if i == len(ts.runLen)-3 {
+		ts.runBase[i+1] = ts.runBase[i+2]
+		ts.runLen[i+1] = ts.runLen[i+2]
+	}
+	ts.runBase = ts.runBase[:len(ts.runBase)-1]
+	ts.runLen = ts.runLen[:len(ts.runLen)-1]
+
+	// find where the first element of run2 goes in run1. Prior elements
+	// in run1 can be ignored (because they're already in place).
+	k := ts.gallopRight(a[base2], a, base1, len1, 0)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+
+	// find where the last element of run1 goes in run2. Subsequent elements
+	// in run2 can be ignored (because they're already in place).
+	len2 = ts.gallopLeft(a[base1+len1-1], a, base2, len2, len2-1)
+	if len2 == 0 {
+		return
+	}
+
+	// merge remaining runs, using tmp array with min(len1, len2) elements
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+// gallopLeft
+// Locates the position at which to insert key into the sorted range a[base, base+length);
+// if the range contains elements equal to key, returns the index of the leftmost equal element.
+// The search starts at base+hint and gallops away from it with offsets 1, 3, 7, ..., 2^k-1
+// before finishing with a binary search, so it costs O(lg d) compares where d is the distance from hint.
+// The result k satisfies a[base+k-1] < key <= a[base+k].
+func (ts *timSort) gallopLeft(key int, a []int, base, length, hint int) int {
+	lastOfs, ofs := 0, 1
+	if ts.lessThan(a[base+hint], key) {
+		// gallop right until a[base+hint+lastOfs] < key <= a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && ts.lessThan(a[base+hint+ofs], key) {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		// make offsets relative to base
+		lastOfs += hint
+		ofs += hint
+	} else { // key <= a[base + hint]
+		// gallop left until a[base+hint-ofs] < key <= a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && !ts.lessThan(a[base+hint-ofs], key) {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		// make offsets relative to base
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	}
+
+	// now a[base+lastOfs] < key <= a[base+ofs], so key belongs somewhere
+	// to the right of lastOfs but no farther right than ofs. Do a binary
+	// search, with invariant a[base + lastOfs - 1] < key <= a[base + ofs].
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)/2
+		if ts.lessThan(a[base+m], key) {
+			lastOfs = m + 1 // a[base + m] < key
+		} else {
+			ofs = m // key <= a[base + m]
+		}
+	}
+	return ofs
+}
+
+// gallopRight
+// Like gallopLeft, except that if the range contains elements equal to key,
+// returns the index after the rightmost equal element.
+// The result k satisfies a[base+k-1] <= key < a[base+k].
+func (ts *timSort) gallopRight(key int, a []int, base, length, hint int) int {
+	lastOfs, ofs := 0, 1
+	if ts.lessThan(key, a[base+hint]) {
+		// gallop left until a[base+hint-ofs] <= key < a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && ts.lessThan(key, a[base+hint-ofs]) {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		// make offsets relative to base
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else { // a[base + hint] <= key
+		// gallop right until a[base+hint+lastOfs] <= key < a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && !ts.lessThan(key, a[base+hint+ofs]) {
+			lastOfs = ofs
+			ofs = (ofs << 1) + 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+
+		// make offsets relative to base
+		lastOfs += hint
+		ofs += hint
+	}
+
+	// now a[base+lastOfs] <= key < a[base+ofs], so key belongs somewhere to
+	// the right of lastOfs but no farther right than ofs. Do a binary
+	// search, with invariant a[base + lastOfs - 1] <= key < a[base + ofs].
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)/2
+		if ts.lessThan(key, a[base+m]) {
+			ofs = m // key < a[base + m]
+		} else {
+			lastOfs = m + 1 // a[base + m] <= key
+		}
+	}
+	return ofs
+}
+
+func (ts *timSort) ensureCapacity(minCapacity int) []int {
+	if len(ts.tmp) < minCapacity {
+		ts.tmp = make([]int, minCapacity)
+	}
+	return ts.tmp
+}
+
+// mergeLo
+// Merges two adjacent runs in place, in a stable fashion. The first element of the first run must be greater than
+// the first element of the second run (a[base1] > a[base2]), and the last element of the first run
+// (a[base1 + len1-1]) must be greater than all elements of the second run.
+// For performance, this method should be called only when len1 <= len2;
+// its twin, mergeHi should be called if len1 >= len2.
+func (ts *timSort) mergeLo(base1, len1, base2, len2 int) {
+	// copy first run into temp array
+	a := ts.a
+	tmp := ts.ensureCapacity(len1)
+	ts.move(tmp[:len1], a[base1:base1+len1])
+
+	cursor1 := 0     // indexes into tmp array
+	cursor2 := base2 // indexes into a
+	dest := base1    // indexes into a
+
+	// move first element of second run and deal with degenerate cases
+	ts.set(a, dest, a[cursor2])
+	dest++
+	cursor2++
+	if len2--; len2 == 0 {
+		ts.move(a[dest:dest+len1], tmp[cursor1:cursor1+len1])
+		return
+	}
+	if len1 == 1 {
+		ts.move(a[dest:dest+len2], a[cursor2:cursor2+len2])
+		ts.set(a, dest+len2, tmp[cursor1]) // last elt of run 1 to end of merge
+		return
+	}
+
+	gallop := ts.minGallop
+outer:
+	for {
+		count1 := 0 // number of times in a row that first run won
+		count2 := 0 // number of times in a row that second run won
+
+		// do the straightforward thing until (if ever) one run starts
+		// winning consistently.
+		for {
+			if ts.lessThan(a[cursor2], tmp[cursor1]) {
+				ts.set(a, dest, a[cursor2])
+				dest++
+				cursor2++
+				count2++
+				count1 = 0
+				if len2--; len2 == 0 {
+					break outer
+				}
+			} else {
+				ts.set(a, dest, tmp[cursor1])
+				dest++
+				cursor1++
+				count1++
+				count2 = 0
+				if len1--; len1 == 1 {
+					break outer
+				}
+			}
+
+			if count1|count2 >= gallop {
+				break
+			}
+		}
+
+		// one run is winning so consistently that galloping may be a
+		// huge win. So try that, and continue galloping until (if ever)
+		// neither run appears to be winning consistently anymore.
+		for {
+			count1 = ts.gallopRight(a[cursor2], tmp, cursor1, len1, 0)
+			if count1 != 0 {
+				ts.move(a[dest:dest+count1], tmp[cursor1:cursor1+count1])
+				dest += count1
+				cursor1 += count1
+				len1 -= count1
+				if len1 <= 1 { // len1 == 1 || len1 == 0
+					break outer
+				}
+			}
+			ts.set(a, dest, a[cursor2])
+			dest++
+			cursor2++
+			if len2--; len2 == 0 {
+				break outer
+			}
+
+			count2 = ts.gallopLeft(tmp[cursor1], a, cursor2, len2, 0)
+			if count2 != 0 {
+				ts.move(a[dest:dest+count2], a[cursor2:cursor2+count2])
+				dest += count2
+				cursor2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+			ts.set(a, dest, tmp[cursor1])
+			dest++
+			cursor1++
+			if len1--; len1 == 1 {
+				break outer
+			}
+
+			gallop--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+
+		if gallop < 0 {
+			gallop = 0
+		}
+		gallop += 2 // penalize for leaving gallop mode
+	}
+
+	if gallop < 1 {
+		gallop = 1
+	}
+	ts.minGallop = gallop
+
+	if len1 == 1 {
+		ts.move(a[dest:dest+len2], a[cursor2:cursor2+len2])
+		ts.set(a, dest+len2, tmp[cursor1]) //  last elt of run 1 to end of merge
+	} else {
+		ts.move(a[dest:dest+len1], tmp[cursor1:cursor1+len1])
+	}
+}
+
+// mergeHi
+// Like mergeLo, except that this method should be called only if len1 >= len2;
+// mergeLo should be called if len1 <= len2.
+// It merges from the right end towards the left.
+func (ts *timSort) mergeHi(base1, len1, base2, len2 int) {
+	// copy second run into temp array
+	a := ts.a
+	tmp := ts.ensureCapacity(len2)
+	ts.move(tmp[:len2], a[base2:base2+len2])
+
+	cursor1 := base1 + len1 - 1 // indexes into a
+	cursor2 := len2 - 1         // indexes into tmp array
+	dest := base2 + len2 - 1    // indexes into a
+
+	// move last element of first run and deal with degenerate cases
+	ts.set(a, dest, a[cursor1])
+	dest--
+	cursor1--
+	if len1--; len1 == 0 {
+		ts.move(a[dest-(len2-1):dest+1], tmp[:len2])
+		return
+	}
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		ts.move(a[dest+1:dest+1+len1], a[cursor1+1:cursor1+1+len1])
+		ts.set(a, dest, tmp[cursor2])
+		return
+	}
+
+	gallop := ts.minGallop
+outer:
+	for {
+		count1 := 0 // number of times in a row that first run won
+		count2 := 0 // number of times in a row that second run won
+
+		// do the straightforward thing until (if ever) one run
+		// appears to win consistently.
+		for {
+			if ts.lessThan(tmp[cursor2], a[cursor1]) {
+				ts.set(a, dest, a[cursor1])
+				dest--
+				cursor1--
+				count1++
+				count2 = 0
+				if len1--; len1 == 0 {
+					break outer
+				}
+			} else {
+				ts.set(a, dest, tmp[cursor2])
+				dest--
+				cursor2--
+				count2++
+				count1 = 0
+				if len2--; len2 == 1 {
+					break outer
+				}
+			}
+
+			if count1|count2 >= gallop {
+				break
+			}
+		}
+
+		// one run is winning so consistently that galloping may be a
+		// huge win. So try that, and continue galloping until (if ever)
+		// neither run appears to be winning consistently anymore.
+		for {
+			count1 = len1 - ts.gallopRight(tmp[cursor2], a, base1, len1, len1-1)
+			if count1 != 0 {
+				dest -= count1
+				cursor1 -= count1
+				len1 -= count1
+				ts.move(a[dest+1:dest+1+count1], a[cursor1+1:cursor1+1+count1])
+				if len1 == 0 {
+					break outer
+				}
+			}
+			ts.set(a, dest, tmp[cursor2])
+			dest--
+			cursor2--
+			if len2--; len2 == 1 {
+				break outer
+			}
+
+			count2 = len2 - ts.gallopLeft(a[cursor1], tmp, 0, len2, len2-1)
+			if count2 != 0 {
+				dest -= count2
+				cursor2 -= count2
+				len2 -= count2
+				ts.move(a[dest+1:dest+1+count2], tmp[cursor2+1:cursor2+1+count2])
+				if len2 <= 1 { // len2 == 1 || len2 == 0
+					break outer
+				}
+			}
+			ts.set(a, dest, a[cursor1])
+			dest--
+			cursor1--
+			if len1--; len1 == 0 {
+				break outer
+			}
+
+			gallop--
+			if count1 < minGallop && count2 < minGallop {
+				break
+			}
+		}
+
+		if gallop < 0 {
+			gallop = 0
+		}
+		gallop += 2 // penalize for leaving gallop mode
+	}
+
+	if gallop < 1 {
+		gallop = 1
+	}
+	ts.minGallop = gallop
+
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		ts.move(a[dest+1:dest+1+len1], a[cursor1+1:cursor1+1+len1])
+		ts.set(a, dest, tmp[cursor2]) // move first elt of run2 to front of merge
+	} else {
+		ts.move(a[dest-(len2-1):dest+1], tmp[:len2])
+	}
+}
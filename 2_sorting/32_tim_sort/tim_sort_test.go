@@ -0,0 +1,117 @@
+package tim_sort
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func isSorted(a []int) bool {
+	for i := 1; i < len(a); i++ {
+		if a[i] < a[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCase1(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 31, 32, 33, 64, 1000, 10000} {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Intn(n + 1)
+		}
+		Sort(a)
+		require.True(t, isSorted(a))
+	}
+}
+
+func TestCase2(t *testing.T) {
+	// already sorted input is a single run
+	n := 10000
+	a := make([]int, n)
+	for i := range a {
+		a[i] = i
+	}
+	stats := Sort(a)
+	require.True(t, isSorted(a))
+	require.Equal(t, n-1, stats.Compares)
+	require.Equal(t, 0, stats.Moves)
+
+	// strictly descending input is reversed in place
+	for i := range a {
+		a[i] = n - i
+	}
+	stats = Sort(a)
+	require.True(t, isSorted(a))
+	require.Equal(t, n-1, stats.Compares)
+}
+
+func TestCase3(t *testing.T) {
+	// a sorted log with a short unsorted tail appended
+	n, tail := 100000, 100
+	a := make([]int, n+tail)
+	for i := 0; i < n; i++ {
+		a[i] = 2 * i
+	}
+	for i := n; i < n+tail; i++ {
+		a[i] = rand.Intn(2 * n)
+	}
+	stats := Sort(a)
+	require.True(t, isSorted(a))
+	require.Less(t, stats.Compares, 2*(n+tail))
+}
+
+func TestCase4(t *testing.T) {
+	// sorting a permutation of indices by key is stable
+	n := 5000
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = rand.Intn(10)
+	}
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	SortFunc(perm, func(x, y int) bool {
+		return keys[x] < keys[y]
+	})
+
+	expected := make([]int, n)
+	for i := 0; i < n; i++ {
+		expected[i] = i
+	}
+	sort.SliceStable(expected, func(i, j int) bool {
+		return keys[expected[i]] < keys[expected[j]]
+	})
+	require.Equal(t, expected, perm)
+}
+
+func TestCase5(t *testing.T) {
+	// every step reports a sorted run, k runs take k-1 merges, and the last merge covers the array
+	for _, n := range []int{0, 1, 31, 1000} {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = rand.Intn(n + 1)
+		}
+
+		var steps [][2]int
+		SortFuncStep(a, func(x, y int) bool {
+			return x < y
+		}, func(lo, hi int) {
+			require.True(t, isSorted(a[lo:hi]))
+			steps = append(steps, [2]int{lo, hi})
+		})
+		require.True(t, isSorted(a))
+
+		if n < 2 {
+			require.Empty(t, steps)
+			continue
+		}
+		// k runs and k-1 merges
+		require.Equal(t, 1, len(steps)%2)
+		require.Equal(t, [2]int{0, n}, steps[len(steps)-1])
+	}
+}
@@ -0,0 +1,129 @@
+package sort_trace
+
+import (
+	"math/rand"
+)
+
+// Array is an instrumented util.Array: the sorts in 2_sorting touch the data only through
+// Less, LessValue, Exch and Set, which count compares, exchanges and writes and, in trace mode,
+// record the array state after every exchange or write.
+
+// Step
+// One exchange, write or sorted run, with the array state right after it.
+type Step struct {
+	Op string `json:"op"` // "exch", "write" or "run"
+	I  int    `json:"i"`
+	J  int    `json:"j"` // the other index of an exchange, the written value of a write, or the end (exclusive) of a run
+	A  []int  `json:"a"`
+}
+
+type Array struct {
+	a       []int
+	initial []int
+
+	// Compares number of calls to Less
+	// Exchanges number of calls to Exch
+	// Writes number of single-element writes (half exchanges and merges)
+	Compares, Exchanges, Writes int
+
+	trace bool
+	Steps []Step
+
+	rnd *rand.Rand // used by the quicksorts to shuffle, seeded so traces are reproducible
+}
+
+// NewArray
+// Wraps a (sorting will rearrange it). If trace is true every exchange and write is recorded.
+func NewArray(a []int, trace bool) *Array {
+	initial := make([]int, len(a))
+	copy(initial, a)
+
+	return &Array{
+		a:       a,
+		initial: initial,
+		trace:   trace,
+		rnd:     rand.New(rand.NewSource(1)),
+	}
+}
+
+// Len
+// Returns the number of elements.
+func (x *Array) Len() int {
+	return len(x.a)
+}
+
+// Get
+// Returns the ith element; reads are not counted.
+func (x *Array) Get(i int) int {
+	return x.a[i]
+}
+
+// Less
+// Is a[i] < a[j]?
+func (x *Array) Less(i, j int) bool {
+	x.Compares++
+	return x.a[i] < x.a[j]
+}
+
+// LessValue
+// Is v < w? For algorithms that hold an element outside the array (half exchanges, merges).
+func (x *Array) LessValue(v, w int) bool {
+	x.Compares++
+	return v < w
+}
+
+// Exch
+// Exchanges a[i] and a[j].
+func (x *Array) Exch(i, j int) {
+	x.Exchanges++
+	x.a[i], x.a[j] = x.a[j], x.a[i]
+	x.record("exch", i, j)
+}
+
+// Set
+// Writes v to a[i].
+func (x *Array) Set(i, v int) {
+	x.Writes++
+	x.a[i] = v
+	x.record("write", i, v)
+}
+
+func (x *Array) record(op string, i, j int) {
+	if !x.trace {
+		return
+	}
+
+	a := make([]int, len(x.a))
+	copy(a, x.a)
+	x.Steps = append(x.Steps, Step{Op: op, I: i, J: j, A: a})
+}
+
+// Frames
+// Returns the array state before sorting followed by the state after every recorded step.
+func (x *Array) Frames() [][]int {
+	frames := make([][]int, 0, len(x.Steps)+1)
+	frames = append(frames, x.initial)
+	for _, step := range x.Steps {
+		frames = append(frames, step.A)
+	}
+	return frames
+}
+
+// IsSorted
+// Returns true if the array is in ascending order.
+func (x *Array) IsSorted() bool {
+	for i := 1; i < len(x.a); i++ {
+		if x.a[i] < x.a[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// shuffle the array without counting, as the book's sorts do with StdRandom.shuffle()
+func (x *Array) shuffle() {
+	x.rnd.Shuffle(len(x.a), func(i, j int) {
+		x.a[i], x.a[j] = x.a[j], x.a[i]
+	})
+	copy(x.initial, x.a)
+}
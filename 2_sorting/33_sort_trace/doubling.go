@@ -0,0 +1,83 @@
+package sort_trace
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Inputs
+// The input models of the doubling experiment.
+var Inputs = []string{"random", "sorted", "reverse", "few-distinct"}
+
+// Generate
+// Returns an array of length n drawn from the named input model:
+// a random permutation, ascending, descending, or random values from only 10 distinct keys.
+func Generate(input string, n int, r *rand.Rand) ([]int, error) {
+	a := make([]int, n)
+	switch input {
+	case "random":
+		copy(a, r.Perm(n))
+	case "sorted":
+		for i := range a {
+			a[i] = i
+		}
+	case "reverse":
+		for i := range a {
+			a[i] = n - i
+		}
+	case "few-distinct":
+		for i := range a {
+			a[i] = r.Intn(10)
+		}
+	default:
+		return nil, fmt.Errorf("unknown input %q, expected one of %v", input, Inputs)
+	}
+	return a, nil
+}
+
+// Row
+// One line of a doubling-ratio table.
+type Row struct {
+	N                           int
+	Compares, Exchanges, Writes int
+	Time                        time.Duration
+	Ratio                       float64 // Time / Time of the previous row, 0 for the first row
+}
+
+// DoublingRatio
+// Runs the algorithm on inputs of size start, 2*start, 4*start, ... (rows sizes in all), as in
+// the book's DoublingRatio client. Since the running time of most sorts is ~a N^b, the ratio of
+// consecutive times approaches 2^b: about 4 for the quadratic sorts and a bit over 2 for the linearithmic ones.
+// The counts are those of the instrumented sort, so they are exact, while the times include the instrumentation.
+func DoublingRatio(alg Algorithm, input string, start, rows int, seed int64) ([]Row, error) {
+	r := rand.New(rand.NewSource(seed))
+	table := make([]Row, 0, rows)
+
+	n := start
+	for i := 0; i < rows; i++ {
+		a, err := Generate(input, n, r)
+		if err != nil {
+			return nil, err
+		}
+
+		x := NewArray(a, false)
+		begin := time.Now()
+		alg.Sort(x)
+		elapsed := time.Since(begin)
+
+		if !x.IsSorted() {
+			return nil, fmt.Errorf("%s did not sort %s input of size %d", alg.Name, input, n)
+		}
+
+		row := Row{N: n, Compares: x.Compares, Exchanges: x.Exchanges, Writes: x.Writes, Time: elapsed}
+		if i > 0 && table[i-1].Time > 0 {
+			row.Ratio = float64(elapsed) / float64(table[i-1].Time)
+		}
+		table = append(table, row)
+
+		n += n
+	}
+
+	return table, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	sortTrace "github.com/lee-hen/Algorithms/2_sorting/33_sort_trace"
+
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Traces a sort of the integers on standard input, or runs a doubling-ratio experiment.
+// % echo "5 1 4 2 3" | go run main.go trace insertion json
+// {"algorithm":"insertion","n":5,"compares":9,"exchanges":6,"writes":0,"initial":[5,1,4,2,3],"steps":[...]}
+// % echo "5 1 4 2 3" | go run main.go trace quick svg > quick.svg
+// % echo "9 3 7 1 8 2 6 4 5" | go run main.go trace shell bars
+// % go run main.go doubling merge_x random 1000
+//         N   compares  exchanges     writes       time  ratio
+//      1000       9250       1689       7000    111.7µs
+//      2000      20533       3429      16000    213.3µs    1.9
+//      ...
+
+const usage = `usage:
+  main trace <algorithm> json|svg|bars < input
+  main doubling <algorithm> random|sorted|reverse|few-distinct [n]`
+
+func readInts(r io.Reader) []int {
+	var nums []int
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		num, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			log.Fatal(err)
+		}
+		nums = append(nums, num)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	return nums
+}
+
+func trace(alg sortTrace.Algorithm, format string) {
+	x := sortTrace.NewArray(readInts(os.Stdin), true)
+	alg.Sort(x)
+
+	switch format {
+	case "json":
+		if err := sortTrace.WriteJSON(os.Stdout, alg.Name, x); err != nil {
+			log.Fatal(err)
+		}
+	case "svg":
+		if err := sortTrace.WriteSVG(os.Stdout, x, 100); err != nil {
+			log.Fatal(err)
+		}
+	case "bars":
+		lo, hi := sortTrace.ValueRange(x)
+		frames := x.Frames()
+		for i, frame := range frames {
+			// clear the screen and move the cursor home
+			fmt.Print("\033[H\033[2J")
+			if err := sortTrace.WriteBars(os.Stdout, frame, lo, hi, 16); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("%s  step %d/%d\n", alg.Name, i, len(frames)-1)
+			time.Sleep(100 * time.Millisecond)
+		}
+		fmt.Printf("compares: %d  exchanges: %d  writes: %d\n", x.Compares, x.Exchanges, x.Writes)
+	default:
+		log.Fatalln(usage)
+	}
+}
+
+func doubling(alg sortTrace.Algorithm, input string, n int) {
+	rows, err := sortTrace.DoublingRatio(alg, input, n, 8, time.Now().UnixNano())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%10s %10s %10s %10s %10s %6s\n", "N", "compares", "exchanges", "writes", "time", "ratio")
+	for i, row := range rows {
+		ratio := ""
+		if i > 0 {
+			ratio = fmt.Sprintf("%.1f", row.Ratio)
+		}
+		fmt.Printf("%10d %10d %10d %10d %10v %6s\n", row.N, row.Compares, row.Exchanges, row.Writes, row.Time.Round(100*time.Nanosecond), ratio)
+	}
+}
+
+func main() {
+	if len(os.Args) < 4 {
+		log.Fatalln(usage)
+	}
+
+	alg, err := sortTrace.Lookup(os.Args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch strings.ToLower(os.Args[1]) {
+	case "trace":
+		trace(alg, os.Args[3])
+	case "doubling":
+		n := 1000
+		if len(os.Args) > 4 {
+			if n, err = strconv.Atoi(os.Args[4]); err != nil || n < 1 {
+				log.Fatalln(usage)
+			}
+		}
+		doubling(alg, os.Args[3], n)
+	default:
+		log.Fatalln(usage)
+	}
+}
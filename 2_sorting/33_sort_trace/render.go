@@ -0,0 +1,127 @@
+package sort_trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Trace
+// The JSON form of a traced sort.
+type Trace struct {
+	Algorithm string `json:"algorithm"`
+	N         int    `json:"n"`
+	Compares  int    `json:"compares"`
+	Exchanges int    `json:"exchanges"`
+	Writes    int    `json:"writes"`
+	Initial   []int  `json:"initial"`
+	Steps     []Step `json:"steps"`
+}
+
+// WriteJSON
+// Writes the counts and the step trace of x, sorted by the named algorithm, as JSON.
+func WriteJSON(w io.Writer, name string, x *Array) error {
+	return json.NewEncoder(w).Encode(Trace{
+		Algorithm: name,
+		N:         x.Len(),
+		Compares:  x.Compares,
+		Exchanges: x.Exchanges,
+		Writes:    x.Writes,
+		Initial:   x.initial,
+		Steps:     x.Steps,
+	})
+}
+
+// bounds of the values in the frames, so bar heights are comparable across frames
+func valueRange(frames [][]int) (int, int) {
+	if len(frames) == 0 || len(frames[0]) == 0 {
+		return 0, 0
+	}
+
+	lo, hi := frames[0][0], frames[0][0]
+	for _, frame := range frames {
+		for _, v := range frame {
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+	}
+	return lo, hi
+}
+
+// scale v in [lo, hi] to a bar height in [1, max]
+func barHeight(v, lo, hi, max int) int {
+	if hi == lo {
+		return max
+	}
+	return 1 + (v-lo)*(max-1)/(hi-lo)
+}
+
+// WriteSVG
+// Writes an animated SVG bar chart of the frames of x: one bar per element,
+// each frame shown for frameMillis milliseconds, looping forever.
+func WriteSVG(w io.Writer, x *Array, frameMillis int) error {
+	const (
+		barWidth = 8
+		height   = 200
+	)
+
+	frames := x.Frames()
+	lo, hi := valueRange(frames)
+	width := barWidth * x.Len()
+	dur := float64(len(frames)*frameMillis) / 1000
+
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height))
+	s.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="white"/>`+"\n", width, height))
+
+	for i := 0; i < x.Len(); i++ {
+		heights := make([]string, len(frames))
+		ys := make([]string, len(frames))
+		for f, frame := range frames {
+			h := barHeight(frame[i], lo, hi, height)
+			heights[f] = fmt.Sprintf("%d", h)
+			ys[f] = fmt.Sprintf("%d", height-h)
+		}
+
+		s.WriteString(fmt.Sprintf(`<rect x="%d" y="%s" width="%d" height="%s" fill="gray">`, i*barWidth, ys[0], barWidth-1, heights[0]))
+		if len(frames) > 1 {
+			s.WriteString(fmt.Sprintf(`<animate attributeName="height" values="%s" dur="%.3fs" calcMode="discrete" repeatCount="indefinite"/>`, strings.Join(heights, ";"), dur))
+			s.WriteString(fmt.Sprintf(`<animate attributeName="y" values="%s" dur="%.3fs" calcMode="discrete" repeatCount="indefinite"/>`, strings.Join(ys, ";"), dur))
+		}
+		s.WriteString("</rect>\n")
+	}
+	s.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+// WriteBars
+// Writes one frame as a terminal bar chart of the given height, scaled to the values in frames.
+func WriteBars(w io.Writer, frame []int, lo, hi, height int) error {
+	s := strings.Builder{}
+	for row := height; row >= 1; row-- {
+		for _, v := range frame {
+			if barHeight(v, lo, hi, height) >= row {
+				s.WriteString("█")
+			} else {
+				s.WriteString(" ")
+			}
+		}
+		s.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, s.String())
+	return err
+}
+
+// ValueRange
+// Returns the smallest and largest values over all frames of x.
+func ValueRange(x *Array) (int, int) {
+	return valueRange(x.Frames())
+}
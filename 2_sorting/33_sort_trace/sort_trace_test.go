@@ -0,0 +1,91 @@
+package sort_trace
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, alg := range Algorithms {
+		for _, input := range Inputs {
+			for _, n := range []int{0, 1, 2, 10, 100, 257} {
+				a, err := Generate(input, n, r)
+				require.NoError(t, err)
+
+				x := NewArray(a, false)
+				alg.Sort(x)
+				require.True(t, x.IsSorted(), "%s on %s input of size %d", alg.Name, input, n)
+			}
+		}
+	}
+}
+
+func TestCase2(t *testing.T) {
+	// insertion sort uses one exchange per inversion, and selection sort exactly N-1 exchanges
+	a := []int{5, 1, 4, 2, 3}
+	x := NewArray(a, true)
+	Insertion(x)
+	require.Equal(t, 6, x.Exchanges)
+	require.Equal(t, 6, len(x.Steps))
+	require.Equal(t, []int{1, 2, 3, 4, 5}, x.Steps[len(x.Steps)-1].A)
+
+	x = NewArray([]int{5, 1, 4, 2, 3}, false)
+	Selection(x)
+	require.Equal(t, 5, x.Exchanges)
+	require.Equal(t, 10, x.Compares)
+}
+
+func TestCase3(t *testing.T) {
+	x := NewArray([]int{3, 1, 2}, true)
+	Insertion(x)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, "insertion", x))
+
+	var trace Trace
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	require.Equal(t, "insertion", trace.Algorithm)
+	require.Equal(t, []int{3, 1, 2}, trace.Initial)
+	require.Equal(t, x.Exchanges, len(trace.Steps))
+
+	buf.Reset()
+	require.NoError(t, WriteSVG(&buf, x, 100))
+	require.Contains(t, buf.String(), "<animate")
+}
+
+func TestCase4(t *testing.T) {
+	alg, err := Lookup("merge_bu")
+	require.NoError(t, err)
+
+	rows, err := DoublingRatio(alg, "random", 64, 4, 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(rows))
+	require.Equal(t, 512, rows[3].N)
+
+	_, err = Lookup("bogo")
+	require.Error(t, err)
+}
+
+func TestCase5(t *testing.T) {
+	// timsort records a frame for every run and every merge, ending with the whole array
+	r := rand.New(rand.NewSource(1))
+	a := make([]int, 200)
+	for i := range a {
+		a[i] = r.Intn(1000)
+	}
+	x := NewArray(a, true)
+	TimSort(x)
+	require.True(t, x.IsSorted())
+	require.Greater(t, len(x.Steps), 1)
+
+	last := x.Steps[len(x.Steps)-1]
+	require.Equal(t, "run", last.Op)
+	require.Equal(t, 0, last.I)
+	require.Equal(t, len(a), last.J)
+	require.Equal(t, len(x.Steps)+1, len(x.Frames()))
+}
@@ -0,0 +1,162 @@
+package sort_trace
+
+import (
+	insertion "github.com/lee-hen/Algorithms/2_sorting/01_insertion"
+	insertionX "github.com/lee-hen/Algorithms/2_sorting/02_insertion_x"
+	binaryInsertion "github.com/lee-hen/Algorithms/2_sorting/03_binary_insertion"
+	selection "github.com/lee-hen/Algorithms/2_sorting/04_selection"
+	shell "github.com/lee-hen/Algorithms/2_sorting/05_shell"
+	merge "github.com/lee-hen/Algorithms/2_sorting/06_merge"
+	mergeBU "github.com/lee-hen/Algorithms/2_sorting/07_merge_bu"
+	mergeX "github.com/lee-hen/Algorithms/2_sorting/08_merge_x"
+	quick "github.com/lee-hen/Algorithms/2_sorting/10_quick"
+	quickX "github.com/lee-hen/Algorithms/2_sorting/11_quick_x"
+	quickThreeWay "github.com/lee-hen/Algorithms/2_sorting/12_quick_three_way"
+	quickBentleyMcIlroy "github.com/lee-hen/Algorithms/2_sorting/18_quick_bentley_mcIlroy"
+	heap "github.com/lee-hen/Algorithms/2_sorting/24_heap"
+	timSort "github.com/lee-hen/Algorithms/2_sorting/32_tim_sort"
+
+	"fmt"
+)
+
+// The compare-based sorts in 2_sorting, run on an Array through their SortArray functions,
+// so that the traces and counts are those of the implementations themselves.
+// The string sorts (13_LSD, 14_MSD, 15/16_american_flag, 17_quick_three_string) are key-indexed
+// rather than compare-based and are not included.
+
+// Algorithm
+// A named sort; Name matches the directory of its implementation.
+type Algorithm struct {
+	Name string
+	Sort func(x *Array)
+}
+
+// Algorithms
+// All the sorts in the order they appear in 2_sorting.
+var Algorithms = []Algorithm{
+	{"insertion", Insertion},
+	{"insertion_x", InsertionX},
+	{"binary_insertion", BinaryInsertion},
+	{"selection", Selection},
+	{"shell", Shell},
+	{"merge", Merge},
+	{"merge_bu", MergeBU},
+	{"merge_x", MergeX},
+	{"quick", Quick},
+	{"quick_x", QuickX},
+	{"quick_three_way", QuickThreeWay},
+	{"quick_bentley_mcIlroy", QuickBentleyMcIlroy},
+	{"heap", Heap},
+	{"tim_sort", TimSort},
+}
+
+// Lookup
+// Returns the algorithm with the given name.
+func Lookup(name string) (Algorithm, error) {
+	for _, alg := range Algorithms {
+		if alg.Name == name {
+			return alg, nil
+		}
+	}
+
+	names := make([]string, 0, len(Algorithms))
+	for _, alg := range Algorithms {
+		names = append(names, alg.Name)
+	}
+	return Algorithm{}, fmt.Errorf("unknown algorithm %q, expected one of %v", name, names)
+}
+
+// Insertion
+// 01_insertion
+func Insertion(x *Array) {
+	insertion.SortArray(x)
+}
+
+// InsertionX
+// 02_insertion_x: put the smallest item in position as a sentinel, then use half exchanges.
+func InsertionX(x *Array) {
+	insertionX.SortArray(x)
+}
+
+// BinaryInsertion
+// 03_binary_insertion
+func BinaryInsertion(x *Array) {
+	binaryInsertion.SortArray(x)
+}
+
+// Selection
+// 04_selection
+func Selection(x *Array) {
+	selection.SortArray(x)
+}
+
+// Shell
+// 05_shell with the 3x+1 increment sequence.
+func Shell(x *Array) {
+	shell.SortArray(x)
+}
+
+// Merge
+// 06_merge, top-down.
+func Merge(x *Array) {
+	merge.SortArray(x)
+}
+
+// MergeBU
+// 07_merge_bu, bottom-up.
+func MergeBU(x *Array) {
+	mergeBU.SortArray(x)
+}
+
+// MergeX
+// 08_merge_x: top-down mergesort that alternates the roles of the array and the auxiliary array,
+// so only the merges into the array are traced.
+func MergeX(x *Array) {
+	mergeX.SortArray(x)
+}
+
+// Quick
+// 10_quick, after an uncounted shuffle.
+func Quick(x *Array) {
+	x.shuffle()
+	quick.SortArray(x)
+}
+
+// QuickX
+// 11_quick_x: partitions around the median of three.
+func QuickX(x *Array) {
+	quickX.SortArray(x)
+}
+
+// QuickThreeWay
+// 12_quick_three_way: Dijkstra's 3-way partitioning, after an uncounted shuffle.
+func QuickThreeWay(x *Array) {
+	x.shuffle()
+	quickThreeWay.SortArray(x)
+}
+
+// QuickBentleyMcIlroy
+// 18_quick_bentley_mcIlroy: Bentley-McIlroy 3-way partitioning with median-of-3 or Tukey ninther pivots.
+func QuickBentleyMcIlroy(x *Array) {
+	quickBentleyMcIlroy.SortArray(x)
+}
+
+// Heap
+// 24_heap
+func Heap(x *Array) {
+	heap.SortArray(x)
+}
+
+// TimSort
+// 32_tim_sort. Timsort moves runs in blocks through its own merge buffer, so its compares come
+// from the less function and its writes from its move count, and the trace holds one "run" step
+// for every run it finds and for every merge of two runs.
+func TimSort(x *Array) {
+	stats := timSort.SortFuncStep(x.a, func(v, w int) bool {
+		return v < w
+	}, func(lo, hi int) {
+		x.record("run", lo, hi)
+	})
+	x.Compares += stats.Compares
+	x.Writes += stats.Moves
+}
@@ -0,0 +1,256 @@
+package priority_queue
+
+import (
+	"cmp"
+	"log"
+)
+
+// PQ
+// A generic priority queue on a d-ary heap. The element with the highest priority is the one
+// that is not less than any other under the comparator: with cmp.Less it is a max-oriented
+// queue like 19_max_pq, with a reversed comparator a min-oriented one like 21_min_pq.
+// The heap is stored 0-based: the children of k are d*k+1 .. d*k+d and its parent is (k-1)/d.
+// A larger d gives a shallower heap, so Insert is cheaper (log_d N compares) and DelTop
+// more expensive (d log_d N compares).
+type PQ[T any] struct {
+	less  func(v, w T) bool
+	d     int
+	items []T
+}
+
+// Option
+// Configures a PQ at construction.
+type Option func(*options)
+
+type options struct {
+	arity    int
+	capacity int
+}
+
+// WithArity
+// Uses a d-ary heap instead of a binary one; d must be at least 2.
+func WithArity(d int) Option {
+	return func(o *options) {
+		if d < 2 {
+			log.Fatalln("heap arity must be at least 2")
+		}
+		o.arity = d
+	}
+}
+
+// WithCapacity
+// Preallocates room for n elements.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{arity: 2}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// New
+// Returns an empty priority queue whose top is the maximum under less.
+func New[T any](less func(v, w T) bool, opts ...Option) *PQ[T] {
+	o := newOptions(opts)
+	return &PQ[T]{
+		less:  less,
+		d:     o.arity,
+		items: make([]T, 0, o.capacity),
+	}
+}
+
+// NewMax
+// Returns an empty max-oriented priority queue of ordered elements.
+func NewMax[T cmp.Ordered](opts ...Option) *PQ[T] {
+	return New(cmp.Less[T], opts...)
+}
+
+// NewMin
+// Returns an empty min-oriented priority queue of ordered elements.
+func NewMin[T cmp.Ordered](opts ...Option) *PQ[T] {
+	return New(func(v, w T) bool { return cmp.Less(w, v) }, opts...)
+}
+
+// Heapify
+// Builds a priority queue from keys in linear time with bottom-up sink-based construction,
+// as in 24_heap. The keys are copied.
+func Heapify[T any](keys []T, less func(v, w T) bool, opts ...Option) *PQ[T] {
+	o := newOptions(opts)
+	pq := &PQ[T]{
+		less:  less,
+		d:     o.arity,
+		items: make([]T, len(keys), max(len(keys), o.capacity)),
+	}
+	copy(pq.items, keys)
+	pq.heapify()
+	return pq
+}
+
+func (pq *PQ[T]) heapify() {
+	if len(pq.items) < 2 {
+		return
+	}
+	for k := (len(pq.items) - 2) / pq.d; k >= 0; k-- {
+		pq.sink(k)
+	}
+}
+
+func (pq *PQ[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+func (pq *PQ[T]) Size() int {
+	return len(pq.items)
+}
+
+// Arity
+// Returns d, the number of children of each heap node.
+func (pq *PQ[T]) Arity() int {
+	return pq.d
+}
+
+// Peek
+// Returns the element with the highest priority without removing it.
+func (pq *PQ[T]) Peek() T {
+	if pq.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	return pq.items[0]
+}
+
+func (pq *PQ[T]) Insert(x T) {
+	pq.items = append(pq.items, x)
+	pq.swim(len(pq.items) - 1)
+}
+
+// DelTop
+// Removes and returns the element with the highest priority.
+func (pq *PQ[T]) DelTop() T {
+	if pq.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+
+	top := pq.items[0]
+	n := len(pq.items) - 1
+	pq.exchange(0, n)
+
+	var zero T
+	pq.items[n] = zero // avoid loitering
+	pq.items = pq.items[:n]
+	pq.sink(0)
+	return top
+}
+
+// Merge
+// Moves all elements of other into pq in time linear in the combined size,
+// leaving other empty. The queues must use the same ordering.
+func (pq *PQ[T]) Merge(other *PQ[T]) {
+	if other == pq || other.IsEmpty() {
+		return
+	}
+
+	// inserting one by one is cheaper when other is small relative to pq
+	if other.Size() <= len(pq.items)/8 {
+		for _, x := range other.items {
+			pq.Insert(x)
+		}
+	} else {
+		pq.items = append(pq.items, other.items...)
+		pq.heapify()
+	}
+	other.items = nil
+}
+
+func (pq *PQ[T]) swim(k int) {
+	for k > 0 {
+		parent := (k - 1) / pq.d
+		if !pq.lessAt(parent, k) {
+			break
+		}
+		pq.exchange(k, parent)
+		k = parent
+	}
+}
+
+func (pq *PQ[T]) sink(k int) {
+	n := len(pq.items)
+	for {
+		first := pq.d*k + 1
+		if first >= n {
+			break
+		}
+
+		// the child with the highest priority
+		j := first
+		for c := first + 1; c < first+pq.d && c < n; c++ {
+			if pq.lessAt(j, c) {
+				j = c
+			}
+		}
+		if !pq.lessAt(k, j) {
+			break
+		}
+		pq.exchange(k, j)
+		k = j
+	}
+}
+
+func (pq *PQ[T]) lessAt(i, j int) bool {
+	return pq.less(pq.items[i], pq.items[j])
+}
+
+func (pq *PQ[T]) exchange(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+}
+
+func (pq *PQ[T]) isHeap() bool {
+	for k := 1; k < len(pq.items); k++ {
+		if pq.lessAt((k-1)/pq.d, k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterator
+// Iterates over the elements in priority order without modifying the queue.
+// Rather than copying the whole heap, it keeps a frontier of heap positions in an auxiliary
+// queue: the next element is the best of the frontier, and taking it adds its children.
+// Producing the first k elements takes O(k d log k) time. Modifying the queue invalidates the iterator.
+type Iterator[T any] struct {
+	pq       *PQ[T]
+	frontier *PQ[int]
+}
+
+func (pq *PQ[T]) Iterator() *Iterator[T] {
+	frontier := New(func(i, j int) bool {
+		return pq.lessAt(i, j)
+	})
+	if !pq.IsEmpty() {
+		frontier.Insert(0)
+	}
+	return &Iterator[T]{pq, frontier}
+}
+
+func (it *Iterator[T]) HasNext() bool {
+	return !it.frontier.IsEmpty()
+}
+
+func (it *Iterator[T]) Next() T {
+	if !it.HasNext() {
+		log.Fatalln("Priority queue underflow")
+	}
+
+	k := it.frontier.DelTop()
+	first := it.pq.d*k + 1
+	for c := first; c < first+it.pq.d && c < it.pq.Size(); c++ {
+		it.frontier.Insert(c)
+	}
+	return it.pq.items[k]
+}
@@ -0,0 +1,78 @@
+package priority_queue
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	pq := Heapify([]int{48, 12, 24, 7, 8, -5, 24, 391, 24, 56, 2, 6, 8, 41}, func(v, w int) bool { return v < w })
+	pq.Insert(76)
+	require.Equal(t, true, pq.isHeap())
+	require.Equal(t, 391, pq.Peek())
+	require.Equal(t, 391, pq.DelTop())
+	require.Equal(t, true, pq.isHeap())
+	require.Equal(t, 76, pq.DelTop())
+	require.Equal(t, 56, pq.Peek())
+	require.Equal(t, 13, pq.Size())
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for d := 2; d <= 5; d++ {
+		pq := NewMin[int](WithArity(d))
+		keys := r.Perm(200)
+		for _, key := range keys {
+			pq.Insert(key)
+			require.Equal(t, true, pq.isHeap())
+		}
+		for i := 0; i < 200; i++ {
+			require.Equal(t, i, pq.DelTop())
+		}
+		require.Equal(t, true, pq.IsEmpty())
+	}
+}
+
+func TestCase3(t *testing.T) {
+	type job struct {
+		name     string
+		priority float64
+	}
+	pq := New(func(v, w job) bool { return v.priority < w.priority }, WithArity(4))
+	pq.Insert(job{"a", 0.5})
+	pq.Insert(job{"b", 2.5})
+	pq.Insert(job{"c", 1.5})
+
+	var names []string
+	for it := pq.Iterator(); it.HasNext(); {
+		names = append(names, it.Next().name)
+	}
+	require.Equal(t, []string{"b", "c", "a"}, names)
+	require.Equal(t, 3, pq.Size())
+	require.Equal(t, "b", pq.Peek().name)
+}
+
+func TestCase4(t *testing.T) {
+	a := NewMax[string](WithArity(3))
+	b := NewMax[string](WithArity(3))
+	for _, s := range []string{"it", "was", "the", "best"} {
+		a.Insert(s)
+	}
+	for _, s := range []string{"of", "times", "worst"} {
+		b.Insert(s)
+	}
+
+	a.Merge(b)
+	require.Equal(t, true, b.IsEmpty())
+	require.Equal(t, 7, a.Size())
+	require.Equal(t, true, a.isHeap())
+
+	want := []string{"it", "was", "the", "best", "of", "times", "worst"}
+	sort.Sort(sort.Reverse(sort.StringSlice(want)))
+	for _, s := range want {
+		require.Equal(t, s, a.DelTop())
+	}
+}
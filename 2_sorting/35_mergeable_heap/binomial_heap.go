@@ -0,0 +1,220 @@
+package mergeable_heap
+
+import "log"
+
+// BinomialHeap
+// A forest of heap-ordered binomial trees with distinct orders, kept as a root list sorted by
+// increasing order, like the binary representation of N. Meld is binary addition of the two root lists.
+type BinomialHeap[T any] struct {
+	less  func(v, w T) bool
+	head  *binomialNode[T]
+	n     int
+	owner *owner
+}
+
+type binomialNode[T any] struct {
+	value  T
+	handle *binomialHandle[T]
+	owner  *owner
+
+	parent, child, sibling *binomialNode[T]
+	order                  int
+}
+
+// Handles point at nodes rather than being nodes, because DecreaseKey swaps values
+// between a node and its parent instead of relinking the tree.
+type binomialHandle[T any] struct {
+	node *binomialNode[T]
+}
+
+func (h *binomialHandle[T]) Value() T {
+	if h.node == nil {
+		log.Fatalln("handle is not in the heap")
+	}
+	return h.node.value
+}
+
+// NewBinomialHeap
+// Returns an empty heap whose minimum is under less.
+func NewBinomialHeap[T any](less func(v, w T) bool) *BinomialHeap[T] {
+	return &BinomialHeap[T]{less: less, owner: &owner{}}
+}
+
+func (h *BinomialHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+func (h *BinomialHeap[T]) Size() int {
+	return h.n
+}
+
+func (h *BinomialHeap[T]) Insert(x T) Handle[T] {
+	node := &binomialNode[T]{value: x, owner: h.owner}
+	node.handle = &binomialHandle[T]{node}
+	h.union(node)
+	h.n++
+	return node.handle
+}
+
+// the root with the smallest key and the root before it
+func (h *BinomialHeap[T]) minRoot() (min, prev *binomialNode[T]) {
+	min = h.head
+	for p, x := h.head, h.head.sibling; x != nil; p, x = x, x.sibling {
+		if h.less(x.value, min.value) {
+			min, prev = x, p
+		}
+	}
+	return min, prev
+}
+
+func (h *BinomialHeap[T]) Min() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	min, _ := h.minRoot()
+	return min.value
+}
+
+func (h *BinomialHeap[T]) DelMin() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	min, prev := h.minRoot()
+	h.removeRoot(min, prev)
+	return min.value
+}
+
+func (h *BinomialHeap[T]) DecreaseKey(handle Handle[T], x T) {
+	node := h.node(handle)
+	if h.less(node.value, x) {
+		log.Fatalln("Calling DecreaseKey() with a key that is strictly greater than the key in the heap")
+	}
+	node.value = x
+	h.swim(node, false)
+}
+
+func (h *BinomialHeap[T]) Delete(handle Handle[T]) {
+	node := h.swim(h.node(handle), true)
+
+	var prev *binomialNode[T]
+	for x := h.head; x != node; x = x.sibling {
+		prev = x
+	}
+	h.removeRoot(node, prev)
+}
+
+// Meld
+// Moves all elements of other into h, leaving other empty. Handles into other stay valid in h.
+func (h *BinomialHeap[T]) Meld(other *BinomialHeap[T]) {
+	if other == h {
+		return
+	}
+	h.union(other.head)
+	h.n += other.n
+	other.owner.next = h.owner
+	other.head, other.n, other.owner = nil, 0, &owner{}
+}
+
+func (h *BinomialHeap[T]) node(handle Handle[T]) *binomialNode[T] {
+	bh, ok := handle.(*binomialHandle[T])
+	if !ok || bh.node == nil {
+		log.Fatalln("handle is not in the heap")
+	}
+	if bh.node.owner = bh.node.owner.find(); bh.node.owner != h.owner {
+		log.Fatalln("handle is not in the heap")
+	}
+	return bh.node
+}
+
+// move the value of node up towards the root while it is smaller than its parent's,
+// or all the way if force, and return the node it ends in
+func (h *BinomialHeap[T]) swim(node *binomialNode[T], force bool) *binomialNode[T] {
+	for node.parent != nil && (force || h.less(node.value, node.parent.value)) {
+		parent := node.parent
+		node.value, parent.value = parent.value, node.value
+		node.handle, parent.handle = parent.handle, node.handle
+		node.handle.node, parent.handle.node = node, parent
+		node = parent
+	}
+	return node
+}
+
+// remove the root after prev from the root list and union its children back in
+func (h *BinomialHeap[T]) removeRoot(root, prev *binomialNode[T]) {
+	if prev == nil {
+		h.head = root.sibling
+	} else {
+		prev.sibling = root.sibling
+	}
+
+	// the children are in decreasing order, so reverse them
+	var children *binomialNode[T]
+	for x := root.child; x != nil; {
+		next := x.sibling
+		x.parent = nil
+		x.sibling = children
+		children = x
+		x = next
+	}
+
+	h.union(children)
+	h.n--
+	root.handle.node = nil
+}
+
+// make y a child of z; both are roots of order k
+func (h *BinomialHeap[T]) link(y, z *binomialNode[T]) {
+	y.parent = z
+	y.sibling = z.child
+	z.child = y
+	z.order++
+}
+
+// merge the root list starting at other into the root list of h, then link trees
+// of equal order, like carries in binary addition
+func (h *BinomialHeap[T]) union(other *binomialNode[T]) {
+	h.head = h.merge(h.head, other)
+	if h.head == nil {
+		return
+	}
+
+	var prev *binomialNode[T]
+	x, next := h.head, h.head.sibling
+	for next != nil {
+		if x.order != next.order || (next.sibling != nil && next.sibling.order == x.order) {
+			prev, x = x, next
+		} else if !h.less(next.value, x.value) {
+			x.sibling = next.sibling
+			h.link(next, x)
+		} else {
+			if prev == nil {
+				h.head = next
+			} else {
+				prev.sibling = next
+			}
+			h.link(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+}
+
+// merge two root lists sorted by order
+func (h *BinomialHeap[T]) merge(x, y *binomialNode[T]) *binomialNode[T] {
+	head := &binomialNode[T]{}
+	tail := head
+	for x != nil && y != nil {
+		if x.order <= y.order {
+			tail.sibling, x = x, x.sibling
+		} else {
+			tail.sibling, y = y, y.sibling
+		}
+		tail = tail.sibling
+	}
+	if x != nil {
+		tail.sibling = x
+	} else {
+		tail.sibling = y
+	}
+	return head.sibling
+}
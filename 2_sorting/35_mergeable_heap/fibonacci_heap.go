@@ -0,0 +1,250 @@
+package mergeable_heap
+
+import "log"
+
+// FibonacciHeap
+// A lazy collection of heap-ordered trees in a circular root list. Insert and Meld only splice
+// root lists; DelMin consolidates the roots so that no two have the same degree. DecreaseKey
+// cuts the node from its parent, and a parent that loses a second child is cut as well
+// (cascading cut), which keeps the size of a subtree of degree k at least F(k+2).
+type FibonacciHeap[T any] struct {
+	less  func(v, w T) bool
+	min   *fibonacciNode[T]
+	n     int
+	owner *owner
+}
+
+type fibonacciNode[T any] struct {
+	value   T
+	removed bool
+	owner   *owner
+
+	parent, child, left, right *fibonacciNode[T]
+	degree                     int
+	mark                       bool // lost a child since it became a child itself
+}
+
+func (x *fibonacciNode[T]) Value() T {
+	return x.value
+}
+
+// NewFibonacciHeap
+// Returns an empty heap whose minimum is under less.
+func NewFibonacciHeap[T any](less func(v, w T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{less: less, owner: &owner{}}
+}
+
+func (h *FibonacciHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+func (h *FibonacciHeap[T]) Size() int {
+	return h.n
+}
+
+func (h *FibonacciHeap[T]) Insert(x T) Handle[T] {
+	node := &fibonacciNode[T]{value: x, owner: h.owner}
+	node.left, node.right = node, node
+	h.addRoot(node)
+	h.n++
+	return node
+}
+
+func (h *FibonacciHeap[T]) Min() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	return h.min.value
+}
+
+func (h *FibonacciHeap[T]) DelMin() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	z := h.min
+
+	// move the children of z to the root list
+	if z.child != nil {
+		children := h.list(z.child)
+		for _, x := range children {
+			x.parent = nil
+			x.mark = false
+			x.left, x.right = x, x
+			h.splice(z, x)
+		}
+		z.child = nil
+	}
+
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.unlink(z)
+		h.consolidate()
+	}
+
+	h.n--
+	z.removed = true
+	return z.value
+}
+
+func (h *FibonacciHeap[T]) DecreaseKey(handle Handle[T], x T) {
+	node := h.node(handle)
+	if h.less(node.value, x) {
+		log.Fatalln("Calling DecreaseKey() with a key that is strictly greater than the key in the heap")
+	}
+	node.value = x
+
+	if parent := node.parent; parent != nil && h.less(node.value, parent.value) {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+	if h.less(node.value, h.min.value) {
+		h.min = node
+	}
+}
+
+// Delete
+// Cuts the node to the root list, treats it as the minimum and removes it.
+func (h *FibonacciHeap[T]) Delete(handle Handle[T]) {
+	node := h.node(handle)
+	if parent := node.parent; parent != nil {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+	h.min = node
+	h.DelMin()
+}
+
+// Meld
+// Moves all elements of other into h, leaving other empty. Handles into other stay valid in h.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		h.splice(h.min, other.min)
+		if h.less(other.min.value, h.min.value) {
+			h.min = other.min
+		}
+	}
+	h.n += other.n
+	other.owner.next = h.owner
+	other.min, other.n, other.owner = nil, 0, &owner{}
+}
+
+func (h *FibonacciHeap[T]) node(handle Handle[T]) *fibonacciNode[T] {
+	node, ok := handle.(*fibonacciNode[T])
+	if !ok || node.removed {
+		log.Fatalln("handle is not in the heap")
+	}
+	if node.owner = node.owner.find(); node.owner != h.owner {
+		log.Fatalln("handle is not in the heap")
+	}
+	return node
+}
+
+// add a single detached node to the root list
+func (h *FibonacciHeap[T]) addRoot(x *fibonacciNode[T]) {
+	if h.min == nil {
+		h.min = x
+		return
+	}
+	h.splice(h.min, x)
+	if h.less(x.value, h.min.value) {
+		h.min = x
+	}
+}
+
+// join the circular lists containing a and b
+func (h *FibonacciHeap[T]) splice(a, b *fibonacciNode[T]) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	bLeft.right, aRight.left = aRight, bLeft
+}
+
+// remove x from its circular list, leaving it a list of one
+func (h *FibonacciHeap[T]) unlink(x *fibonacciNode[T]) {
+	x.left.right = x.right
+	x.right.left = x.left
+	x.left, x.right = x, x
+}
+
+// the nodes of the circular list containing x
+func (h *FibonacciHeap[T]) list(x *fibonacciNode[T]) []*fibonacciNode[T] {
+	nodes := []*fibonacciNode[T]{x}
+	for y := x.right; y != x; y = y.right {
+		nodes = append(nodes, y)
+	}
+	return nodes
+}
+
+// link roots of equal degree until all degrees are distinct, then find the new minimum
+func (h *FibonacciHeap[T]) consolidate() {
+	var byDegree []*fibonacciNode[T]
+	for _, x := range h.list(h.min) {
+		h.unlink(x)
+		d := x.degree
+		for d < len(byDegree) && byDegree[d] != nil {
+			y := byDegree[d]
+			if h.less(y.value, x.value) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+		for d >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[d] = x
+	}
+
+	h.min = nil
+	for _, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+// make the detached root y a child of x
+func (h *FibonacciHeap[T]) link(y, x *fibonacciNode[T]) {
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		x.child = y
+	} else {
+		h.splice(x.child, y)
+	}
+	x.degree++
+}
+
+// move x from the children of parent to the root list
+func (h *FibonacciHeap[T]) cut(x, parent *fibonacciNode[T]) {
+	if parent.child == x {
+		if x.right == x {
+			parent.child = nil
+		} else {
+			parent.child = x.right
+		}
+	}
+	h.unlink(x)
+	parent.degree--
+
+	x.parent = nil
+	x.mark = false
+	h.splice(h.min, x)
+}
+
+func (h *FibonacciHeap[T]) cascadingCut(y *fibonacciNode[T]) {
+	for z := y.parent; z != nil; y, z = z, z.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+	}
+}
@@ -0,0 +1,51 @@
+package mergeable_heap
+
+// Mergeable (meldable) min-oriented heaps with handles. Insert returns a handle to the new
+// element that stays valid until the element is removed, even across Meld, and is used to
+// decrease its key or delete it without a fixed-capacity index array as in 22_index_min_pq.
+// A handle is only accepted by the heap that holds its element.
+//
+//                 Insert     Min      DelMin     DecreaseKey   Delete     Meld
+// binary heap     log N      1        log N      log N         log N      N
+// binomial        log N      log N    log N      log N         log N      log N
+// pairing         1          1        log N*     log N*(+)     log N*     1
+// Fibonacci       1          1        log N*     1*            log N*     1
+//
+// * amortized; (+) the exact bound for the pairing heap is open, o(log N) is known.
+
+// Heap
+// The operations shared by the mergeable heaps. Meld is defined on the concrete types
+// because both heaps must be of the same kind.
+type Heap[T any] interface {
+	IsEmpty() bool
+	Size() int
+	Insert(x T) Handle[T]
+	Min() T
+	DelMin() T
+	DecreaseKey(h Handle[T], x T)
+	Delete(h Handle[T])
+}
+
+// Handle
+// A reference to an element of a heap.
+type Handle[T any] interface {
+	Value() T
+}
+
+// Every node records the heap it was inserted into, so that a handle from another heap is
+// rejected instead of corrupting both. Meld cannot visit the nodes of other, so it forwards
+// the owner of other to the owner of h, as union-find links a root to another, and gives
+// other a fresh owner; looking up the owner of a node follows the links with path halving.
+type owner struct {
+	next *owner
+}
+
+func (o *owner) find() *owner {
+	for o.next != nil {
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+		o = o.next
+	}
+	return o
+}
@@ -0,0 +1,116 @@
+package mergeable_heap
+
+import "log"
+
+// Kind
+// Selects the heap behind an IndexMinPQ.
+type Kind int
+
+const (
+	Binomial Kind = iota
+	Pairing
+	Fibonacci
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Binomial:
+		return "binomial"
+	case Pairing:
+		return "pairing"
+	case Fibonacci:
+		return "fibonacci"
+	}
+	return "unknown"
+}
+
+type entry struct {
+	idx      int
+	priority float64
+}
+
+// IndexMinPQ
+// A vertex-indexed min priority queue with float64 priorities on top of a mergeable heap,
+// with the operations of 22_index_min_pq that the graph algorithms use. The indices need
+// not be bounded in advance.
+type IndexMinPQ struct {
+	heap    Heap[entry]
+	handles map[int]Handle[entry]
+}
+
+// NewIndexMinPQ
+// Returns an empty index priority queue backed by the given kind of heap.
+func NewIndexMinPQ(kind Kind) *IndexMinPQ {
+	less := func(v, w entry) bool {
+		return v.priority < w.priority
+	}
+
+	pq := &IndexMinPQ{handles: make(map[int]Handle[entry])}
+	switch kind {
+	case Binomial:
+		pq.heap = NewBinomialHeap(less)
+	case Pairing:
+		pq.heap = NewPairingHeap(less)
+	case Fibonacci:
+		pq.heap = NewFibonacciHeap(less)
+	default:
+		log.Fatalln("unknown heap kind", int(kind))
+	}
+	return pq
+}
+
+func (pq *IndexMinPQ) IsEmpty() bool {
+	return pq.heap.IsEmpty()
+}
+
+func (pq *IndexMinPQ) Size() int {
+	return pq.heap.Size()
+}
+
+func (pq *IndexMinPQ) Contains(idx int) bool {
+	_, ok := pq.handles[idx]
+	return ok
+}
+
+func (pq *IndexMinPQ) Insert(idx int, x float64) {
+	if pq.Contains(idx) {
+		log.Fatalln("index is already in the priority queue")
+	}
+	pq.handles[idx] = pq.heap.Insert(entry{idx, x})
+}
+
+func (pq *IndexMinPQ) Min() int {
+	return pq.heap.Min().idx
+}
+
+func (pq *IndexMinPQ) MinPriority() float64 {
+	return pq.heap.Min().priority
+}
+
+func (pq *IndexMinPQ) DelMin() int {
+	min := pq.heap.DelMin()
+	delete(pq.handles, min.idx)
+	return min.idx
+}
+
+func (pq *IndexMinPQ) PriorityOf(idx int) float64 {
+	if !pq.Contains(idx) {
+		log.Fatalln("index is not in the priority queue")
+	}
+	return pq.handles[idx].Value().priority
+}
+
+func (pq *IndexMinPQ) DecreasePriority(idx int, x float64) {
+	if !pq.Contains(idx) {
+		log.Fatalln("index is not in the priority queue")
+	}
+	pq.heap.DecreaseKey(pq.handles[idx], entry{idx, x})
+}
+
+func (pq *IndexMinPQ) Delete(idx int) {
+	if !pq.Contains(idx) {
+		log.Fatalln("index is not in the priority queue")
+	}
+	pq.heap.Delete(pq.handles[idx])
+	delete(pq.handles, idx)
+}
@@ -0,0 +1,218 @@
+package mergeable_heap
+
+import (
+	indexMinPQ "github.com/lee-hen/Algorithms/2_sorting/22_index_min_pq"
+	graph "github.com/lee-hen/Algorithms/4_graphs/23_edge_weighted_graph"
+	digraph "github.com/lee-hen/Algorithms/4_graphs/24_edge_weighted_digraph"
+	prim "github.com/lee-hen/Algorithms/4_graphs/37_prim_mst"
+	dijkstra "github.com/lee-hen/Algorithms/4_graphs/39_dijkstra_sp"
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"os"
+	"os/exec"
+	"sort"
+	"testing"
+)
+
+func less(v, w int) bool {
+	return v < w
+}
+
+type meldable interface {
+	Heap[int]
+	meld(other Heap[int])
+}
+
+type binomial struct{ *BinomialHeap[int] }
+type pairing struct{ *PairingHeap[int] }
+type fibonacci struct{ *FibonacciHeap[int] }
+
+func (h binomial) meld(other Heap[int])  { h.Meld(other.(binomial).BinomialHeap) }
+func (h pairing) meld(other Heap[int])   { h.Meld(other.(pairing).PairingHeap) }
+func (h fibonacci) meld(other Heap[int]) { h.Meld(other.(fibonacci).FibonacciHeap) }
+
+var heaps = map[string]func() meldable{
+	"binomial":  func() meldable { return binomial{NewBinomialHeap(less)} },
+	"pairing":   func() meldable { return pairing{NewPairingHeap(less)} },
+	"fibonacci": func() meldable { return fibonacci{NewFibonacciHeap(less)} },
+}
+
+func drain(h Heap[int]) []int {
+	var keys []int
+	for !h.IsEmpty() {
+		keys = append(keys, h.DelMin())
+	}
+	return keys
+}
+
+func TestCase1(t *testing.T) {
+	for name, newHeap := range heaps {
+		h := newHeap()
+		for _, key := range []int{48, 12, 24, 7, 8, -5, 24, 391, 24, 56, 2, 6, 8, 41} {
+			h.Insert(key)
+		}
+		require.Equal(t, 14, h.Size(), name)
+		require.Equal(t, -5, h.Min(), name)
+		require.Equal(t, []int{-5, 2, 6, 7, 8, 8, 12, 24, 24, 24, 41, 48, 56, 391}, drain(h), name)
+	}
+}
+
+func TestCase2(t *testing.T) {
+	// random inserts, decrease-keys, deletes and delete-mins checked against the live handles;
+	// ids break ties so that DelMin identifies exactly one handle
+	type item struct{ key, id int }
+	less := func(v, w item) bool {
+		return v.key < w.key || v.key == w.key && v.id < w.id
+	}
+	for name, h := range map[string]Heap[item]{
+		"binomial":  NewBinomialHeap(less),
+		"pairing":   NewPairingHeap(less),
+		"fibonacci": NewFibonacciHeap(less),
+	} {
+		r := rand.New(rand.NewSource(1))
+		handles := make(map[int]Handle[item])
+		for i := 0; i < 5000; i++ {
+			switch op := r.Intn(10); {
+			case op < 5:
+				handles[i] = h.Insert(item{r.Intn(1000), i})
+			case op < 7 && len(handles) > 0:
+				for _, handle := range handles {
+					x := handle.Value()
+					h.DecreaseKey(handle, item{x.key - r.Intn(100), x.id})
+					break
+				}
+			case op < 8 && len(handles) > 0:
+				for id, handle := range handles {
+					h.Delete(handle)
+					delete(handles, id)
+					break
+				}
+			case len(handles) > 0:
+				delete(handles, h.DelMin().id)
+			}
+			require.Equal(t, len(handles), h.Size(), name)
+		}
+
+		var want []item
+		for _, handle := range handles {
+			want = append(want, handle.Value())
+		}
+		sort.Slice(want, func(i, j int) bool { return less(want[i], want[j]) })
+		for _, x := range want {
+			require.Equal(t, x, h.DelMin(), name)
+		}
+		require.Equal(t, true, h.IsEmpty(), name)
+	}
+}
+
+func TestCase3(t *testing.T) {
+	for name, newHeap := range heaps {
+		a, b := newHeap(), newHeap()
+		var handles []Handle[int]
+		for i := 0; i < 50; i++ {
+			a.Insert(2 * i)
+			handles = append(handles, b.Insert(2*i+1))
+		}
+
+		a.meld(b)
+		require.Equal(t, true, b.IsEmpty(), name)
+		require.Equal(t, 100, a.Size(), name)
+
+		// handles into b are still valid in a
+		a.DecreaseKey(handles[49], -1)
+		a.Delete(handles[0])
+		require.Equal(t, -1, a.DelMin(), name)
+		require.Equal(t, 0, a.DelMin(), name)
+		require.Equal(t, 2, a.DelMin(), name)
+		require.Equal(t, 3, a.DelMin(), name)
+		require.Equal(t, 95, a.Size(), name)
+	}
+}
+
+func TestCase4(t *testing.T) {
+	// the graph algorithms give the same results with every heap
+	for _, kind := range []Kind{Binomial, Pairing, Fibonacci} {
+		g := digraph.NewRandomEdgeWeightedDigraph(200, 2000)
+		want := dijkstra.New(g, 0)
+		sp := dijkstra.NewWithPQ(g, 0, NewIndexMinPQ(kind))
+		for v := 0; v < g.V; v++ {
+			require.Equal(t, want.HasPathTo(v), sp.HasPathTo(v), kind.String())
+			require.InDelta(t, want.DistTo(v), sp.DistTo(v), 1e-9, kind.String())
+		}
+
+		ug := graph.NewRandomEdgeWeightedGraph(200, 1000)
+		require.InDelta(t, prim.New(ug).Weight(), prim.NewWithPQ(ug, NewIndexMinPQ(kind)).Weight(), 1e-9, kind.String())
+	}
+}
+
+func TestCase5(t *testing.T) {
+	// a handle from another heap makes DecreaseKey and Delete exit, which only a separate
+	// process can see; handles melded in are valid, through any number of melds
+	if name := os.Getenv("MERGEABLE_HEAP_FATAL"); name != "" {
+		a, b := heaps[name](), heaps[name]()
+		handle := a.Insert(1)
+		b.Insert(2)
+		if os.Getenv("MERGEABLE_HEAP_OP") == "delete" {
+			b.Delete(handle)
+		} else {
+			b.DecreaseKey(handle, 0)
+		}
+		return
+	}
+
+	for name, newHeap := range heaps {
+		for _, op := range []string{"decrease", "delete"} {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestCase5$")
+			cmd.Env = append(os.Environ(), "MERGEABLE_HEAP_FATAL="+name, "MERGEABLE_HEAP_OP="+op)
+			out, err := cmd.CombinedOutput()
+			require.IsType(t, (*exec.ExitError)(nil), err, name)
+			require.Contains(t, string(out), "handle is not in the heap", name)
+		}
+
+		a, b, c := newHeap(), newHeap(), newHeap()
+		ha, hb := a.Insert(10), b.Insert(20)
+		a.meld(b)
+		c.Insert(30)
+		c.meld(a)
+		hb2 := b.Insert(40)
+		c.DecreaseKey(hb, 5)
+		c.Delete(ha)
+		b.DecreaseKey(hb2, 0)
+		require.Equal(t, []int{5, 30}, drain(c), name)
+		require.Equal(t, []int{0}, drain(b), name)
+	}
+}
+
+// go test -run none -bench . compares the heaps on random graphs
+func BenchmarkDijkstra(b *testing.B) {
+	g := digraph.NewRandomEdgeWeightedDigraph(10000, 100000)
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dijkstra.NewWithPQ(g, 0, indexMinPQ.NewIndexMinPQ(g.V))
+		}
+	})
+	for _, kind := range []Kind{Binomial, Pairing, Fibonacci} {
+		b.Run(kind.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dijkstra.NewWithPQ(g, 0, NewIndexMinPQ(kind))
+			}
+		})
+	}
+}
+
+func BenchmarkPrim(b *testing.B) {
+	g := graph.NewRandomEdgeWeightedGraph(250, 2500)
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			prim.NewWithPQ(g, indexMinPQ.NewIndexMinPQ(g.V))
+		}
+	})
+	for _, kind := range []Kind{Binomial, Pairing, Fibonacci} {
+		b.Run(kind.String(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				prim.NewWithPQ(g, NewIndexMinPQ(kind))
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package mergeable_heap
+
+import "log"
+
+// PairingHeap
+// A single heap-ordered multiway tree. Insert, Meld and DecreaseKey just link trees by
+// making the larger root the leftmost child of the smaller one; DelMin pays for it by
+// pairing up the children of the root left to right and then melding the pairs right to left.
+type PairingHeap[T any] struct {
+	less  func(v, w T) bool
+	root  *pairingNode[T]
+	n     int
+	owner *owner
+}
+
+// the children of a node form a doubly linked list; prev is the left sibling,
+// or the parent for the leftmost child
+type pairingNode[T any] struct {
+	value   T
+	removed bool
+	owner   *owner
+
+	child, sibling, prev *pairingNode[T]
+}
+
+func (x *pairingNode[T]) Value() T {
+	return x.value
+}
+
+// NewPairingHeap
+// Returns an empty heap whose minimum is under less.
+func NewPairingHeap[T any](less func(v, w T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less, owner: &owner{}}
+}
+
+func (h *PairingHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+func (h *PairingHeap[T]) Size() int {
+	return h.n
+}
+
+func (h *PairingHeap[T]) Insert(x T) Handle[T] {
+	node := &pairingNode[T]{value: x, owner: h.owner}
+	h.root = h.link(h.root, node)
+	h.n++
+	return node
+}
+
+func (h *PairingHeap[T]) Min() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	return h.root.value
+}
+
+func (h *PairingHeap[T]) DelMin() T {
+	if h.IsEmpty() {
+		log.Fatalln("Priority queue underflow")
+	}
+	min := h.root
+	h.root = h.combine(min.child)
+	h.remove(min)
+	return min.value
+}
+
+func (h *PairingHeap[T]) DecreaseKey(handle Handle[T], x T) {
+	node := h.node(handle)
+	if h.less(node.value, x) {
+		log.Fatalln("Calling DecreaseKey() with a key that is strictly greater than the key in the heap")
+	}
+	node.value = x
+	if node != h.root {
+		h.cut(node)
+		h.root = h.link(h.root, node)
+	}
+}
+
+func (h *PairingHeap[T]) Delete(handle Handle[T]) {
+	node := h.node(handle)
+	if node == h.root {
+		h.DelMin()
+		return
+	}
+	h.cut(node)
+	h.root = h.link(h.root, h.combine(node.child))
+	h.remove(node)
+}
+
+// Meld
+// Moves all elements of other into h, leaving other empty. Handles into other stay valid in h.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.n += other.n
+	other.owner.next = h.owner
+	other.root, other.n, other.owner = nil, 0, &owner{}
+}
+
+func (h *PairingHeap[T]) node(handle Handle[T]) *pairingNode[T] {
+	node, ok := handle.(*pairingNode[T])
+	if !ok || node.removed {
+		log.Fatalln("handle is not in the heap")
+	}
+	if node.owner = node.owner.find(); node.owner != h.owner {
+		log.Fatalln("handle is not in the heap")
+	}
+	return node
+}
+
+func (h *PairingHeap[T]) remove(node *pairingNode[T]) {
+	h.n--
+	node.removed = true
+	node.child = nil
+}
+
+// link two detached trees, either of which may be nil, and return the root
+func (h *PairingHeap[T]) link(x, y *pairingNode[T]) *pairingNode[T] {
+	if x == nil {
+		return y
+	}
+	if y == nil {
+		return x
+	}
+	if h.less(y.value, x.value) {
+		x, y = y, x
+	}
+
+	y.prev = x
+	y.sibling = x.child
+	if x.child != nil {
+		x.child.prev = y
+	}
+	x.child = y
+	return x
+}
+
+// detach the subtree rooted at x from its parent
+func (h *PairingHeap[T]) cut(x *pairingNode[T]) {
+	if x.prev.child == x {
+		x.prev.child = x.sibling
+	} else {
+		x.prev.sibling = x.sibling
+	}
+	if x.sibling != nil {
+		x.sibling.prev = x.prev
+	}
+	x.prev, x.sibling = nil, nil
+}
+
+// two-pass pairing of the sibling list starting at first
+func (h *PairingHeap[T]) combine(first *pairingNode[T]) *pairingNode[T] {
+	if first == nil {
+		return nil
+	}
+
+	var pairs []*pairingNode[T]
+	for x := first; x != nil; {
+		y := x.sibling
+		var next *pairingNode[T]
+		if y != nil {
+			next = y.sibling
+			y.prev, y.sibling = nil, nil
+		}
+		x.prev, x.sibling = nil, nil
+		pairs = append(pairs, h.link(x, y))
+		x = next
+	}
+
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	return root
+}
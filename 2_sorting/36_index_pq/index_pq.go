@@ -0,0 +1,315 @@
+package index_pq
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
+
+// Generic index priority queues. Unlike 20_index_max_pq and 22_index_min_pq, the indices are
+// any comparable handle (a vertex number, a job ID string, ...) and need not be bounded in advance:
+// each handle is mapped to a slot, slots grow as needed and are reused after removal.
+// Invalid operations return errors instead of exiting.
+
+var (
+	ErrUnderflow    = errors.New("priority queue underflow")
+	ErrNotInPQ      = errors.New("index is not in the priority queue")
+	ErrAlreadyInPQ  = errors.New("index is already in the priority queue")
+	ErrNotIncreased = errors.New("priority is not strictly greater than the priority in the priority queue")
+	ErrNotDecreased = errors.New("priority is not strictly less than the priority in the priority queue")
+)
+
+// IndexMinPQ
+// An index priority queue that removes the index with the smallest priority first.
+type IndexMinPQ[K comparable, P cmp.Ordered] struct {
+	*Heap[K, P]
+}
+
+// NewIndexMinPQ
+// Returns an empty index min priority queue.
+func NewIndexMinPQ[K comparable, P cmp.Ordered]() *IndexMinPQ[K, P] {
+	return &IndexMinPQ[K, P]{
+		NewHeap[K](func(p, q P) bool { return p > q }),
+	}
+}
+
+// Min
+// Returns an index associated with a minimum priority.
+func (pq *IndexMinPQ[K, P]) Min() (K, error) {
+	return pq.top()
+}
+
+// MinPriority
+// Returns a minimum priority.
+func (pq *IndexMinPQ[K, P]) MinPriority() (P, error) {
+	return pq.topPriority()
+}
+
+// DelMin
+// Removes a minimum priority and returns its associated index.
+func (pq *IndexMinPQ[K, P]) DelMin() (K, error) {
+	return pq.delTop()
+}
+
+// DecreasePriority
+// Decreases the priority associated with index k to p, which must be strictly less than the current one.
+func (pq *IndexMinPQ[K, P]) DecreasePriority(k K, p P) error {
+	return decreasePriority(pq.Heap, k, p)
+}
+
+// IncreasePriority
+// Increases the priority associated with index k to p, which must be strictly greater than the current one.
+func (pq *IndexMinPQ[K, P]) IncreasePriority(k K, p P) error {
+	return increasePriority(pq.Heap, k, p)
+}
+
+// IndexMaxPQ
+// An index priority queue that removes the index with the largest priority first.
+type IndexMaxPQ[K comparable, P cmp.Ordered] struct {
+	*Heap[K, P]
+}
+
+// NewIndexMaxPQ
+// Returns an empty index max priority queue.
+func NewIndexMaxPQ[K comparable, P cmp.Ordered]() *IndexMaxPQ[K, P] {
+	return &IndexMaxPQ[K, P]{
+		NewHeap[K](func(p, q P) bool { return p < q }),
+	}
+}
+
+// Max
+// Returns an index associated with a maximum priority.
+func (pq *IndexMaxPQ[K, P]) Max() (K, error) {
+	return pq.top()
+}
+
+// MaxPriority
+// Returns a maximum priority.
+func (pq *IndexMaxPQ[K, P]) MaxPriority() (P, error) {
+	return pq.topPriority()
+}
+
+// DelMax
+// Removes a maximum priority and returns its associated index.
+func (pq *IndexMaxPQ[K, P]) DelMax() (K, error) {
+	return pq.delTop()
+}
+
+// DecreasePriority
+// Decreases the priority associated with index k to p, which must be strictly less than the current one.
+func (pq *IndexMaxPQ[K, P]) DecreasePriority(k K, p P) error {
+	return decreasePriority(pq.Heap, k, p)
+}
+
+// IncreasePriority
+// Increases the priority associated with index k to p, which must be strictly greater than the current one.
+func (pq *IndexMaxPQ[K, P]) IncreasePriority(k K, p P) error {
+	return increasePriority(pq.Heap, k, p)
+}
+
+func decreasePriority[K comparable, P cmp.Ordered](h *Heap[K, P], k K, p P) error {
+	current, err := h.PriorityOf(k)
+	if err != nil {
+		return err
+	}
+	if p >= current {
+		return fmt.Errorf("%w: %v", ErrNotDecreased, k)
+	}
+	return h.ChangePriority(k, p)
+}
+
+func increasePriority[K comparable, P cmp.Ordered](h *Heap[K, P], k K, p P) error {
+	current, err := h.PriorityOf(k)
+	if err != nil {
+		return err
+	}
+	if p <= current {
+		return fmt.Errorf("%w: %v", ErrNotIncreased, k)
+	}
+	return h.ChangePriority(k, p)
+}
+
+// Heap
+// The binary heap shared by IndexMinPQ and IndexMaxPQ, with the same pq/qp arrangement as
+// 22_index_min_pq but over slots: pq[i] is the slot at heap position i (1-based), qp[s] the heap
+// position of slot s, and keys[s], priorities[s] the index and priority stored in slot s.
+// The top of the heap is the priority that no other priority is less than under less.
+type Heap[K comparable, P any] struct {
+	less func(p, q P) bool
+
+	slots      map[K]int
+	keys       []K
+	priorities []P
+	pq, qp     []int
+	free       []int // slots to reuse
+}
+
+// NewHeap
+// Returns an empty heap ordered by less, which makes a custom index priority queue.
+func NewHeap[K comparable, P any](less func(p, q P) bool) *Heap[K, P] {
+	return &Heap[K, P]{
+		less:  less,
+		slots: make(map[K]int),
+		pq:    []int{-1},
+	}
+}
+
+func (h *Heap[K, P]) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+func (h *Heap[K, P]) Size() int {
+	return len(h.pq) - 1
+}
+
+func (h *Heap[K, P]) Contains(k K) bool {
+	_, ok := h.slots[k]
+	return ok
+}
+
+// Insert
+// Associates priority p with index k.
+func (h *Heap[K, P]) Insert(k K, p P) error {
+	if h.Contains(k) {
+		return fmt.Errorf("%w: %v", ErrAlreadyInPQ, k)
+	}
+
+	var s int
+	if n := len(h.free); n > 0 {
+		s, h.free = h.free[n-1], h.free[:n-1]
+		h.keys[s], h.priorities[s] = k, p
+	} else {
+		s = len(h.keys)
+		h.keys = append(h.keys, k)
+		h.priorities = append(h.priorities, p)
+		h.qp = append(h.qp, 0)
+	}
+
+	h.slots[k] = s
+	h.pq = append(h.pq, s)
+	h.qp[s] = h.Size()
+	h.swim(h.Size())
+	return nil
+}
+
+// PriorityOf
+// Returns the priority associated with index k.
+func (h *Heap[K, P]) PriorityOf(k K) (P, error) {
+	s, ok := h.slots[k]
+	if !ok {
+		var zero P
+		return zero, fmt.Errorf("%w: %v", ErrNotInPQ, k)
+	}
+	return h.priorities[s], nil
+}
+
+// ChangePriority
+// Changes the priority associated with index k to p.
+func (h *Heap[K, P]) ChangePriority(k K, p P) error {
+	s, ok := h.slots[k]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrNotInPQ, k)
+	}
+
+	h.priorities[s] = p
+	h.swim(h.qp[s])
+	h.sink(h.qp[s])
+	return nil
+}
+
+// Delete
+// Removes index k and its associated priority.
+func (h *Heap[K, P]) Delete(k K) error {
+	s, ok := h.slots[k]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrNotInPQ, k)
+	}
+	h.remove(h.qp[s])
+	return nil
+}
+
+// Keys
+// Returns the indices in heap order (not sorted).
+func (h *Heap[K, P]) Keys() []K {
+	keys := make([]K, 0, h.Size())
+	for _, s := range h.pq[1:] {
+		keys = append(keys, h.keys[s])
+	}
+	return keys
+}
+
+func (h *Heap[K, P]) top() (K, error) {
+	if h.IsEmpty() {
+		var zero K
+		return zero, ErrUnderflow
+	}
+	return h.keys[h.pq[1]], nil
+}
+
+func (h *Heap[K, P]) topPriority() (P, error) {
+	if h.IsEmpty() {
+		var zero P
+		return zero, ErrUnderflow
+	}
+	return h.priorities[h.pq[1]], nil
+}
+
+func (h *Heap[K, P]) delTop() (K, error) {
+	k, err := h.top()
+	if err != nil {
+		return k, err
+	}
+	h.remove(1)
+	return k, nil
+}
+
+// remove the slot at heap position i and free it
+func (h *Heap[K, P]) remove(i int) {
+	s := h.pq[i]
+	n := h.Size()
+	h.exchange(i, n)
+	h.pq = h.pq[:n]
+	if i < n {
+		h.swim(i)
+		h.sink(i)
+	}
+
+	delete(h.slots, h.keys[s])
+	var zeroK K
+	var zeroP P
+	h.keys[s], h.priorities[s] = zeroK, zeroP // avoid loitering
+	h.qp[s] = -1
+	h.free = append(h.free, s)
+}
+
+func (h *Heap[K, P]) swim(k int) {
+	for k > 1 && h.lessAt(k/2, k) {
+		h.exchange(k, k/2)
+		k = k / 2
+	}
+}
+
+func (h *Heap[K, P]) sink(k int) {
+	n := h.Size()
+	for 2*k <= n {
+		j := 2 * k
+		if j < n && h.lessAt(j, j+1) {
+			j++
+		}
+		if !h.lessAt(k, j) {
+			break
+		}
+		h.exchange(k, j)
+		k = j
+	}
+}
+
+func (h *Heap[K, P]) lessAt(i, j int) bool {
+	return h.less(h.priorities[h.pq[i]], h.priorities[h.pq[j]])
+}
+
+func (h *Heap[K, P]) exchange(i, j int) {
+	h.pq[i], h.pq[j] = h.pq[j], h.pq[i]
+	h.qp[h.pq[i]] = i
+	h.qp[h.pq[j]] = j
+}
@@ -0,0 +1,106 @@
+package index_pq
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	pq := NewIndexMinPQ[string, float64]()
+	jobs := map[string]float64{"build": 3.5, "test": 1.25, "deploy": 7, "lint": 0.5, "docs": 2}
+	for job, priority := range jobs {
+		require.NoError(t, pq.Insert(job, priority))
+	}
+	require.Equal(t, 5, pq.Size())
+
+	min, err := pq.Min()
+	require.NoError(t, err)
+	require.Equal(t, "lint", min)
+
+	require.NoError(t, pq.DecreasePriority("deploy", 0.1))
+	require.NoError(t, pq.IncreasePriority("lint", 9))
+	require.NoError(t, pq.Delete("docs"))
+
+	var order []string
+	for !pq.IsEmpty() {
+		job, err := pq.DelMin()
+		require.NoError(t, err)
+		order = append(order, job)
+	}
+	require.Equal(t, []string{"deploy", "test", "build", "lint"}, order)
+
+	_, err = pq.DelMin()
+	require.True(t, errors.Is(err, ErrUnderflow))
+}
+
+func TestCase2(t *testing.T) {
+	pq := NewIndexMaxPQ[int, int]()
+	require.NoError(t, pq.Insert(1000000, 5))
+	require.True(t, errors.Is(pq.Insert(1000000, 6), ErrAlreadyInPQ))
+	require.True(t, errors.Is(pq.Delete(-1), ErrNotInPQ))
+	require.True(t, errors.Is(pq.ChangePriority(7, 1), ErrNotInPQ))
+	require.True(t, errors.Is(pq.DecreasePriority(1000000, 5), ErrNotDecreased))
+	require.True(t, errors.Is(pq.IncreasePriority(1000000, 4), ErrNotIncreased))
+
+	_, err := pq.PriorityOf(3)
+	require.True(t, errors.Is(err, ErrNotInPQ))
+
+	require.NoError(t, pq.Insert(-3, 8))
+	max, err := pq.Max()
+	require.NoError(t, err)
+	require.Equal(t, -3, max)
+
+	priority, err := pq.MaxPriority()
+	require.NoError(t, err)
+	require.Equal(t, 8, priority)
+}
+
+func TestCase3(t *testing.T) {
+	// random operations against a map, with slots reused after removal
+	r := rand.New(rand.NewSource(1))
+	pq := NewIndexMaxPQ[int, int]()
+	want := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(100)
+		switch r.Intn(5) {
+		case 0, 4:
+			if _, ok := want[k]; !ok {
+				want[k] = r.Intn(1000)
+				require.NoError(t, pq.Insert(k, want[k]))
+			}
+		case 1:
+			if _, ok := want[k]; ok {
+				want[k] = r.Intn(1000)
+				require.NoError(t, pq.ChangePriority(k, want[k]))
+			}
+		case 2:
+			if _, ok := want[k]; ok {
+				delete(want, k)
+				require.NoError(t, pq.Delete(k))
+			}
+		case 3:
+			if len(want) > 0 {
+				priority, err := pq.MaxPriority()
+				require.NoError(t, err)
+				k, err := pq.DelMax()
+				require.NoError(t, err)
+				require.Equal(t, want[k], priority)
+				for _, p := range want {
+					require.LessOrEqual(t, p, priority)
+				}
+				delete(want, k)
+			}
+		}
+		require.Equal(t, len(want), pq.Size())
+	}
+	require.LessOrEqual(t, len(pq.keys), 100)
+
+	var keys []int
+	for k := range want {
+		keys = append(keys, k)
+	}
+	require.ElementsMatch(t, keys, pq.Keys())
+}
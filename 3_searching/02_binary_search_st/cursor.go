@@ -0,0 +1,115 @@
+package binary_search_st
+
+import (
+	"github.com/lee-hen/Algorithms/3_searching/27_cursor"
+
+	"iter"
+)
+
+// Cursor
+// A 27_cursor.Interface over the symbol table. It is an index into the sorted arrays,
+// so Next and Prev take constant time and Seek takes a binary search.
+// Modifying the symbol table invalidates its cursors.
+type Cursor struct {
+	st *BinarySearchST
+	i  int
+}
+
+// Cursor
+// Returns a cursor that is not positioned yet; call First, Last, Seek or SeekFloor.
+func (st *BinarySearchST) Cursor() *Cursor {
+	return &Cursor{st: st, i: -1}
+}
+
+// Valid
+// Returns true if the cursor is positioned at a key.
+func (c *Cursor) Valid() bool {
+	return c.i >= 0 && c.i < c.st.Size()
+}
+
+// Key
+// Returns the key at the cursor.
+func (c *Cursor) Key() string {
+	return c.st.Keys[c.i]
+}
+
+// Value
+// Returns the value at the cursor.
+func (c *Cursor) Value() int {
+	return c.st.Values[c.i]
+}
+
+// First
+// Moves to the smallest key.
+func (c *Cursor) First() bool {
+	c.i = 0
+	return c.Valid()
+}
+
+// Last
+// Moves to the largest key.
+func (c *Cursor) Last() bool {
+	c.i = c.st.Size() - 1
+	return c.Valid()
+}
+
+// Seek
+// Moves to the smallest key greater than or equal to key.
+func (c *Cursor) Seek(key string) bool {
+	c.i = c.st.Rank(key)
+	return c.Valid()
+}
+
+// SeekFloor
+// Moves to the largest key less than or equal to key.
+func (c *Cursor) SeekFloor(key string) bool {
+	c.i = c.st.Rank(key)
+	if !c.Valid() || c.Key() != key {
+		c.i--
+	}
+	return c.Valid()
+}
+
+// Next
+// Moves to the next larger key.
+func (c *Cursor) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.i++
+	return c.Valid()
+}
+
+// Prev
+// Moves to the next smaller key.
+func (c *Cursor) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.i--
+	return c.Valid()
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order.
+func (st *BinarySearchST) All() iter.Seq2[string, int] {
+	return cursor.All(st.Cursor)
+}
+
+// Backward
+// Returns an iterator over the key-value pairs in descending key order.
+func (st *BinarySearchST) Backward() iter.Seq2[string, int] {
+	return cursor.Backward(st.Cursor)
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+func (st *BinarySearchST) Range(lo, hi string) iter.Seq2[string, int] {
+	return cursor.Range(st.Cursor, lo, hi)
+}
+
+// RangeReverse
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in descending order.
+func (st *BinarySearchST) RangeReverse(lo, hi string) iter.Seq2[string, int] {
+	return cursor.RangeReverse(st.Cursor, lo, hi)
+}
@@ -0,0 +1,52 @@
+package BST
+
+import (
+	"github.com/lee-hen/Algorithms/3_searching/27_cursor"
+
+	"iter"
+)
+
+// Entry
+// Returns the key and the value of the node, for 27_cursor.TreeCursor.
+func (x *Node) Entry() (string, int) {
+	return x.Key, x.Value
+}
+
+// Children
+// Returns the left and right subtrees of the node, for 27_cursor.TreeCursor.
+func (x *Node) Children() (*Node, *Node) {
+	return x.Left, x.Right
+}
+
+// Cursor
+// Returns a cursor that is not positioned yet; call First, Last, Seek or SeekFloor.
+// It keeps the path from the root to the current node, so Next and Prev take amortized
+// constant time and Seek takes time proportional to the height. Modifying the symbol table
+// invalidates its cursors.
+func (bst *BST) Cursor() *cursor.TreeCursor[*Node] {
+	return cursor.NewTreeCursor(bst.root)
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order.
+func (bst *BST) All() iter.Seq2[string, int] {
+	return cursor.All(bst.Cursor)
+}
+
+// Backward
+// Returns an iterator over the key-value pairs in descending key order.
+func (bst *BST) Backward() iter.Seq2[string, int] {
+	return cursor.Backward(bst.Cursor)
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+func (bst *BST) Range(lo, hi string) iter.Seq2[string, int] {
+	return cursor.Range(bst.Cursor, lo, hi)
+}
+
+// RangeReverse
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in descending order.
+func (bst *BST) RangeReverse(lo, hi string) iter.Seq2[string, int] {
+	return cursor.RangeReverse(bst.Cursor, lo, hi)
+}
@@ -62,7 +62,7 @@ func main() {
 
 	fmt.Println("---------------------------")
 	for _, s := range perfectBst.LevelOrder() {
-		_, val := perfectBst.Get(s)
+		val, _ := perfectBst.Get(s)
 		fmt.Printf("%s  %d\n", s, val)
 	}
 }
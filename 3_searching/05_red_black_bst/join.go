@@ -0,0 +1,312 @@
+package red_black_bst
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"log"
+)
+
+// Split and join.
+// Joining two left-leaning red-black trees around a middle node walks down the spine of
+// the taller one to a black node of the same black height as the shorter one, hangs the
+// middle node there as a red link, and fixes the tree on the way up as put does, so it
+// takes time proportional to the difference of the black heights. Split cuts the search
+// path and joins the pieces back on the way up; the costs telescope to O(log n).
+// Union, intersection and difference split one tree by the root of the other and recur
+// on both sides (Blelloch, Ferizovic and Sun), which takes O(m log(n/m + 1)) time for
+// trees of sizes m <= n. All of them reuse the nodes of their arguments.
+
+// NewRedBlackBSTFromSorted
+// Initializes a symbol table with the given keys, which must be in strictly increasing
+// order, and values, in linear time. The tree is a 2-3 tree of the largest possible
+// black height, so it is as balanced as a red-black tree can be.
+func NewRedBlackBSTFromSorted(keys []string, values []int) *RedBlackBST {
+	return fromSorted(keys, values, nil)
+}
+
+// NewAugmentedRedBlackBSTFromSorted
+// Initializes a symbol table augmented with the monoid, as NewAugmentedRedBlackBST does,
+// with the given keys, which must be in strictly increasing order, and values, in linear time.
+func NewAugmentedRedBlackBSTFromSorted(monoid util.Monoid, keys []string, values []int) *RedBlackBST {
+	return fromSorted(keys, values, &monoid)
+}
+
+func fromSorted(keys []string, values []int, m *util.Monoid) *RedBlackBST {
+	if len(keys) != len(values) {
+		log.Fatalln("keys and values have different lengths")
+	}
+	for i := range keys {
+		if keys[i] == "" {
+			log.Fatalln("calls newRedBlackBSTFromSorted() with a null key")
+		}
+		if i > 0 && keys[i-1] >= keys[i] {
+			log.Fatalln("keys are not in strictly increasing order")
+		}
+	}
+
+	// the largest black height for which there are enough keys to fill every 2-node
+	black := 0
+	for n := len(keys) + 1; n > 1; n /= 2 {
+		black++
+	}
+	return &RedBlackBST{root: build(keys, values, black, m), monoid: m}
+}
+
+// the largest number of keys in a 2-3 tree of the given black height, 3^black - 1, capped
+func maxKeys(black int) int {
+	n := 1
+	for i := 0; i < black && n < 1<<40; i++ {
+		n *= 3
+	}
+	return n - 1
+}
+
+// build a 2-3 tree of the given black height over the keys; 2^black - 1 <= len(keys) <= 3^black - 1
+func build(keys []string, values []int, black int, m *util.Monoid) *Node {
+	n := len(keys)
+	if n == 0 {
+		return nil
+	}
+
+	// a 2-node if the children can hold the other keys, a 3-node otherwise
+	if n-1 <= 2*maxKeys(black-1) {
+		left := n / 2
+		h := newNode(keys[left], BLACK, values[left], n)
+		h.Left = build(keys[:left], values[:left], black-1, m)
+		h.Right = build(keys[left+1:], values[left+1:], black-1, m)
+		h.updateAggregate(m)
+		return h
+	}
+
+	// the other keys go into three children as evenly as possible
+	a := n / 3
+	b := (n - 1 - a) / 2
+	r := newNode(keys[a], RED, values[a], a+b+1)
+	r.Left = build(keys[:a], values[:a], black-1, m)
+	r.Right = build(keys[a+1:a+1+b], values[a+1:a+1+b], black-1, m)
+	r.updateAggregate(m)
+	h := newNode(keys[a+1+b], BLACK, values[a+1+b], n)
+	h.Left = r
+	h.Right = build(keys[a+2+b:], values[a+2+b:], black-1, m)
+	h.updateAggregate(m)
+	return h
+}
+
+// Split
+// Moves the keys greater than or equal to key into a new symbol table, which it returns,
+// and keeps the smaller keys. Takes O(log n) time.
+func (bst *RedBlackBST) Split(key string) *RedBlackBST {
+	if key == "" {
+		log.Fatalln("argument to split() is null")
+	}
+
+	l, _, mid, r, rh := split(bst.root, blackHeight(bst.root), key, bst.monoid)
+	if mid != nil {
+		r, _ = join(nil, 0, mid, r, rh, bst.monoid)
+	}
+	bst.root = l
+	return &RedBlackBST{root: r, monoid: bst.monoid}
+}
+
+// Join
+// Returns the symbol table with the keys of left and right, all of which must be smaller
+// in left than in right, leaving both empty. Takes O(log n) time.
+func Join(left, right *RedBlackBST) *RedBlackBST {
+	checkMonoids(left, right)
+	if !left.IsEmpty() && !right.IsEmpty() && left.Max() >= right.Min() {
+		log.Fatalln("calls join() with keys that are not all greater")
+	}
+
+	root, _ := join2(left.root, blackHeight(left.root), right.root, blackHeight(right.root), left.monoid)
+	left.root, right.root = nil, nil
+	return &RedBlackBST{root: root, monoid: left.monoid}
+}
+
+// Union
+// Returns the symbol table with the keys of a or b, leaving both empty. The value of
+// a key in both is the one in a.
+func Union(a, b *RedBlackBST) *RedBlackBST {
+	checkMonoids(a, b)
+	root, _ := union(a.root, blackHeight(a.root), b.root, blackHeight(b.root), a.monoid)
+	a.root, b.root = nil, nil
+	return &RedBlackBST{root: root, monoid: a.monoid}
+}
+
+// Intersection
+// Returns the symbol table with the keys of a that are also in b, and their values in a,
+// leaving both empty.
+func Intersection(a, b *RedBlackBST) *RedBlackBST {
+	checkMonoids(a, b)
+	root, _ := intersection(a.root, blackHeight(a.root), b.root, blackHeight(b.root), a.monoid)
+	a.root, b.root = nil, nil
+	return &RedBlackBST{root: root, monoid: a.monoid}
+}
+
+// Difference
+// Returns the symbol table with the keys of a that are not in b, and their values in a,
+// leaving both empty.
+func Difference(a, b *RedBlackBST) *RedBlackBST {
+	checkMonoids(a, b)
+	root, _ := difference(a.root, blackHeight(a.root), b.root, blackHeight(b.root), a.monoid)
+	a.root, b.root = nil, nil
+	return &RedBlackBST{root: root, monoid: a.monoid}
+}
+
+// the nodes of both trees end up in one, so they must agree on keeping aggregates
+func checkMonoids(a, b *RedBlackBST) {
+	if a == b {
+		log.Fatalln("calls a set operation with the same symbol table twice")
+	}
+	if (a.monoid == nil) != (b.monoid == nil) {
+		log.Fatalln("calls a set operation with an augmented and a plain symbol table")
+	}
+}
+
+// number of black links on the paths from h to null
+func blackHeight(h *Node) int {
+	black := 0
+	for ; h != nil; h = h.Left {
+		if !h.isRed() {
+			black++
+		}
+	}
+	return black
+}
+
+// color the root of a subtree of the given black height black, so that it is a tree of its own
+func blacken(h *Node, black int) (*Node, int) {
+	if h.isRed() {
+		h.Color = BLACK
+		return h, black + 1
+	}
+	return h, black
+}
+
+// join the trees rooted at l and r, whose roots are black and whose keys are smaller
+// and larger than the key of x; returns the black root and the black height
+func join(l *Node, lh int, x *Node, r *Node, rh int, m *util.Monoid) (*Node, int) {
+	if lh > rh {
+		return blacken(joinRight(l, lh, x, r, rh, m), lh)
+	}
+	if lh < rh {
+		return blacken(joinLeft(r, rh, x, l, lh, m), rh)
+	}
+	return blacken(hang(l, x, r, m), lh)
+}
+
+// make x a red node between l and r
+func hang(l, x, r *Node, m *util.Monoid) *Node {
+	x.Left, x.Right, x.Color = l, r, RED
+	x.size = 1 + size(l) + size(r)
+	x.updateAggregate(m)
+	return x
+}
+
+// walk down the right spine of h, which is all black, to the black height of r
+func joinRight(h *Node, hh int, x *Node, r *Node, rh int, m *util.Monoid) *Node {
+	if hh == rh {
+		return hang(h, x, r, m)
+	}
+	h.Right = joinRight(h.Right, hh-1, x, r, rh, m)
+	return balance(h, m)
+}
+
+// walk down the left spine of h to a black node at the black height of l
+func joinLeft(h *Node, hh int, x *Node, l *Node, lh int, m *util.Monoid) *Node {
+	if !h.isRed() && hh == lh {
+		return hang(l, x, h, m)
+	}
+	if !h.isRed() {
+		hh--
+	}
+	h.Left = joinLeft(h.Left, hh, x, l, lh, m)
+	return balance(h, m)
+}
+
+// join the trees rooted at l and r without a middle node
+func join2(l *Node, lh int, r *Node, rh int, m *util.Monoid) (*Node, int) {
+	if r == nil {
+		return l, lh
+	}
+
+	// take the smallest node of r out, as DelMin does
+	x := r.min()
+	if !r.Left.isRed() && !r.Right.isRed() {
+		r.Color = RED
+	}
+	r = delMin(r, m)
+	if r != nil {
+		r.Color = BLACK
+	}
+	return join(l, lh, x, r, blackHeight(r), m)
+}
+
+// the children of the black root h as trees of their own
+func children(h *Node, hh int) (*Node, int, *Node, int) {
+	l, lh := blacken(h.Left, hh-1)
+	r, rh := blacken(h.Right, hh-1)
+	return l, lh, r, rh
+}
+
+// split the tree rooted at the black node h into the keys less than key, the node with
+// the key if there is one, and the keys greater than key
+func split(h *Node, hh int, key string, m *util.Monoid) (*Node, int, *Node, *Node, int) {
+	if h == nil {
+		return nil, 0, nil, nil, 0
+	}
+
+	l, lh, r, rh := children(h, hh)
+	if key < h.Key {
+		ll, llh, mid, lr, lrh := split(l, lh, key, m)
+		r, rh = join(lr, lrh, h, r, rh, m)
+		return ll, llh, mid, r, rh
+	}
+	if key > h.Key {
+		rl, rlh, mid, rr, rrh := split(r, rh, key, m)
+		l, lh = join(l, lh, h, rl, rlh, m)
+		return l, lh, mid, rr, rrh
+	}
+	return l, lh, h, r, rh
+}
+
+func union(a *Node, ah int, b *Node, bh int, m *util.Monoid) (*Node, int) {
+	if a == nil {
+		return b, bh
+	}
+	if b == nil {
+		return a, ah
+	}
+
+	al, alh, ar, arh := children(a, ah)
+	bl, blh, _, br, brh := split(b, bh, a.Key, m)
+	l, lh := union(al, alh, bl, blh, m)
+	r, rh := union(ar, arh, br, brh, m)
+	return join(l, lh, a, r, rh, m)
+}
+
+func intersection(a *Node, ah int, b *Node, bh int, m *util.Monoid) (*Node, int) {
+	if a == nil || b == nil {
+		return nil, 0
+	}
+
+	al, alh, ar, arh := children(a, ah)
+	bl, blh, mid, br, brh := split(b, bh, a.Key, m)
+	l, lh := intersection(al, alh, bl, blh, m)
+	r, rh := intersection(ar, arh, br, brh, m)
+	if mid != nil {
+		return join(l, lh, a, r, rh, m)
+	}
+	return join2(l, lh, r, rh, m)
+}
+
+func difference(a *Node, ah int, b *Node, bh int, m *util.Monoid) (*Node, int) {
+	if a == nil || b == nil {
+		return a, ah
+	}
+
+	bl, blh, br, brh := children(b, bh)
+	al, alh, _, ar, arh := split(a, ah, b.Key, m)
+	l, lh := difference(al, alh, bl, blh, m)
+	r, rh := difference(ar, arh, br, brh, m)
+	return join2(l, lh, r, rh, m)
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type RedBlackBST struct {
-	root *Node // root of BST
+	root   *Node        // root of BST
+	monoid *util.Monoid // aggregate kept in each node, nil for none
 }
 
 // NewRedBlackBST
@@ -17,6 +18,13 @@ func NewRedBlackBST() *RedBlackBST {
 	return &RedBlackBST{}
 }
 
+// NewAugmentedRedBlackBST
+// Initializes an empty symbol table that keeps, in each node, the values of its subtree
+// combined in key order under the monoid, for Aggregate and AggregateBetween.
+func NewAugmentedRedBlackBST(monoid util.Monoid) *RedBlackBST {
+	return &RedBlackBST{monoid: &monoid}
+}
+
 const (
  	RED = true
 	BLACK = false
@@ -32,11 +40,14 @@ type Node struct {
 
 	Color bool // color of parent link
 
+	// combination of the values in the subtree under the monoid of the tree, if it has one
+	aggregate int
+
 	Left, Right *Node // left and right subtrees
 }
 
 func newNode(key string, color bool, value, size int) *Node {
-	return &Node{Key: key, Color: color, Value: value, size: size}
+	return &Node{Key: key, Color: color, Value: value, size: size, aggregate: value}
 }
 
 // is node x red; false if x is null ?
@@ -56,6 +67,22 @@ func size(x *Node) int {
 	return x.size
 }
 
+// Returns the aggregate of the subtree rooted at x under monoid.
+func aggregate(x *Node, monoid *util.Monoid) int {
+	if x == nil {
+		return monoid.Identity
+	}
+	return x.aggregate
+}
+
+// recompute the aggregate of h from its children and its own value under m, if not nil
+func (h *Node) updateAggregate(m *util.Monoid) {
+	if m == nil {
+		return
+	}
+	h.aggregate = m.Combine(m.Combine(aggregate(h.Left, m), h.Value), aggregate(h.Right, m))
+}
+
 // IsEmpty
 // Is this symbol table empty?
 // return true if this symbol table is empty and return false otherwise
@@ -110,6 +137,21 @@ func (bst *RedBlackBST) Contains(key string) bool {
 	return found
 }
 
+// Clone
+// Returns a deep copy of the symbol table that shares no nodes with it.
+func (bst *RedBlackBST) Clone() *RedBlackBST {
+	return &RedBlackBST{root: bst.root.clone(), monoid: bst.monoid}
+}
+
+func (h *Node) clone() *Node {
+	if h == nil {
+		return nil
+	}
+	x := *h
+	x.Left, x.Right = h.Left.clone(), h.Right.clone()
+	return &x
+}
+
 // Red-black tree insertion.
 
 // Put
@@ -120,20 +162,20 @@ func (bst *RedBlackBST) Put(key string, value int) {
 		log.Fatalln("first argument to put() is null")
 	}
 
-	bst.root = put(bst.root, key, value)
+	bst.root = put(bst.root, key, value, bst.monoid)
 	bst.root.Color = BLACK
 }
 
 // insert the key-value pair in the subtree rooted at h
-func put(h *Node, key string, value int) *Node {
+func put(h *Node, key string, value int, m *util.Monoid) *Node {
 	if h == nil {
 		return newNode(key, RED, value, 1)
 	}
 
 	if key < h.Key {
-		h.Left = put(h.Left, key, value)
+		h.Left = put(h.Left, key, value, m)
 	} else if key > h.Key {
-		h.Right = put(h.Right, key, value)
+		h.Right = put(h.Right, key, value, m)
 	} else {
 		h.Value = value
 	}
@@ -142,12 +184,12 @@ func put(h *Node, key string, value int) *Node {
 
 	// 右->赤　左->黒 left rotate, !h.Left.isRed() not required
 	if h.Right.isRed() && !h.Left.isRed()  {
-		h = h.rotateLeft()
+		h = h.rotateLeft(m)
 	}
 
 	// 左->赤 左.左->赤 right rotate
 	if h.Left.isRed() && h.Left.Left.isRed() {
-		h = h.rotateRight()
+		h = h.rotateRight(m)
 	}
 
 	// 右->赤　左->赤 flip color
@@ -156,6 +198,7 @@ func put(h *Node, key string, value int) *Node {
 	}
 
 	h.size = 1 + size(h.Left) + size(h.Right)
+	h.updateAggregate(m)
 
 	return h
 }
@@ -174,7 +217,7 @@ func (bst *RedBlackBST) DelMin() {
 		bst.root.Color = RED
 	}
 
-	bst.root = delMin(bst.root)
+	bst.root = delMin(bst.root, bst.monoid)
 
 	if !bst.IsEmpty() {
 		bst.root.Color = BLACK
@@ -182,7 +225,7 @@ func (bst *RedBlackBST) DelMin() {
 }
 
 // delete the key-value pair with the minimum key rooted at h
-func delMin(h *Node) *Node {
+func delMin(h *Node, m *util.Monoid) *Node {
 	// remove node on bottom level
 	// (h must be RED by invariant)
 	if h.Left == nil {
@@ -191,16 +234,16 @@ func delMin(h *Node) *Node {
 
 	// push red link down if necessary
 	if !h.Left.isRed() && !h.Left.Left.isRed() {
-		h = moveRedLeft(h)
+		h = moveRedLeft(h, m)
 	}
 
 	// move down one level
-	h.Left = delMin(h.Left)
+	h.Left = delMin(h.Left, m)
 
 	// fix right-leaning red links
 	// and eliminate 4-nodes
 	// on the way up
-	return balance(h)
+	return balance(h, m)
 }
 
 // DelMax
@@ -215,7 +258,7 @@ func (bst *RedBlackBST) DelMax() {
 		bst.root.Color = RED
 	}
 
-	bst.root = delMax(bst.root)
+	bst.root = delMax(bst.root, bst.monoid)
 
 	if !bst.IsEmpty() {
 		bst.root.Color = BLACK
@@ -226,10 +269,10 @@ func (bst *RedBlackBST) DelMax() {
 // push reds down
 // remove maximum
 // fix right-leaning reds on the way up
-func delMax(h *Node) *Node {
+func delMax(h *Node, m *util.Monoid) *Node {
 	// lean 3-nodes to the right
 	if h.Left.isRed() {
-		h = h.rotateRight()
+		h = h.rotateRight(m)
 	}
 
 	// remove node on bottom level
@@ -240,16 +283,16 @@ func delMax(h *Node) *Node {
 
 	// borrow from sibling if necessary
 	if !h.Right.isRed() && !h.Right.Left.isRed() {
-		h = moveRedRight(h)
+		h = moveRedRight(h, m)
 	}
 
 	// move down one level
-	h.Right = delMax(h.Right)
+	h.Right = delMax(h.Right, m)
 
 	// fix right-leaning red links
 	// and eliminate 4-nodes
 	// on the way up
-	return balance(h)
+	return balance(h, m)
 }
 
 // Del
@@ -269,7 +312,7 @@ func (bst *RedBlackBST) Del(key string) {
 		bst.root.Color = RED
 	}
 
-	bst.root = del(bst.root, key)
+	bst.root = del(bst.root, key, bst.monoid)
 
 	if !bst.IsEmpty() {
 		bst.root.Color = BLACK
@@ -277,19 +320,19 @@ func (bst *RedBlackBST) Del(key string) {
 }
 
 // delete the key-value pair with the given key rooted at h
-func del(h *Node, key string) *Node {
+func del(h *Node, key string, m *util.Monoid) *Node {
 	if key < h.Key { // LEFT
 		// push red right if necessary move down (left)
 		if !h.Left.isRed() && !h.Left.Left.isRed() {
-			h = moveRedLeft(h)
+			h = moveRedLeft(h, m)
 		}
-		h.Left = del(h.Left, key)
+		h.Left = del(h.Left, key, m)
 	} else {
 		// the same as delete max start
 
 		// rotate to push red right
 		if h.Left.isRed() {
-			h = h.rotateRight()
+			h = h.rotateRight(m)
 		}
 
 		// EQUAL (at bottom)
@@ -300,7 +343,7 @@ func del(h *Node, key string) *Node {
 
 		// push red right if necessary
 		if !h.Right.isRed() && !h.Right.Left.isRed() {
-			h = moveRedRight(h)
+			h = moveRedRight(h, m)
 		}
 
 		// the same as delete max end
@@ -313,24 +356,24 @@ func del(h *Node, key string) *Node {
 			h.Value = x.Value
 
 			//delete successor
-			h.Right = delMin(h.Right)
+			h.Right = delMin(h.Right, m)
 		} else {
 			// move down (right)
-			h.Right = del(h.Right, key)
+			h.Right = del(h.Right, key, m)
 		}
 	}
 
 	// fix right-leaning red links
 	// and eliminate 4-nodes
 	// on the way up
-	return balance(h)
+	return balance(h, m)
 }
 
 // Red-black tree helper functions.
 
 // left leaning
 // make a left-leaning link lean to the right
-func (h *Node) rotateRight() *Node {
+func (h *Node) rotateRight(m *util.Monoid) *Node {
 	x := h.Left
 	h.Left = x.Right
 	x.Right = h
@@ -338,12 +381,14 @@ func (h *Node) rotateRight() *Node {
 	x.Right.Color = RED
 	x.size = h.size
 	h.size = size(h.Left) + size(h.Right) + 1
+	x.aggregate = h.aggregate
+	h.updateAggregate(m)
 	return x
 }
 
 // right leaning
 // make a right-leaning link lean to the left
-func (h *Node) rotateLeft() *Node {
+func (h *Node) rotateLeft(m *util.Monoid) *Node {
 	x := h.Right
 	h.Right = x.Left
 	x.Left = h
@@ -351,6 +396,8 @@ func (h *Node) rotateLeft() *Node {
 	x.Left.Color = RED
 	x.size = h.size
 	h.size = size(h.Left) + size(h.Right) + 1
+	x.aggregate = h.aggregate
+	h.updateAggregate(m)
 	return x
 }
 
@@ -363,12 +410,12 @@ func flipColors(h *Node) {
 
 // Assuming that h is red and both h.left and h.left.left
 // are black, make h.left or one of its children red.
-func moveRedLeft(h *Node) *Node {
+func moveRedLeft(h *Node, m *util.Monoid) *Node {
 	flipColors(h)
 
 	if h.Right.Left.isRed() {
-		h.Right = h.Right.rotateRight()
-		h = h.rotateLeft()
+		h.Right = h.Right.rotateRight(m)
+		h = h.rotateLeft(m)
 		flipColors(h)
 	}
 	return h
@@ -376,31 +423,32 @@ func moveRedLeft(h *Node) *Node {
 
 // Assuming that h is red and both h.right and h.right.left
 // are black, make h.right or one of its children red.
-func moveRedRight(h *Node) *Node {
+func moveRedRight(h *Node, m *util.Monoid) *Node {
 	flipColors(h)
 
 	// 2-3 node
 	if h.Left.Left.isRed() {
-		h = h.rotateRight()
+		h = h.rotateRight(m)
 		flipColors(h)
 	}
 	return h
 }
 
 // restore red-black tree invariant
-func balance(h *Node) *Node {
+func balance(h *Node, m *util.Monoid) *Node {
 	if h.Right.isRed() && !h.Left.isRed() {
-		h = h.rotateLeft()
+		h = h.rotateLeft(m)
 	}
 
 	if h.Left.isRed() && h.Left.Left.isRed() {
-		h = h.rotateRight()
+		h = h.rotateRight(m)
 	}
 
 	if h.Left.isRed() && h.Right.isRed() {
 		flipColors(h)
 	}
 	h.size = size(h.Right) + size(h.Left) + 1
+	h.updateAggregate(m)
 
 	return h
 }
@@ -673,6 +721,76 @@ func (bst *RedBlackBST) SizeBetween(lo, hi string) int {
 	}
 }
 
+// Aggregate
+// Returns the values of all keys combined in key order under the monoid of the symbol table.
+func (bst *RedBlackBST) Aggregate() int {
+	if bst.monoid == nil {
+		log.Fatalln("calls aggregate() on a symbol table without a monoid")
+	}
+	return aggregate(bst.root, bst.monoid)
+}
+
+// AggregateBetween
+// Returns the values of the keys between lo and hi (inclusive) combined in key order
+// under the monoid of the symbol table, visiting O(log n) nodes.
+func (bst *RedBlackBST) AggregateBetween(lo, hi string) int {
+	if lo == "" {
+		log.Fatalln("first argument to aggregateBetween() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to aggregateBetween() is null")
+	}
+	if bst.monoid == nil {
+		log.Fatalln("calls aggregateBetween() on a symbol table without a monoid")
+	}
+
+	m := bst.monoid
+	x := bst.root
+	// find the highest node in [lo, hi]; the range splits there
+	for x != nil && (x.Key < lo || x.Key > hi) {
+		if x.Key < lo {
+			x = x.Right
+		} else {
+			x = x.Left
+		}
+	}
+	if x == nil {
+		return m.Identity
+	}
+
+	return m.Combine(m.Combine(aggregateFrom(x.Left, lo, m), x.Value), aggregateTo(x.Right, hi, m))
+}
+
+// combine the values of the keys >= lo in the subtree rooted at x
+func aggregateFrom(x *Node, lo string, m *util.Monoid) int {
+	result := m.Identity
+	for x != nil {
+		if x.Key < lo {
+			x = x.Right
+		} else {
+			// x and its right subtree are in range, in front of what was collected
+			result = m.Combine(m.Combine(x.Value, aggregate(x.Right, m)), result)
+			x = x.Left
+		}
+	}
+	return result
+}
+
+// combine the values of the keys <= hi in the subtree rooted at x
+func aggregateTo(x *Node, hi string, m *util.Monoid) int {
+	result := m.Identity
+	for x != nil {
+		if x.Key > hi {
+			x = x.Left
+		} else {
+			// x and its left subtree are in range, behind what was collected
+			result = m.Combine(result, m.Combine(aggregate(x.Left, m), x.Value))
+			x = x.Right
+		}
+	}
+	return result
+}
+
 // Check integrity of red-black tree data structure.
 
 func Check(bst *RedBlackBST) bool {
@@ -692,11 +810,15 @@ func Check(bst *RedBlackBST) bool {
 		fmt.Println("Not a 2-3 tree")
 	}
 
+	if !bst.isAggregateConsistent() {
+		fmt.Println("Subtree aggregates not consistent")
+	}
+
 	if !bst.isBalanced(){
 		fmt.Println("Not balanced")
 	}
 
-	return bst.isBST() && bst.isSizeConsistent() && bst.isRankConsistent() && bst.is23() && bst.isBalanced()
+	return bst.isBST() && bst.isSizeConsistent() && bst.isRankConsistent() && bst.is23() && bst.isBalanced() && bst.isAggregateConsistent()
 }
 
 func (bst *RedBlackBST) isBST() bool {
@@ -800,3 +922,21 @@ func (h *Node) isBalanced(black int) bool {
 
 	return h.Left.isBalanced(black) && h.Right.isBalanced(black)
 }
+
+// are the aggregates in the nodes correct?
+func (bst *RedBlackBST) isAggregateConsistent() bool {
+	if bst.monoid == nil {
+		return true
+	}
+	return isAggregateConsistent(bst.root, bst.monoid)
+}
+
+func isAggregateConsistent(x *Node, m *util.Monoid) bool {
+	if x == nil {
+		return true
+	}
+	if x.aggregate != m.Combine(m.Combine(aggregate(x.Left, m), x.Value), aggregate(x.Right, m)) {
+		return false
+	}
+	return isAggregateConsistent(x.Left, m) && isAggregateConsistent(x.Right, m)
+}
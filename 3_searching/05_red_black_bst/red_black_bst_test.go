@@ -1,7 +1,11 @@
 package red_black_bst
 
 import (
+	"github.com/lee-hen/Algorithms/util"
 	"github.com/stretchr/testify/require"
+	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -17,3 +21,175 @@ func TestCase1(t *testing.T) {
 
 	require.True(t, Check(st))
 }
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, m := range []util.Monoid{util.SumMonoid, util.MaxMonoid, util.MinMonoid} {
+		st := NewAugmentedRedBlackBST(m)
+		values := make(map[string]int)
+		for i := 0; i < 2000; i++ {
+			key := fmt.Sprintf("%03d", r.Intn(500))
+			switch r.Intn(8) {
+			case 0:
+				st.Del(key)
+				delete(values, key)
+			case 1:
+				if !st.IsEmpty() {
+					delete(values, st.Min())
+					st.DelMin()
+				}
+			case 2:
+				if !st.IsEmpty() {
+					delete(values, st.Max())
+					st.DelMax()
+				}
+			default:
+				values[key] = r.Intn(1000) - 500
+				st.Put(key, values[key])
+			}
+		}
+		require.True(t, Check(st))
+
+		for i := 0; i < 200; i++ {
+			lo, hi := fmt.Sprintf("%03d", r.Intn(500)), fmt.Sprintf("%03d", r.Intn(500))
+			expected := m.Identity
+			for _, key := range st.KeysBetween(lo, hi) {
+				expected = m.Combine(expected, values[key])
+			}
+			require.Equal(t, expected, st.AggregateBetween(lo, hi))
+		}
+
+		expected := m.Identity
+		for _, value := range values {
+			expected = m.Combine(expected, value)
+		}
+		require.Equal(t, expected, st.Aggregate())
+	}
+}
+
+func TestCase3(t *testing.T) {
+	for n := 0; n <= 300; n++ {
+		keys := make([]string, n)
+		values := make([]int, n)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("%03d", i)
+			values[i] = i
+		}
+		st := NewRedBlackBSTFromSorted(keys, values)
+		require.True(t, Check(st))
+		require.Equal(t, keys, st.Keys())
+		// as low as a perfectly balanced BST, or one more
+		lg := 0
+		for k := n; k > 1; k /= 2 {
+			lg++
+		}
+		require.LessOrEqual(t, st.Height(), lg+1)
+
+		// the same tree, augmented
+		augmented := NewAugmentedRedBlackBSTFromSorted(util.MaxMonoid, keys, values)
+		require.True(t, Check(augmented))
+		if n > 0 {
+			require.Equal(t, n-1, augmented.Aggregate())
+			require.Equal(t, n/2, augmented.AggregateBetween("000", fmt.Sprintf("%03d", n/2)))
+		}
+	}
+
+	r := rand.New(rand.NewSource(2))
+	st := NewAugmentedRedBlackBST(util.SumMonoid)
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		st.Put(fmt.Sprintf("%04d", i), i)
+		sum += i
+	}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1100))
+		right := st.Split(key)
+		require.True(t, Check(st))
+		require.True(t, Check(right))
+		if !st.IsEmpty() {
+			require.Less(t, st.Max(), key)
+		}
+		if !right.IsEmpty() {
+			require.GreaterOrEqual(t, right.Min(), key)
+		}
+		require.Equal(t, sum, st.Aggregate()+right.Aggregate())
+
+		st = Join(st, right)
+		require.True(t, Check(st))
+		require.True(t, right.IsEmpty())
+		require.Equal(t, 1000, st.Size())
+		require.Equal(t, sum, st.Aggregate())
+	}
+
+	// a table built from sorted keys can be joined with one built by puts
+	more := NewAugmentedRedBlackBSTFromSorted(util.SumMonoid, []string{"1000", "1001", "1002"}, []int{5, 7, 9})
+	st = Join(st, more)
+	require.True(t, Check(st))
+	require.Equal(t, sum+21, st.Aggregate())
+	require.Equal(t, 16, st.AggregateBetween("1001", "1002"))
+}
+
+func TestCase4(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	random := func(n, universe int) (*RedBlackBST, map[string]int) {
+		st := NewRedBlackBST()
+		values := make(map[string]int)
+		for i := 0; i < n; i++ {
+			key := fmt.Sprintf("%04d", r.Intn(universe))
+			values[key] = r.Intn(1000)
+			st.Put(key, values[key])
+		}
+		return st, values
+	}
+	sorted := func(keys map[string]bool) []string {
+		all := make([]string, 0, len(keys))
+		for key := range keys {
+			all = append(all, key)
+		}
+		sort.Strings(all)
+		return all
+	}
+
+	for _, sizes := range [][2]int{{0, 50}, {50, 0}, {10, 1000}, {1000, 10}, {500, 500}, {300, 2000}} {
+		a, av := random(sizes[0], 2000)
+		b, bv := random(sizes[1], 2000)
+		union, intersection, difference := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+		for key := range av {
+			union[key] = true
+			if _, ok := bv[key]; ok {
+				intersection[key] = true
+			} else {
+				difference[key] = true
+			}
+		}
+		for key := range bv {
+			union[key] = true
+		}
+
+		st := Union(a.Clone(), b.Clone())
+		require.True(t, Check(st))
+		require.Equal(t, sorted(union), st.Keys())
+		for key := range union {
+			value, _ := st.Get(key)
+			if v, ok := av[key]; ok {
+				require.Equal(t, v, value)
+			} else {
+				require.Equal(t, bv[key], value)
+			}
+		}
+
+		st = Intersection(a.Clone(), b.Clone())
+		require.True(t, Check(st))
+		require.Equal(t, sorted(intersection), st.Keys())
+		for key := range intersection {
+			value, _ := st.Get(key)
+			require.Equal(t, av[key], value)
+		}
+
+		st = Difference(a, b)
+		require.True(t, Check(st))
+		require.Equal(t, sorted(difference), st.Keys())
+		require.True(t, a.IsEmpty())
+		require.True(t, b.IsEmpty())
+	}
+}
@@ -12,6 +12,8 @@ const initCapacity = 4
 type HashST struct {
 	n int  // number of key-value pairs
 	st []bst.RedBlackBST // array of Red Black BST symbol tables
+
+	resizes int // number of times resize was called
 }
 
 // NewHashST
@@ -41,6 +43,7 @@ func (h *HashST) hash(key string) int {
 // the hash table to have the given number of chains,
 // rehashing all of the keys
 func (h *HashST) resize(chains int) {
+	h.resizes++
 	temp := newHashST(chains)
 	for i := 0; i < len(h.st); i++ {
 		for _, key := range h.st[i].Keys() {
@@ -141,3 +144,45 @@ func (h *HashST) Keys() []string {
 	util.ReverseStringSlice(keys)
 	return keys
 }
+
+// Stats
+// The shape of a separate chaining hash table.
+type Stats struct {
+	Size    int
+	Chains  int
+	Resizes int
+
+	LoadFactor   float64 // keys per chain, the average chain length
+	MaxChain     int
+	EmptyChains  int
+	ChainLengths []int // ChainLengths[k] = number of chains of length k
+
+	// chi-square test of the chain lengths against a uniform spread of the keys
+	ChiSquare        float64
+	DegreesOfFreedom int
+	PValue           float64
+}
+
+// Stats
+// Reports the load factor, the chain lengths and how uniformly the hash spreads the keys.
+func (h *HashST) Stats() Stats {
+	lengths := make([]int, len(h.st))
+	for i := range h.st {
+		lengths[i] = h.st[i].Size()
+	}
+
+	stats := Stats{
+		Size:       h.n,
+		Chains:     len(h.st),
+		Resizes:    h.resizes,
+		LoadFactor: float64(h.n) / float64(len(h.st)),
+		MaxChain:   util.Max(0, lengths...),
+	}
+	stats.ChainLengths = make([]int, stats.MaxChain+1)
+	for _, length := range lengths {
+		stats.ChainLengths[length]++
+	}
+	stats.EmptyChains = stats.ChainLengths[0]
+	stats.ChiSquare, stats.DegreesOfFreedom, stats.PValue = util.ChiSquare(lengths)
+	return stats
+}
@@ -0,0 +1,53 @@
+package separate_chaining_hash_st
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"strconv"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	hashSt := NewHashST()
+	for i := 0; i < 1000; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+	}
+
+	stats := hashSt.Stats()
+	require.Equal(t, 1000, stats.Size)
+	require.Equal(t, 128, stats.Chains)
+	require.Equal(t, 5, stats.Resizes)
+	require.InDelta(t, 1000.0/128, stats.LoadFactor, 1e-9)
+
+	// every key is in exactly one chain
+	keys, chains := 0, 0
+	for length, count := range stats.ChainLengths {
+		keys += length * count
+		chains += count
+	}
+	require.Equal(t, 1000, keys)
+	require.Equal(t, 128, chains)
+	require.Equal(t, len(stats.ChainLengths)-1, stats.MaxChain)
+	require.Equal(t, stats.ChainLengths[0], stats.EmptyChains)
+
+	// 7.8 keys per chain is enough for a chi-square test over the chains themselves
+	require.Equal(t, 127, stats.DegreesOfFreedom)
+	require.Greater(t, stats.PValue, 0.001)
+}
+
+func TestCase2(t *testing.T) {
+	// fewer than 5 keys per chain: the chains are grouped, and an empty table has nothing to test
+	hashSt := NewHashST()
+	stats := hashSt.Stats()
+	require.Equal(t, 0, stats.Size)
+	require.Equal(t, 4, stats.EmptyChains)
+	require.Equal(t, 0, stats.DegreesOfFreedom)
+	require.Equal(t, 1.0, stats.PValue)
+
+	for i := 0; i < 10; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+	}
+	stats = hashSt.Stats()
+	require.Equal(t, 4, stats.Chains)
+	require.Equal(t, 1, stats.DegreesOfFreedom)
+}
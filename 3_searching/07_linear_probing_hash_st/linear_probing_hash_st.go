@@ -12,6 +12,8 @@ type HashST struct {
 	n int  // number of key-value pairs
 	keys []string // the keys
 	values []int // the values
+
+	resizes int // number of times resize was called
 }
 
 // NewHashST
@@ -65,6 +67,7 @@ func (h *HashST) hash(key string) int {
 // resize
 // the hash table to the given capacity by re-hashing all of the keys
 func (h *HashST) resize(capacity int) {
+	h.resizes++
 	temp := newHashST(capacity)
 	for i := 0; i < len(h.keys); i++ {
 		if key := h.keys[i]; key != "" {
@@ -203,3 +206,83 @@ func Check(h *HashST) bool {
 
 	return true
 }
+
+// Stats
+// The shape of a linear probing hash table.
+type Stats struct {
+	Size     int
+	Capacity int
+	Resizes  int
+
+	LoadFactor     float64 // fraction of occupied slots
+	Clusters       int     // maximal runs of occupied slots
+	AvgCluster     float64
+	MaxCluster     int
+	ClusterLengths []int // ClusterLengths[k] = number of clusters of length k
+
+	AvgProbe float64 // average number of compares for a search hit
+	MaxProbe int
+
+	// chi-square test of the number of keys per home slot against a uniform spread
+	ChiSquare        float64
+	DegreesOfFreedom int
+	PValue           float64
+}
+
+// Stats
+// Reports the load factor, the cluster and probe lengths and how uniformly the hash spreads the keys.
+func (h *HashST) Stats() Stats {
+	m := len(h.keys)
+	stats := Stats{
+		Size:       h.n,
+		Capacity:   m,
+		Resizes:    h.resizes,
+		LoadFactor: float64(h.n) / float64(m),
+	}
+
+	// clusters, starting after an empty slot so that a cluster wrapping around the end is counted once
+	var lengths []int
+	start := 0
+	for start < m && h.keys[start] != "" {
+		start++
+	}
+	if start < m {
+		length := 0
+		for k := 1; k <= m; k++ {
+			if h.keys[(start+k)%m] != "" {
+				length++
+			} else if length > 0 {
+				lengths = append(lengths, length)
+				length = 0
+			}
+		}
+	}
+
+	stats.Clusters = len(lengths)
+	stats.MaxCluster = util.Max(0, lengths...)
+	stats.ClusterLengths = make([]int, stats.MaxCluster+1)
+	for _, length := range lengths {
+		stats.ClusterLengths[length]++
+	}
+	if stats.Clusters > 0 {
+		stats.AvgCluster = float64(h.n) / float64(stats.Clusters)
+	}
+
+	homes := make([]int, m)
+	probes := 0
+	for i, key := range h.keys {
+		if key == "" {
+			continue
+		}
+		home := h.hash(key)
+		homes[home]++
+		probe := (i-home+m)%m + 1
+		probes += probe
+		stats.MaxProbe = util.Max(stats.MaxProbe, probe)
+	}
+	if h.n > 0 {
+		stats.AvgProbe = float64(probes) / float64(h.n)
+	}
+	stats.ChiSquare, stats.DegreesOfFreedom, stats.PValue = util.ChiSquare(homes)
+	return stats
+}
@@ -2,6 +2,7 @@ package linear_probing_hash_st
 
 import (
 	"github.com/stretchr/testify/require"
+	"strconv"
 	"testing"
 )
 
@@ -24,3 +25,28 @@ func TestCase1(t *testing.T) {
 
 	require.Equal(t, 0, hashSt.Size())
 }
+
+func TestCase2(t *testing.T) {
+	hashSt := NewHashST()
+	for i := 0; i < 1000; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+	}
+
+	stats := hashSt.Stats()
+	require.Equal(t, 1000, stats.Size)
+	require.Equal(t, 2048, stats.Capacity)
+	require.Equal(t, 9, stats.Resizes)
+	require.InDelta(t, 1000.0/2048, stats.LoadFactor, 1e-9)
+
+	// every key is in exactly one cluster
+	keys := 0
+	for length, clusters := range stats.ClusterLengths {
+		keys += length * clusters
+	}
+	require.Equal(t, 1000, keys)
+	require.GreaterOrEqual(t, stats.MaxProbe, 1)
+	require.LessOrEqual(t, stats.MaxProbe, stats.MaxCluster)
+	// 0.49 keys per slot: the home slots are grouped 11 at a time, 186 groups
+	require.Equal(t, 185, stats.DegreesOfFreedom)
+	require.Greater(t, stats.PValue, 0.001)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+
+	"fmt"
+)
+
+func main() {
+	a := sv.NewSparseVector(10)
+	b := sv.NewSparseVector(10)
+
+	a.Put(3, 0.50)
+	a.Put(9, 0.75)
+	a.Put(6, 0.11)
+	a.Put(6, 0.00)
+	b.Put(3, 0.60)
+	b.Put(4, 0.90)
+
+	fmt.Println("a =", a)
+	fmt.Println("b =", b)
+
+	fmt.Println("a dot b =", a.Dot(b))
+	fmt.Println("a + b =", a.Plus(b))
+
+	// the 1-D Poisson equation on 5 points: a tridiagonal system with solution 1, 2, 3, 4, 5
+	n := 5
+	m := sv.NewSparseMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Put(i, i, 2)
+		if i > 0 {
+			m.Put(i, i-1, -1)
+		}
+		if i < n-1 {
+			m.Put(i, i+1, -1)
+		}
+	}
+	rhs := []float64{0, 0, 0, 0, 6}
+
+	x, iterations, err := sv.ConjugateGradient(m.CSR(), rhs)
+	fmt.Printf("conjugate gradient: %.6f in %d iterations, %v\n", x, iterations, err)
+	x, iterations, err = sv.Jacobi(m.CSR(), rhs, sv.WithMaxIterations(1000))
+	fmt.Printf("Jacobi:             %.6f in %d iterations, %v\n", x, iterations, err)
+}
@@ -0,0 +1,178 @@
+package sparse_vector
+
+import (
+	"errors"
+	"log"
+	"math"
+)
+
+// Iterative solvers for A x = b with A sparse and square. They only multiply by A, so each
+// iteration takes time proportional to the number of nonzeros, and they stop when the
+// residual ||b - A x|| is within a tolerance of ||b||. Jacobi converges when A is strictly
+// diagonally dominant; conjugate gradient needs A symmetric positive definite and then
+// converges in at most n iterations in exact arithmetic, far fewer in practice when the
+// eigenvalues of A are clustered.
+
+// ErrNotConverged is returned by a solver that reaches its iteration limit.
+var ErrNotConverged = errors.New("sparse_vector: solver did not converge")
+
+// SolverOption
+// Configures an iterative solver.
+type SolverOption func(*solverOptions)
+
+type solverOptions struct {
+	tolerance     float64
+	maxIterations int
+	guess         []float64
+}
+
+// WithTolerance
+// Sets the relative residual ||b - A x|| / ||b|| at which the solver stops (default 1e-10).
+func WithTolerance(tolerance float64) SolverOption {
+	return func(o *solverOptions) {
+		if tolerance <= 0 {
+			log.Fatalln("tolerance must be positive")
+		}
+		o.tolerance = tolerance
+	}
+}
+
+// WithMaxIterations
+// Sets the number of iterations after which the solver gives up (default 10 n).
+func WithMaxIterations(iterations int) SolverOption {
+	return func(o *solverOptions) {
+		if iterations < 1 {
+			log.Fatalln("max iterations must be at least 1")
+		}
+		o.maxIterations = iterations
+	}
+}
+
+// WithInitialGuess
+// Starts the iteration from x instead of the zero vector.
+func WithInitialGuess(x []float64) SolverOption {
+	return func(o *solverOptions) {
+		o.guess = x
+	}
+}
+
+func newSolverOptions(a *CSR, b []float64, opts []SolverOption) solverOptions {
+	if a.m != a.n {
+		log.Fatalln("Matrix is not square")
+	}
+	if a.n != len(b) {
+		log.Fatalln("Dimensions disagree")
+	}
+
+	o := solverOptions{tolerance: 1e-10, maxIterations: 10 * a.n}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.guess != nil && len(o.guess) != a.n {
+		log.Fatalln("Initial guess has the wrong dimension")
+	}
+	return o
+}
+
+// the starting point of the iteration, a copy of the guess if there is one
+func (o *solverOptions) start(n int) []float64 {
+	x := make([]float64, n)
+	copy(x, o.guess)
+	return x
+}
+
+func norm(x []float64) float64 {
+	return math.Sqrt(dot(x, x))
+}
+
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// r = b - A x; returns ||r||
+func residual(a *CSR, x, b, r []float64) float64 {
+	a.mulVec(x, r)
+	for i := range r {
+		r[i] = b[i] - r[i]
+	}
+	return norm(r)
+}
+
+// Jacobi
+// Solves A x = b by Jacobi iteration, x_i <- (b_i - sum_{j != i} a_ij x_j) / a_ii, and
+// returns x and the number of iterations. A must have no zeros on its diagonal.
+func Jacobi(a *CSR, b []float64, opts ...SolverOption) ([]float64, int, error) {
+	o := newSolverOptions(a, b, opts)
+	diagonal := a.Diagonal()
+	for _, d := range diagonal {
+		if d == 0.0 {
+			log.Fatalln("Matrix has a zero on the diagonal")
+		}
+	}
+
+	x := o.start(a.n)
+	r := make([]float64, a.n)
+	target := o.tolerance * norm(b)
+	if residual(a, x, b, r) <= target {
+		return x, 0, nil
+	}
+
+	for iteration := 1; iteration <= o.maxIterations; iteration++ {
+		// x + D^-1 r is the Jacobi update: the diagonal term of r cancels the old x_i
+		for i := range x {
+			x[i] += r[i] / diagonal[i]
+		}
+		if residual(a, x, b, r) <= target {
+			return x, iteration, nil
+		}
+	}
+	return x, o.maxIterations, ErrNotConverged
+}
+
+// ConjugateGradient
+// Solves A x = b by the conjugate gradient method (Hestenes and Stiefel) and returns x and
+// the number of iterations. A must be symmetric positive definite.
+func ConjugateGradient(a *CSR, b []float64, opts ...SolverOption) ([]float64, int, error) {
+	o := newSolverOptions(a, b, opts)
+
+	x := o.start(a.n)
+	r := make([]float64, a.n)
+	target := o.tolerance * norm(b)
+	if residual(a, x, b, r) <= target {
+		return x, 0, nil
+	}
+
+	p := make([]float64, a.n)
+	copy(p, r)
+	ap := make([]float64, a.n)
+	rr := dot(r, r)
+	for iteration := 1; iteration <= o.maxIterations; iteration++ {
+		a.mulVec(p, ap)
+		pap := dot(p, ap)
+		if pap <= 0 {
+			log.Fatalln("Matrix is not positive definite")
+		}
+
+		// step to the minimum of the energy along p, then make the next direction A-conjugate to p
+		alpha := rr / pap
+		for i := range x {
+			x[i] += alpha * p[i]
+			r[i] -= alpha * ap[i]
+		}
+
+		next := dot(r, r)
+		if math.Sqrt(next) <= target {
+			return x, iteration, nil
+		}
+		beta := next / rr
+		for i := range p {
+			p[i] = r[i] + beta*p[i]
+		}
+		rr = next
+	}
+	return x, o.maxIterations, ErrNotConverged
+}
@@ -0,0 +1,283 @@
+package sparse_vector
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// SparseMatrix
+// An m-by-n matrix stored as m sparse row vectors, so that it takes space proportional to
+// m plus the number of nonzeros, and entries can be set in any order. Once it is built,
+// CSR compacts it into compressed sparse row form for the solvers, which only multiply.
+type SparseMatrix struct {
+	m, n int             // number of rows and columns
+	rows []*SparseVector // rows[i] is row i, of dimension n
+}
+
+// NewSparseMatrix
+// Initializes an m-by-n zero matrix.
+func NewSparseMatrix(m, n int) *SparseMatrix {
+	if m < 0 || n < 0 {
+		log.Fatalln("Matrix dimensions must be nonnegative")
+	}
+
+	rows := make([]*SparseVector, m)
+	for i := range rows {
+		rows[i] = NewSparseVector(n)
+	}
+	return &SparseMatrix{m: m, n: n, rows: rows}
+}
+
+// Rows
+// Returns the number of rows.
+func (a *SparseMatrix) Rows() int {
+	return a.m
+}
+
+// Cols
+// Returns the number of columns.
+func (a *SparseMatrix) Cols() int {
+	return a.n
+}
+
+// Nnz
+// Returns the number of nonzero entries in this matrix.
+func (a *SparseMatrix) Nnz() int {
+	nnz := 0
+	for _, row := range a.rows {
+		nnz += row.Nnz()
+	}
+	return nnz
+}
+
+// Put
+// Sets entry (i, j) to the specified value.
+func (a *SparseMatrix) Put(i, j int, value float64) {
+	if i < 0 || i >= a.m {
+		log.Fatalln("Illegal index")
+	}
+	a.rows[i].Put(j, value)
+}
+
+// Get
+// Returns entry (i, j).
+func (a *SparseMatrix) Get(i, j int) float64 {
+	if i < 0 || i >= a.m {
+		log.Fatalln("Illegal index")
+	}
+	return a.rows[i].Get(j)
+}
+
+// Row
+// Returns row i, which shares its entries with the matrix.
+func (a *SparseMatrix) Row(i int) *SparseVector {
+	if i < 0 || i >= a.m {
+		log.Fatalln("Illegal index")
+	}
+	return a.rows[i]
+}
+
+// Times
+// Returns the matrix-vector product of this matrix with the specified vector.
+func (a *SparseMatrix) Times(x *SparseVector) *SparseVector {
+	if a.n != x.d {
+		log.Fatalln("Dimensions disagree")
+	}
+
+	b := NewSparseVector(a.m)
+	for i, row := range a.rows {
+		b.Put(i, row.Dot(x))
+	}
+	return b
+}
+
+// Mul
+// Returns the matrix-matrix product of this matrix with the specified matrix. Row i of the
+// product combines the rows of that matrix picked by the nonzeros of row i of this one
+// (Gustavson), so the time is proportional to the number of multiplications that involve
+// two nonzeros.
+func (a *SparseMatrix) Mul(that *SparseMatrix) *SparseMatrix {
+	if a.n != that.m {
+		log.Fatalln("Dimensions disagree")
+	}
+
+	c := NewSparseMatrix(a.m, that.n)
+	for i, row := range a.rows {
+		st := c.rows[i].st
+		for k, value := range row.st {
+			for j, other := range that.rows[k].st {
+				st[j] += value * other
+			}
+		}
+		// drop the entries that cancelled out
+		for j, value := range st {
+			if value == 0.0 {
+				delete(st, j)
+			}
+		}
+	}
+	return c
+}
+
+// Transpose
+// Returns the transpose of this matrix.
+func (a *SparseMatrix) Transpose() *SparseMatrix {
+	t := NewSparseMatrix(a.n, a.m)
+	for i, row := range a.rows {
+		for j, value := range row.st {
+			t.rows[j].st[i] = value
+		}
+	}
+	return t
+}
+
+// CSR
+// Returns this matrix in compressed sparse row form.
+func (a *SparseMatrix) CSR() *CSR {
+	c := &CSR{
+		m:      a.m,
+		n:      a.n,
+		rowPtr: make([]int, a.m+1),
+		colInd: make([]int, 0, a.Nnz()),
+		values: make([]float64, 0, a.Nnz()),
+	}
+	for i, row := range a.rows {
+		for _, j := range row.Indices() {
+			c.colInd = append(c.colInd, j)
+			c.values = append(c.values, row.st[j])
+		}
+		c.rowPtr[i+1] = len(c.colInd)
+	}
+	return c
+}
+
+// String
+// Returns the nonzero entries row by row.
+func (a *SparseMatrix) String() string {
+	var s strings.Builder
+	for i, row := range a.rows {
+		fmt.Fprintf(&s, "%d: %v\n", i, row)
+	}
+	return s.String()
+}
+
+// CSR
+// An immutable m-by-n matrix in compressed sparse row form: the nonzeros of row i are at
+// positions rowPtr[i] through rowPtr[i+1]-1 of colInd and values, in increasing order of
+// column. Three flat arrays make a matrix-vector product a single pass over memory.
+type CSR struct {
+	m, n   int
+	rowPtr []int
+	colInd []int
+	values []float64
+}
+
+// Rows
+// Returns the number of rows.
+func (c *CSR) Rows() int {
+	return c.m
+}
+
+// Cols
+// Returns the number of columns.
+func (c *CSR) Cols() int {
+	return c.n
+}
+
+// Nnz
+// Returns the number of nonzero entries in this matrix.
+func (c *CSR) Nnz() int {
+	return len(c.values)
+}
+
+// Get
+// Returns entry (i, j), by binary search in row i.
+func (c *CSR) Get(i, j int) float64 {
+	if i < 0 || i >= c.m || j < 0 || j >= c.n {
+		log.Fatalln("Illegal index")
+	}
+
+	lo, hi := c.rowPtr[i], c.rowPtr[i+1]
+	if k := lo + sort.SearchInts(c.colInd[lo:hi], j); k < hi && c.colInd[k] == j {
+		return c.values[k]
+	}
+	return 0.0
+}
+
+// MulVec
+// Returns the matrix-vector product of this matrix with the specified dense vector.
+func (c *CSR) MulVec(x []float64) []float64 {
+	if c.n != len(x) {
+		log.Fatalln("Dimensions disagree")
+	}
+
+	b := make([]float64, c.m)
+	c.mulVec(x, b)
+	return b
+}
+
+// b = A x without allocating
+func (c *CSR) mulVec(x, b []float64) {
+	for i := 0; i < c.m; i++ {
+		sum := 0.0
+		for k := c.rowPtr[i]; k < c.rowPtr[i+1]; k++ {
+			sum += c.values[k] * x[c.colInd[k]]
+		}
+		b[i] = sum
+	}
+}
+
+// Diagonal
+// Returns the entries on the diagonal.
+func (c *CSR) Diagonal() []float64 {
+	d := make([]float64, min(c.m, c.n))
+	for i := range d {
+		d[i] = c.Get(i, i)
+	}
+	return d
+}
+
+// Transpose
+// Returns the transpose of this matrix, by counting the nonzeros in each column.
+func (c *CSR) Transpose() *CSR {
+	t := &CSR{
+		m:      c.n,
+		n:      c.m,
+		rowPtr: make([]int, c.n+1),
+		colInd: make([]int, len(c.colInd)),
+		values: make([]float64, len(c.values)),
+	}
+	for _, j := range c.colInd {
+		t.rowPtr[j+1]++
+	}
+	for j := 0; j < c.n; j++ {
+		t.rowPtr[j+1] += t.rowPtr[j]
+	}
+
+	// rows are visited in order, so each column of the transpose comes out sorted
+	next := make([]int, c.n)
+	copy(next, t.rowPtr)
+	for i := 0; i < c.m; i++ {
+		for k := c.rowPtr[i]; k < c.rowPtr[i+1]; k++ {
+			j := c.colInd[k]
+			t.colInd[next[j]] = i
+			t.values[next[j]] = c.values[k]
+			next[j]++
+		}
+	}
+	return t
+}
+
+// SparseMatrix
+// Returns this matrix in row-wise form.
+func (c *CSR) SparseMatrix() *SparseMatrix {
+	a := NewSparseMatrix(c.m, c.n)
+	for i := 0; i < c.m; i++ {
+		for k := c.rowPtr[i]; k < c.rowPtr[i+1]; k++ {
+			a.rows[i].st[c.colInd[k]] = c.values[k]
+		}
+	}
+	return a
+}
@@ -1,13 +1,15 @@
-package main
+package sparse_vector
 
 import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
+	"strings"
 )
 
 type SparseVector struct {
-	d int // dimension
+	d  int             // dimension
 	st map[int]float64 // the vector, represented by index-value pairs
 }
 
@@ -15,32 +17,32 @@ type SparseVector struct {
 // Initializes a d-dimensional zero vector.
 func NewSparseVector(dimension int) *SparseVector {
 	return &SparseVector{
-		d: dimension,
+		d:  dimension,
 		st: make(map[int]float64),
 	}
 }
 
 // Put
 // Sets the ith coordinate of this vector to the specified value.
-func Put(this *SparseVector, i int, value float64) {
-	if i < 0 || i >= this.d {
+func (v *SparseVector) Put(i int, value float64) {
+	if i < 0 || i >= v.d {
 		log.Fatalln("Illegal index")
 	}
 	if value == 0.0 {
-		delete(this.st, i)
+		delete(v.st, i)
 	} else {
-		this.st[i] = value
+		v.st[i] = value
 	}
 }
 
 // Get
 // Returns the ith coordinate of this vector.
-func Get(this *SparseVector, i int) float64 {
-	if i < 0 || i >= this.d {
+func (v *SparseVector) Get(i int) float64 {
+	if i < 0 || i >= v.d {
 		log.Fatalln("Illegal index")
 	}
 
-	if value, ok := this.st[i]; ok {
+	if value, ok := v.st[i]; ok {
 		return value
 	}
 
@@ -49,91 +51,104 @@ func Get(this *SparseVector, i int) float64 {
 
 // Nnz
 // Returns the number of nonzero entries in this vector.
-func Nnz(this *SparseVector) int {
-	return len(this.st)
+func (v *SparseVector) Nnz() int {
+	return len(v.st)
 }
 
 // Dimension
 // Returns the dimension of this vector.
-func Dimension(this *SparseVector) int {
-	return this.d
+func (v *SparseVector) Dimension() int {
+	return v.d
+}
+
+// Indices
+// Returns the indices of the nonzero entries in increasing order.
+func (v *SparseVector) Indices() []int {
+	indices := make([]int, 0, len(v.st))
+	for i := range v.st {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
 }
 
 // Dot
 // Returns the inner product of this vector with the specified vector
-func Dot(this, that *SparseVector) float64 {
-	if this.d != that.d {
-		log.Fatalln("Illegal index")
+func (v *SparseVector) Dot(that *SparseVector) float64 {
+	if v.d != that.d {
+		log.Fatalln("Vector lengths disagree")
 	}
 	var sum = 0.0
 
 	// iterate over the vector with the fewest nonzeros
-	if Nnz(this) <= Nnz(that) {
-		for i := range this.st {
-			if _, ok := that.st[i]; ok {
-				sum += Get(this, i) * Get(that, i)
-			}
-		}
-	} else {
-		for i := range that.st {
-			if _, ok := this.st[i]; ok {
-				sum += Get(this, i) * Get(that, i)
-			}
+	a, b := v, that
+	if a.Nnz() > b.Nnz() {
+		a, b = b, a
+	}
+	for i, value := range a.st {
+		if other, ok := b.st[i]; ok {
+			sum += value * other
 		}
 	}
 
 	return sum
 }
 
+// DotDense
+// Returns the inner product of this vector with the specified dense vector.
+func (v *SparseVector) DotDense(that []float64) float64 {
+	if v.d != len(that) {
+		log.Fatalln("Vector lengths disagree")
+	}
+
+	var sum = 0.0
+	for i, value := range v.st {
+		sum += value * that[i]
+	}
+	return sum
+}
+
 // Magnitude
 // Returns the Magnitude of this vector.
 // This is also known as the L2 norm or the Euclidean norm.
-func Magnitude(this *SparseVector) float64 {
-	return math.Sqrt(Dot(this, this))
+func (v *SparseVector) Magnitude() float64 {
+	return math.Sqrt(v.Dot(v))
 }
 
 // Scale
 // Returns the scalar-vector product of this vector with the specified scalar.
-func Scale(this *SparseVector, alpha float64) *SparseVector {
-	c := NewSparseVector(this.d)
-	for i := range this.st {
-		Put(c, i, alpha * Get(this, i))
+func (v *SparseVector) Scale(alpha float64) *SparseVector {
+	c := NewSparseVector(v.d)
+	for i, value := range v.st {
+		c.Put(i, alpha*value)
 	}
 	return c
 }
 
 // Plus
 // Returns the sum of this vector and the specified vector.
-func Plus(this, that *SparseVector) *SparseVector {
-	if this.d != that.d {
+func (v *SparseVector) Plus(that *SparseVector) *SparseVector {
+	if v.d != that.d {
 		log.Fatalln("Vector lengths disagree")
 	}
-	c := NewSparseVector(this.d)
-	for i := range this.st {
-		Put(c, i, Get(this, i))
+	c := NewSparseVector(v.d)
+	for i, value := range v.st {
+		c.Put(i, value)
 	}
 
-	for i := range that.st {
-		Put(c, i, Get(that, i) + Get(c, i))
+	for i, value := range that.st {
+		c.Put(i, value+c.Get(i))
 	}
 
 	return c
 }
 
-func main(){
-	a := NewSparseVector(10)
-	b := NewSparseVector(10)
-
-	Put(a,3, 0.50)
-	Put(a,9, 0.75)
-	Put(a,6, 0.11)
-	Put(a,6, 0.00)
-	Put(b,3, 0.60)
-	Put(b,4, 0.90)
-
-	fmt.Println("a =", a)
-	fmt.Println("b =", b)
-
-	fmt.Println("a dot b =", Dot(a, b))
-	fmt.Println("a + b =", Plus(a, b))
+// String
+// Returns the nonzero entries as (index, value) pairs in increasing order of index.
+func (v *SparseVector) String() string {
+	var s strings.Builder
+	for _, i := range v.Indices() {
+		fmt.Fprintf(&s, "(%d, %g) ", i, v.st[i])
+	}
+	return s.String()
 }
@@ -0,0 +1,171 @@
+package sparse_vector
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	a := NewSparseVector(10)
+	b := NewSparseVector(10)
+
+	a.Put(3, 0.50)
+	a.Put(9, 0.75)
+	a.Put(6, 0.11)
+	a.Put(6, 0.00)
+	b.Put(3, 0.60)
+	b.Put(4, 0.90)
+
+	require.Equal(t, 2, a.Nnz())
+	require.Equal(t, 10, a.Dimension())
+	require.Equal(t, []int{3, 9}, a.Indices())
+	require.Equal(t, "(3, 0.5) (9, 0.75) ", a.String())
+	require.InDelta(t, 0.3, a.Dot(b), 1e-12)
+	require.InDelta(t, 0.3, a.DotDense([]float64{0, 0, 0, 0.6, 0.9, 0, 0, 0, 0, 0}), 1e-12)
+	require.InDelta(t, 0.9013878, a.Magnitude(), 1e-6)
+	require.Equal(t, "(3, 1.1) (4, 0.9) (9, 0.75) ", a.Plus(b).String())
+	require.Equal(t, "(3, 1) (9, 1.5) ", a.Scale(2).String())
+	require.Equal(t, 0, a.Scale(0).Nnz())
+}
+
+// a random m-by-n matrix with about density m n nonzeros, and its dense form
+func random(r *rand.Rand, m, n int, density float64) (*SparseMatrix, [][]float64) {
+	a := NewSparseMatrix(m, n)
+	dense := make([][]float64, m)
+	for i := range dense {
+		dense[i] = make([]float64, n)
+		for j := range dense[i] {
+			if r.Float64() < density {
+				dense[i][j] = float64(r.Intn(19) - 9)
+				a.Put(i, j, dense[i][j])
+			}
+		}
+	}
+	return a, dense
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, da := random(r, 30, 20, 0.2)
+	b, db := random(r, 20, 25, 0.2)
+
+	c := a.Mul(b)
+	require.Equal(t, 30, c.Rows())
+	require.Equal(t, 25, c.Cols())
+	nnz := 0
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 25; j++ {
+			sum := 0.0
+			for k := 0; k < 20; k++ {
+				sum += da[i][k] * db[k][j]
+			}
+			require.Equal(t, sum, c.Get(i, j))
+			if sum != 0 {
+				nnz++
+			}
+		}
+	}
+	require.Equal(t, nnz, c.Nnz())
+
+	at := a.Transpose()
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 20; j++ {
+			require.Equal(t, da[i][j], at.Get(j, i))
+		}
+	}
+
+	x := NewSparseVector(20)
+	dx := make([]float64, 20)
+	for j := 0; j < 20; j += 3 {
+		x.Put(j, float64(j))
+		dx[j] = float64(j)
+	}
+	ax := a.Times(x)
+	csr := a.CSR()
+	require.Equal(t, a.Nnz(), csr.Nnz())
+	for i, value := range csr.MulVec(dx) {
+		require.Equal(t, ax.Get(i), value)
+	}
+
+	ct := csr.Transpose()
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 20; j++ {
+			require.Equal(t, da[i][j], csr.Get(i, j))
+			require.Equal(t, da[i][j], ct.Get(j, i))
+		}
+	}
+	require.Equal(t, a.String(), csr.SparseMatrix().String())
+	require.Equal(t, at.String(), ct.SparseMatrix().String())
+}
+
+// the 5-point Laplacian on a k-by-k grid, which is symmetric positive definite
+func poisson(k int) *SparseMatrix {
+	a := NewSparseMatrix(k*k, k*k)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			v := i*k + j
+			a.Put(v, v, 4)
+			if i > 0 {
+				a.Put(v, v-k, -1)
+			}
+			if i < k-1 {
+				a.Put(v, v+k, -1)
+			}
+			if j > 0 {
+				a.Put(v, v-1, -1)
+			}
+			if j < k-1 {
+				a.Put(v, v+1, -1)
+			}
+		}
+	}
+	return a
+}
+
+func TestCase3(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	a := poisson(30).CSR()
+	expected := make([]float64, a.Rows())
+	for i := range expected {
+		expected[i] = r.Float64()
+	}
+	b := a.MulVec(expected)
+
+	x, cgIterations, err := ConjugateGradient(a, b)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, expected, x, 1e-8)
+	require.Less(t, cgIterations, 150)
+
+	// Jacobi converges on the Laplacian, but much more slowly
+	_, _, err = Jacobi(a, b, WithMaxIterations(100))
+	require.ErrorIs(t, err, ErrNotConverged)
+	x, jacobiIterations, err := Jacobi(a, b, WithTolerance(1e-8), WithMaxIterations(100000))
+	require.NoError(t, err)
+	require.InDeltaSlice(t, expected, x, 1e-5)
+	require.Greater(t, jacobiIterations, 10*cgIterations)
+
+	// strictly diagonally dominant systems converge fast
+	d, _ := random(r, 200, 200, 0.02)
+	for i := 0; i < 200; i++ {
+		sum := 0.0
+		for _, j := range d.Row(i).Indices() {
+			if j != i {
+				sum += max(d.Get(i, j), -d.Get(i, j))
+			}
+		}
+		d.Put(i, i, sum+1)
+	}
+	b = d.CSR().MulVec(expected[:200])
+	x, iterations, err := Jacobi(d.CSR(), b)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, expected[:200], x, 1e-8)
+	require.Less(t, iterations, 200)
+
+	// starting from the answer takes no iterations
+	x, iterations, err = ConjugateGradient(a, a.MulVec(expected), WithInitialGuess(expected))
+	require.NoError(t, err)
+	require.Equal(t, 0, iterations)
+	require.Equal(t, expected, x)
+}
@@ -0,0 +1,277 @@
+package robin_hood_hash_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"math/rand"
+)
+
+// Open addressing with Robin Hood linear probing. Every slot records whether it is occupied and
+// how far its key is from its home slot, so no key value is reserved as the empty marker and ""
+// is a legal key, unlike in 07_linear_probing_hash_st. On insert, a key that has probed further
+// than the resident of a slot takes the slot and the resident moves on ("take from the rich"),
+// which keeps the probe lengths short and even, so the table runs well at high load.
+// A search can stop as soon as it passes a key that is closer to home than it would be.
+// Delete shifts the following keys of the cluster back one slot (backward-shift deletion)
+// instead of leaving tombstones or reinserting the cluster.
+
+// must be a power of 2
+const initCapacity = 8
+
+const defaultMaxLoad = 0.875
+
+type slot struct {
+	key   string
+	value int
+	hash  uint64
+	dist  int  // distance from the home slot
+	used  bool // occupancy
+}
+
+type HashST struct {
+	n       int // number of key-value pairs
+	slots   []slot
+	mask    uint64
+	hash    util.Hasher
+	maxLoad float64
+}
+
+// Option
+// Configures a HashST at construction.
+type Option func(*HashST)
+
+// WithHash
+// Uses hash instead of the default xxHash64 with a random seed.
+func WithHash(hash util.Hasher) Option {
+	return func(h *HashST) {
+		h.hash = hash
+	}
+}
+
+// WithMaxLoad
+// Grows the table when the load factor would exceed maxLoad, in (0, 1).
+func WithMaxLoad(maxLoad float64) Option {
+	return func(h *HashST) {
+		if maxLoad > 0 && maxLoad < 1 {
+			h.maxLoad = maxLoad
+		}
+	}
+}
+
+// WithCapacity
+// Sizes the table to hold n keys without growing.
+func WithCapacity(n int) Option {
+	return func(h *HashST) {
+		m := initCapacity
+		for float64(n) > h.maxLoad*float64(m) {
+			m *= 2
+		}
+		h.init(m)
+	}
+}
+
+// NewHashST
+// Initializes an empty symbol table
+func NewHashST(opts ...Option) *HashST {
+	h := &HashST{
+		hash:    util.Seeded(rand.Uint64()),
+		maxLoad: defaultMaxLoad,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.slots == nil {
+		h.init(initCapacity)
+	}
+	return h
+}
+
+func (h *HashST) init(m int) {
+	h.slots = make([]slot, m)
+	h.mask = uint64(m - 1)
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (h *HashST) Size() int {
+	return h.n
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty
+func (h *HashST) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Contains
+// Returns true if this symbol table contains the specified key.
+func (h *HashST) Contains(key string) bool {
+	_, found := h.Get(key)
+	return found
+}
+
+// LoadFactor
+// Returns the fraction of occupied slots.
+func (h *HashST) LoadFactor() float64 {
+	return float64(h.n) / float64(len(h.slots))
+}
+
+// MaxProbe
+// Returns the largest number of slots Get inspects to find a key in the table.
+func (h *HashST) MaxProbe() int {
+	max := 0
+	for i := range h.slots {
+		if h.slots[i].used && h.slots[i].dist+1 > max {
+			max = h.slots[i].dist + 1
+		}
+	}
+	return max
+}
+
+// AverageProbe
+// Returns the average number of slots Get inspects to find a key in the table.
+func (h *HashST) AverageProbe() float64 {
+	if h.n == 0 {
+		return 0
+	}
+	sum := 0
+	for i := range h.slots {
+		if h.slots[i].used {
+			sum += h.slots[i].dist + 1
+		}
+	}
+	return float64(sum) / float64(h.n)
+}
+
+// index of key in slots, or -1
+func (h *HashST) find(key string) int {
+	hash := h.hash(key)
+	i := hash & h.mask
+	for dist := 0; ; dist++ {
+		s := &h.slots[i]
+		if !s.used || s.dist < dist {
+			return -1
+		}
+		if s.hash == hash && s.key == key {
+			return int(i)
+		}
+		i = (i + 1) & h.mask
+	}
+}
+
+// Get
+// Returns the value associated with the specified key.
+func (h *HashST) Get(key string) (int, bool) {
+	if i := h.find(key); i >= 0 {
+		return h.slots[i].value, true
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (h *HashST) Put(key string, value int) {
+	if float64(h.n+1) > h.maxLoad*float64(len(h.slots)) {
+		h.resize(2 * len(h.slots))
+	}
+	if h.insert(slot{key: key, value: value, hash: h.hash(key), used: true}) {
+		h.n++
+	}
+}
+
+// insert s, returning false if its key was already present and only the value was replaced
+func (h *HashST) insert(s slot) bool {
+	i := s.hash & h.mask
+	for {
+		resident := &h.slots[i]
+		if !resident.used {
+			*resident = s
+			return true
+		}
+		if resident.hash == s.hash && resident.key == s.key {
+			resident.value = s.value
+			return false
+		}
+		// the resident is richer (closer to home), so s takes its place
+		if resident.dist < s.dist {
+			*resident, s = s, *resident
+		}
+		i = (i + 1) & h.mask
+		s.dist++
+	}
+}
+
+// resize
+// the hash table to the given capacity, reinserting all of the keys with their stored hashes
+func (h *HashST) resize(capacity int) {
+	old := h.slots
+	h.init(capacity)
+	for _, s := range old {
+		if s.used {
+			s.dist = 0
+			h.insert(s)
+		}
+	}
+}
+
+// Delete
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (h *HashST) Delete(key string) {
+	i := h.find(key)
+	if i < 0 {
+		return
+	}
+
+	// shift the rest of the cluster back until an empty slot or a key at home
+	prev := uint64(i)
+	for next := (prev + 1) & h.mask; h.slots[next].used && h.slots[next].dist > 0; next = (next + 1) & h.mask {
+		h.slots[prev] = h.slots[next]
+		h.slots[prev].dist--
+		prev = next
+	}
+	h.slots[prev] = slot{}
+	h.n--
+
+	// halves size of array if it's 12.5% full or less
+	if len(h.slots) > initCapacity && h.n <= len(h.slots)/8 {
+		h.resize(len(h.slots) / 2)
+	}
+}
+
+// Keys
+// Returns all keys in this symbol table, in table order.
+func (h *HashST) Keys() []string {
+	keys := make([]string, 0, h.n)
+	for i := range h.slots {
+		if h.slots[i].used {
+			keys = append(keys, h.slots[i].key)
+		}
+	}
+	return keys
+}
+
+// Check
+// integrity check: every key is found by Get, every distance matches the key's home slot,
+// and distances never increase by more than one from one slot to the next
+func Check(h *HashST) bool {
+	n := 0
+	for i := range h.slots {
+		s := h.slots[i]
+		if !s.used {
+			continue
+		}
+		n++
+		if uint64(i) != (s.hash+uint64(s.dist))&h.mask {
+			return false
+		}
+		if j := h.find(s.key); j != i {
+			return false
+		}
+		if next := h.slots[(uint64(i)+1)&h.mask]; next.used && next.dist > s.dist+1 {
+			return false
+		}
+	}
+	return n == h.n && h.LoadFactor() <= h.maxLoad
+}
@@ -0,0 +1,145 @@
+package robin_hood_hash_st
+
+import (
+	separateChaining "github.com/lee-hen/Algorithms/3_searching/06_separate_chaining_hash_st"
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	hashSt := NewHashST()
+	for i := 32; i <= 127; i++ {
+		hashSt.Put(string(rune(i)), i)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 96, hashSt.Size())
+
+	for i := 32; i <= 127; i++ {
+		key := string(rune(i))
+		val, _ := hashSt.Get(key)
+		require.Equal(t, i, val)
+		hashSt.Delete(key)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 0, hashSt.Size())
+}
+
+func TestCase2(t *testing.T) {
+	// the empty string is an ordinary key
+	hashSt := NewHashST()
+	require.False(t, hashSt.Contains(""))
+	hashSt.Put("", 1)
+	hashSt.Put("a", 2)
+	val, found := hashSt.Get("")
+	require.True(t, found)
+	require.Equal(t, 1, val)
+	hashSt.Delete("")
+	require.False(t, hashSt.Contains(""))
+	require.Equal(t, []string{"a"}, hashSt.Keys())
+}
+
+func TestCase3(t *testing.T) {
+	// random operations against a map, with every hash function and a crowded table
+	for _, hash := range []util.Hasher{util.CRC32, util.FNV1a, util.Seeded(42), func(string) uint64 { return 7 }} {
+		r := rand.New(rand.NewSource(1))
+		hashSt := NewHashST(WithHash(hash), WithMaxLoad(0.95))
+		want := make(map[string]int)
+		for i := 0; i < 3000; i++ {
+			key := strconv.Itoa(r.Intn(500))
+			if r.Intn(3) == 0 {
+				delete(want, key)
+				hashSt.Delete(key)
+			} else {
+				want[key] = i
+				hashSt.Put(key, i)
+			}
+		}
+		require.True(t, Check(hashSt))
+		require.Equal(t, len(want), hashSt.Size())
+		for key, value := range want {
+			val, found := hashSt.Get(key)
+			require.True(t, found)
+			require.Equal(t, value, val)
+		}
+		require.ElementsMatch(t, mapKeys(want), hashSt.Keys())
+	}
+}
+
+func mapKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+const benchmarkKeys = 1 << 16
+
+func keys() []string {
+	keys := make([]string, benchmarkKeys)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i*7919)
+	}
+	return keys
+}
+
+// go test -run none -bench . compares against 06_separate_chaining_hash_st and Go maps
+func BenchmarkPut(b *testing.B) {
+	keys := keys()
+	b.Run("robin_hood", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			st := NewHashST()
+			for j, key := range keys {
+				st.Put(key, j)
+			}
+		}
+	})
+	b.Run("separate_chaining", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			st := separateChaining.NewHashST()
+			for j, key := range keys {
+				st.Put(key, j)
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := make(map[string]int)
+			for j, key := range keys {
+				m[key] = j
+			}
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := keys()
+	robinHood, chaining, m := NewHashST(), separateChaining.NewHashST(), make(map[string]int)
+	for j, key := range keys {
+		robinHood.Put(key, j)
+		chaining.Put(key, j)
+		m[key] = j
+	}
+
+	b.Run("robin_hood", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			robinHood.Get(keys[i%benchmarkKeys])
+		}
+	})
+	b.Run("separate_chaining", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			chaining.Get(keys[i%benchmarkKeys])
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = m[keys[i%benchmarkKeys]]
+		}
+	})
+}
@@ -0,0 +1,356 @@
+package cuckoo_hash_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"math/rand"
+)
+
+// Cuckoo hashing. The table is split into d subtables, each with its own hash function, and a key
+// lives in exactly one of its d candidate slots (or in a small stash), so Get inspects at most
+// d slots plus the stash: worst-case constant time. Put evicts the occupant of a full slot and
+// moves it to its slot in the next subtable, which may evict another key, and so on; when
+// the eviction chain runs too long the homeless key goes to the stash, and when the stash is
+// full the table is rebuilt with new hash seeds (a rehash), or doubled if rehashing keeps failing.
+// With 2 subtables inserts start failing at a load factor of about 50%, with 3 at about 91%.
+
+// must be a power of 2
+const initCapacity = 8
+
+const (
+	defaultTables = 2
+	stashSize     = 4
+	maxKicks      = 64 // length of an eviction chain before giving up
+	maxRehashes   = 4  // rehashes at one size before doubling
+)
+
+type slot struct {
+	key   string
+	value int
+	used  bool
+}
+
+type HashST struct {
+	n       int // number of key-value pairs
+	tables  [][]slot
+	stash   []slot
+	seeds   []uint64
+	mask    uint64
+	maxLoad float64
+	rnd     *rand.Rand
+
+	rehashes int // total number of rebuilds with new seeds, including growing
+}
+
+// Option
+// Configures a HashST at construction.
+type Option func(*HashST)
+
+// WithTables
+// Uses d >= 2 subtables and hash functions instead of 2.
+func WithTables(d int) Option {
+	return func(h *HashST) {
+		if d >= 2 {
+			h.seeds = make([]uint64, d)
+		}
+	}
+}
+
+// WithMaxLoad
+// Grows the table when the load factor would exceed maxLoad.
+func WithMaxLoad(maxLoad float64) Option {
+	return func(h *HashST) {
+		if maxLoad > 0 && maxLoad < 1 {
+			h.maxLoad = maxLoad
+		}
+	}
+}
+
+// WithSeed
+// Draws the hash seeds from a source with the given seed, so runs are reproducible.
+func WithSeed(seed int64) Option {
+	return func(h *HashST) {
+		h.rnd = rand.New(rand.NewSource(seed))
+	}
+}
+
+// NewHashST
+// Initializes an empty symbol table
+func NewHashST(opts ...Option) *HashST {
+	h := &HashST{
+		seeds: make([]uint64, defaultTables),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.maxLoad == 0 {
+		if len(h.seeds) == 2 {
+			h.maxLoad = 0.45
+		} else {
+			h.maxLoad = 0.85
+		}
+	}
+	if h.rnd == nil {
+		h.rnd = rand.New(rand.NewSource(rand.Int63()))
+	}
+	h.init(initCapacity)
+	return h
+}
+
+// empty subtables of m slots each, with new seeds
+func (h *HashST) init(m int) {
+	h.tables = make([][]slot, len(h.seeds))
+	for t := range h.tables {
+		h.tables[t] = make([]slot, m)
+		h.seeds[t] = h.rnd.Uint64()
+	}
+	h.mask = uint64(m - 1)
+	h.stash = nil
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (h *HashST) Size() int {
+	return h.n
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty
+func (h *HashST) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Contains
+// Returns true if this symbol table contains the specified key.
+func (h *HashST) Contains(key string) bool {
+	_, found := h.Get(key)
+	return found
+}
+
+// capacity of all subtables together
+func (h *HashST) capacity() int {
+	return len(h.tables) * len(h.tables[0])
+}
+
+// hash of key in subtable t - returns value between 0 and m-1
+func (h *HashST) hash(key string, t int) uint64 {
+	return util.XXHash64(key, h.seeds[t]) & h.mask
+}
+
+// Get
+// Returns the value associated with the specified key.
+func (h *HashST) Get(key string) (int, bool) {
+	for t, table := range h.tables {
+		if s := &table[h.hash(key, t)]; s.used && s.key == key {
+			return s.value, true
+		}
+	}
+	for _, s := range h.stash {
+		if s.key == key {
+			return s.value, true
+		}
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (h *HashST) Put(key string, value int) {
+	if s := h.find(key); s != nil {
+		s.value = value
+		return
+	}
+
+	h.n++
+	if float64(h.n) > h.maxLoad*float64(h.capacity()) {
+		h.rebuild(2*len(h.tables[0]), slot{key, value, true})
+		return
+	}
+	if homeless, ok := h.insert(slot{key, value, true}); !ok {
+		h.rebuild(len(h.tables[0]), homeless)
+	}
+}
+
+// the slot holding key, or nil
+func (h *HashST) find(key string) *slot {
+	for t, table := range h.tables {
+		if s := &table[h.hash(key, t)]; s.used && s.key == key {
+			return s
+		}
+	}
+	for i := range h.stash {
+		if h.stash[i].key == key {
+			return &h.stash[i]
+		}
+	}
+	return nil
+}
+
+// place s by evicting keys along a chain through the subtables; if the chain gets too long
+// and the stash is full, return the key left without a slot
+func (h *HashST) insert(s slot) (slot, bool) {
+	t := 0
+	for kick := 0; kick < maxKicks; kick++ {
+		resident := &h.tables[t][h.hash(s.key, t)]
+		if !resident.used {
+			*resident = s
+			return slot{}, true
+		}
+		*resident, s = s, *resident
+		t = (t + 1) % len(h.tables)
+	}
+
+	if len(h.stash) < stashSize {
+		h.stash = append(h.stash, s)
+		return slot{}, true
+	}
+	return s, false
+}
+
+// rebuild the table with m slots per subtable and new seeds, holding all keys plus extra ones;
+// if some key finds no place, try again with new seeds, doubling m every maxRehashes attempts
+func (h *HashST) rebuild(m int, extra ...slot) {
+	all := append(h.slots(), extra...)
+	for attempt := 1; ; attempt++ {
+		h.rehashes++
+		h.init(m)
+		if h.insertAll(all) {
+			return
+		}
+		if attempt%maxRehashes == 0 {
+			m *= 2
+		}
+	}
+}
+
+func (h *HashST) insertAll(slots []slot) bool {
+	for _, s := range slots {
+		if _, ok := h.insert(s); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// all occupied slots of the subtables and the stash
+func (h *HashST) slots() []slot {
+	slots := make([]slot, 0, h.n)
+	for _, table := range h.tables {
+		for _, s := range table {
+			if s.used {
+				slots = append(slots, s)
+			}
+		}
+	}
+	return append(slots, h.stash...)
+}
+
+// Delete
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (h *HashST) Delete(key string) {
+	s := h.find(key)
+	if s == nil {
+		return
+	}
+
+	*s = slot{}
+	for i := range h.stash {
+		if !h.stash[i].used {
+			h.stash = append(h.stash[:i], h.stash[i+1:]...)
+			break
+		}
+	}
+	h.n--
+
+	// halves size of subtables if they're 12.5% full or less
+	if m := len(h.tables[0]); m > initCapacity && h.n <= h.capacity()/8 {
+		h.rebuild(m / 2)
+	}
+}
+
+// Keys
+// Returns all keys in this symbol table, in table order.
+func (h *HashST) Keys() []string {
+	keys := make([]string, 0, h.n)
+	for _, s := range h.slots() {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// LoadFactor
+// Returns the fraction of occupied slots in the subtables (the stash not included).
+func (h *HashST) LoadFactor() float64 {
+	return float64(h.n-len(h.stash)) / float64(h.capacity())
+}
+
+// probe lengths of all keys: the number of slots Get inspects to find each one
+func (h *HashST) probes() []int {
+	probes := make([]int, 0, h.n)
+	for t, table := range h.tables {
+		for _, s := range table {
+			if s.used {
+				probes = append(probes, t+1)
+			}
+		}
+	}
+	for i := range h.stash {
+		probes = append(probes, len(h.tables)+i+1)
+	}
+	return probes
+}
+
+// MaxProbe
+// Returns the largest number of slots Get inspects to find a key in the table.
+func (h *HashST) MaxProbe() int {
+	return util.Max(0, h.probes()...)
+}
+
+// AverageProbe
+// Returns the average number of slots Get inspects to find a key in the table.
+func (h *HashST) AverageProbe() float64 {
+	if h.n == 0 {
+		return 0
+	}
+	sum := 0
+	for _, p := range h.probes() {
+		sum += p
+	}
+	return float64(sum) / float64(h.n)
+}
+
+// Stashed
+// Returns the number of keys in the stash.
+func (h *HashST) Stashed() int {
+	return len(h.stash)
+}
+
+// Rehashes
+// Returns the number of times the table was rebuilt, to grow, shrink or choose new seeds.
+func (h *HashST) Rehashes() int {
+	return h.rehashes
+}
+
+// Check
+// integrity check: every key sits in one of its candidate slots or in the stash,
+// and is found by Get
+func Check(h *HashST) bool {
+	n := 0
+	for t, table := range h.tables {
+		for i, s := range table {
+			if !s.used {
+				continue
+			}
+			n++
+			if h.hash(s.key, t) != uint64(i) {
+				return false
+			}
+			if val, found := h.Get(s.key); !found || val != s.value {
+				return false
+			}
+		}
+	}
+	return n+len(h.stash) == h.n && len(h.stash) <= stashSize
+}
@@ -0,0 +1,78 @@
+package cuckoo_hash_st
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	hashSt := NewHashST()
+	for i := 32; i <= 127; i++ {
+		hashSt.Put(string(rune(i)), i)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 96, hashSt.Size())
+
+	for i := 32; i <= 127; i++ {
+		key := string(rune(i))
+		val, _ := hashSt.Get(key)
+		require.Equal(t, i, val)
+		hashSt.Delete(key)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 0, hashSt.Size())
+}
+
+func TestCase2(t *testing.T) {
+	// random operations against a map
+	for _, tables := range []int{2, 3, 4} {
+		r := rand.New(rand.NewSource(1))
+		hashSt := NewHashST(WithTables(tables), WithSeed(1))
+		want := make(map[string]int)
+		for i := 0; i < 20000; i++ {
+			key := strconv.Itoa(r.Intn(5000))
+			if r.Intn(3) == 0 {
+				delete(want, key)
+				hashSt.Delete(key)
+			} else {
+				want[key] = i
+				hashSt.Put(key, i)
+			}
+		}
+		require.True(t, Check(hashSt))
+		require.Equal(t, len(want), hashSt.Size())
+		for key, value := range want {
+			val, found := hashSt.Get(key)
+			require.True(t, found)
+			require.Equal(t, value, val)
+		}
+
+		keys := make([]string, 0, len(want))
+		for key := range want {
+			keys = append(keys, key)
+		}
+		got := hashSt.Keys()
+		sort.Strings(keys)
+		sort.Strings(got)
+		require.Equal(t, keys, got)
+	}
+}
+
+func TestCase3(t *testing.T) {
+	// lookups never inspect more than the subtables and the stash
+	hashSt := NewHashST(WithTables(3), WithSeed(7))
+	for i := 0; i < 10000; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+	}
+	require.LessOrEqual(t, hashSt.MaxProbe(), 3+stashSize)
+	require.LessOrEqual(t, hashSt.LoadFactor(), 0.85)
+	require.Greater(t, hashSt.LoadFactor(), 0.4)
+	require.Greater(t, hashSt.AverageProbe(), 1.0)
+	require.Greater(t, hashSt.Rehashes(), 0)
+}
@@ -0,0 +1,368 @@
+package hopscotch_hash_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"math/bits"
+	"math/rand"
+)
+
+// Hopscotch hashing. Every key is kept within a neighborhood of H slots starting at its home
+// slot, and each home slot has an H-bit hop map telling which slots of its neighborhood hold
+// its keys, so Get inspects at most H slots, all in the same few cache lines. Put finds the
+// nearest free slot by linear probing and, while it is too far from home, hops it backwards
+// by moving a key whose neighborhood covers the free slot into it. If no key can be moved
+// the table doubles, unless it is less than a quarter full: then the keys in the way share
+// their home slot, or nearly, and no table size separates them, so the key goes to a short
+// overflow list that Get searches after the neighborhood. Delete just clears the slot and
+// its hop bit.
+
+// must be a power of 2, and at least H
+const initCapacity = 32
+
+// neighborhood size: the number of bits in a hop map
+const H = 32
+
+const (
+	defaultMaxLoad  = 0.9
+	maxFreeDistance = 512 // how far Put searches for a free slot before growing the table
+)
+
+type slot struct {
+	key   string
+	value int
+	used  bool
+}
+
+type HashST struct {
+	n        int // number of key-value pairs
+	slots    []slot
+	hop      []uint32 // hop[i] bit b set = slots[i+b] holds a key whose home is i
+	overflow []slot   // keys that fit in no neighborhood
+	mask     uint64
+	hash     util.Hasher
+	maxLoad  float64
+
+	resizes int
+}
+
+// Option
+// Configures a HashST at construction.
+type Option func(*HashST)
+
+// WithHash
+// Uses hash instead of the default xxHash64 with a random seed.
+func WithHash(hash util.Hasher) Option {
+	return func(h *HashST) {
+		h.hash = hash
+	}
+}
+
+// WithMaxLoad
+// Grows the table when the load factor would exceed maxLoad.
+func WithMaxLoad(maxLoad float64) Option {
+	return func(h *HashST) {
+		if maxLoad > 0 && maxLoad < 1 {
+			h.maxLoad = maxLoad
+		}
+	}
+}
+
+// NewHashST
+// Initializes an empty symbol table
+func NewHashST(opts ...Option) *HashST {
+	h := &HashST{
+		hash:    util.Seeded(rand.Uint64()),
+		maxLoad: defaultMaxLoad,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.init(initCapacity)
+	return h
+}
+
+func (h *HashST) init(m int) {
+	h.slots = make([]slot, m)
+	h.hop = make([]uint32, m)
+	h.mask = uint64(m - 1)
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (h *HashST) Size() int {
+	return h.n
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty
+func (h *HashST) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Contains
+// Returns true if this symbol table contains the specified key.
+func (h *HashST) Contains(key string) bool {
+	_, found := h.Get(key)
+	return found
+}
+
+// home slot of key - returns value between 0 and m-1
+func (h *HashST) home(key string) uint64 {
+	return h.hash(key) & h.mask
+}
+
+// index of key in slots, or -1
+func (h *HashST) find(key string) int {
+	home := h.home(key)
+	for hop := h.hop[home]; hop != 0; hop &= hop - 1 {
+		i := (home + uint64(bits.TrailingZeros32(hop))) & h.mask
+		if h.slots[i].key == key {
+			return int(i)
+		}
+	}
+	return -1
+}
+
+// index of key in the overflow list, or -1
+func (h *HashST) findOverflow(key string) int {
+	for i := range h.overflow {
+		if h.overflow[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get
+// Returns the value associated with the specified key.
+func (h *HashST) Get(key string) (int, bool) {
+	if i := h.find(key); i >= 0 {
+		return h.slots[i].value, true
+	}
+	if i := h.findOverflow(key); i >= 0 {
+		return h.overflow[i].value, true
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (h *HashST) Put(key string, value int) {
+	if i := h.find(key); i >= 0 {
+		h.slots[i].value = value
+		return
+	}
+	if i := h.findOverflow(key); i >= 0 {
+		h.overflow[i].value = value
+		return
+	}
+
+	if float64(h.n+1) > h.maxLoad*float64(len(h.slots)) {
+		h.resize(2 * len(h.slots))
+	}
+	s := slot{key, value, true}
+	for !h.insert(s) {
+		// growing a sparse table would not separate the keys in the way
+		if h.n < len(h.slots)/4 {
+			h.overflow = append(h.overflow, s)
+			break
+		}
+		h.resize(2 * len(h.slots))
+	}
+	h.n++
+}
+
+// place s within the neighborhood of its home slot, returning false if that is not possible
+func (h *HashST) insert(s slot) bool {
+	home := h.home(s.key)
+
+	// nearest free slot
+	free := home
+	dist := uint64(0)
+	for h.slots[free].used {
+		free = (free + 1) & h.mask
+		dist++
+		if dist >= maxFreeDistance || dist > h.mask {
+			return false
+		}
+	}
+
+	// hop the free slot back until it is in the neighborhood
+	for dist >= H {
+		moved := false
+		// try the homes whose neighborhoods cover free, furthest first
+		for back := uint64(H - 1); back > 0; back-- {
+			base := (free - back) & h.mask
+			hop := h.hop[base]
+			if hop == 0 {
+				continue
+			}
+			offset := uint64(bits.TrailingZeros32(hop))
+			if offset >= back {
+				continue
+			}
+
+			// move the key at base+offset into free
+			from := (base + offset) & h.mask
+			h.slots[free] = h.slots[from]
+			h.slots[from] = slot{}
+			h.hop[base] = hop&^(1<<offset) | 1<<back
+
+			free = from
+			dist -= back - offset
+			moved = true
+			break
+		}
+		if !moved {
+			return false
+		}
+	}
+
+	h.slots[free] = s
+	h.hop[home] |= 1 << dist
+	return true
+}
+
+// resize
+// the hash table to the given capacity by re-hashing all of the keys,
+// the overflow list included; the keys that still cannot be placed make up the new overflow list
+func (h *HashST) resize(capacity int) {
+	h.resizes++
+	old, overflow := h.slots, h.overflow
+	h.init(capacity)
+	h.overflow = nil
+	h.insertAll(old)
+	h.insertAll(overflow)
+}
+
+func (h *HashST) insertAll(slots []slot) {
+	for _, s := range slots {
+		if s.used && !h.insert(s) {
+			h.overflow = append(h.overflow, s)
+		}
+	}
+}
+
+// Delete
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (h *HashST) Delete(key string) {
+	if i := h.find(key); i >= 0 {
+		home := h.home(key)
+		h.hop[home] &^= 1 << ((uint64(i) - home) & h.mask)
+		h.slots[i] = slot{}
+	} else if i := h.findOverflow(key); i >= 0 {
+		h.overflow = append(h.overflow[:i], h.overflow[i+1:]...)
+	} else {
+		return
+	}
+	h.n--
+
+	// halves size of array if it's 12.5% full or less
+	if len(h.slots) > initCapacity && h.n <= len(h.slots)/8 {
+		h.resize(len(h.slots) / 2)
+	}
+}
+
+// Keys
+// Returns all keys in this symbol table, in table order followed by the overflow list.
+func (h *HashST) Keys() []string {
+	keys := make([]string, 0, h.n)
+	for _, s := range h.slots {
+		if s.used {
+			keys = append(keys, s.key)
+		}
+	}
+	for _, s := range h.overflow {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+// LoadFactor
+// Returns the fraction of occupied slots.
+func (h *HashST) LoadFactor() float64 {
+	return float64(h.n) / float64(len(h.slots))
+}
+
+// probe length of the key in slot i: its distance from home plus one
+func (h *HashST) probe(i int) int {
+	return int((uint64(i)-h.home(h.slots[i].key))&h.mask) + 1
+}
+
+// MaxProbe
+// Returns the largest number of slots from the home slot to a key in the table, counting both.
+func (h *HashST) MaxProbe() int {
+	max := 0
+	for i, s := range h.slots {
+		if s.used {
+			max = util.Max(max, h.probe(i))
+		}
+	}
+	return max
+}
+
+// AverageProbe
+// Returns the average number of slots from the home slot to a key in the table, counting both.
+func (h *HashST) AverageProbe() float64 {
+	n := h.n - len(h.overflow)
+	if n == 0 {
+		return 0
+	}
+	sum := 0
+	for i, s := range h.slots {
+		if s.used {
+			sum += h.probe(i)
+		}
+	}
+	return float64(sum) / float64(n)
+}
+
+// Overflow
+// Returns the number of keys in the overflow list.
+func (h *HashST) Overflow() int {
+	return len(h.overflow)
+}
+
+// Resizes
+// Returns the number of times the table was resized.
+func (h *HashST) Resizes() int {
+	return h.resizes
+}
+
+// Check
+// integrity check: every key is within the neighborhood of its home slot, the hop maps
+// match the keys exactly, the overflow list holds no key of the table, and every key is found by Get
+func Check(h *HashST) bool {
+	n := 0
+	hop := make([]uint32, len(h.slots))
+	for i, s := range h.slots {
+		if !s.used {
+			continue
+		}
+		n++
+		home := h.home(s.key)
+		dist := (uint64(i) - home) & h.mask
+		if dist >= H {
+			return false
+		}
+		hop[home] |= 1 << dist
+		if h.find(s.key) != i {
+			return false
+		}
+	}
+	for i := range hop {
+		if hop[i] != h.hop[i] {
+			return false
+		}
+	}
+	for i, s := range h.overflow {
+		if h.find(s.key) >= 0 || h.findOverflow(s.key) != i {
+			return false
+		}
+		n++
+	}
+	return n == h.n
+}
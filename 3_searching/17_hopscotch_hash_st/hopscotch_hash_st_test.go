@@ -0,0 +1,111 @@
+package hopscotch_hash_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	hashSt := NewHashST()
+	for i := 32; i <= 127; i++ {
+		hashSt.Put(string(rune(i)), i)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 96, hashSt.Size())
+
+	for i := 32; i <= 127; i++ {
+		key := string(rune(i))
+		val, _ := hashSt.Get(key)
+		require.Equal(t, i, val)
+		hashSt.Delete(key)
+		require.True(t, Check(hashSt))
+	}
+
+	require.Equal(t, 0, hashSt.Size())
+}
+
+func TestCase2(t *testing.T) {
+	// random operations against a map
+	for _, hash := range []util.Hasher{util.CRC32, util.FNV1a, util.Seeded(42)} {
+		r := rand.New(rand.NewSource(1))
+		hashSt := NewHashST(WithHash(hash), WithMaxLoad(0.95))
+		want := make(map[string]int)
+		for i := 0; i < 20000; i++ {
+			key := strconv.Itoa(r.Intn(5000))
+			if r.Intn(3) == 0 {
+				delete(want, key)
+				hashSt.Delete(key)
+			} else {
+				want[key] = i
+				hashSt.Put(key, i)
+			}
+		}
+		require.True(t, Check(hashSt))
+		require.Equal(t, len(want), hashSt.Size())
+		for key, value := range want {
+			val, found := hashSt.Get(key)
+			require.True(t, found)
+			require.Equal(t, value, val)
+		}
+
+		keys := make([]string, 0, len(want))
+		for key := range want {
+			keys = append(keys, key)
+		}
+		got := hashSt.Keys()
+		sort.Strings(keys)
+		sort.Strings(got)
+		require.Equal(t, keys, got)
+	}
+}
+
+func TestCase3(t *testing.T) {
+	// lookups never leave the neighborhood, even at high load
+	hashSt := NewHashST(WithMaxLoad(0.97))
+	for i := 0; i < 10000; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+	}
+	require.True(t, Check(hashSt))
+	require.LessOrEqual(t, hashSt.MaxProbe(), H)
+	require.Greater(t, hashSt.LoadFactor(), 0.4)
+	require.Greater(t, hashSt.AverageProbe(), 1.0)
+	require.Greater(t, hashSt.Resizes(), 0)
+}
+
+func TestCase4(t *testing.T) {
+	// more than H keys with the same home slot go to the overflow list instead of growing the table forever
+	hashSt := NewHashST(WithHash(func(string) uint64 { return 7 }))
+	n := 3 * H
+	for i := 0; i < n; i++ {
+		hashSt.Put(strconv.Itoa(i), i)
+		require.True(t, Check(hashSt))
+	}
+	require.Equal(t, n, hashSt.Size())
+	require.Equal(t, n-H, hashSt.Overflow())
+	require.GreaterOrEqual(t, hashSt.LoadFactor(), 0.125)
+
+	hashSt.Put("70", -70)
+	for i := 0; i < n; i++ {
+		val, found := hashSt.Get(strconv.Itoa(i))
+		require.True(t, found)
+		if i == 70 {
+			require.Equal(t, -70, val)
+		} else {
+			require.Equal(t, i, val)
+		}
+	}
+	require.Len(t, hashSt.Keys(), n)
+
+	for i := 0; i < n; i++ {
+		hashSt.Delete(strconv.Itoa(i))
+		require.True(t, Check(hashSt))
+	}
+	require.Equal(t, 0, hashSt.Size())
+	require.Equal(t, 0, hashSt.Overflow())
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	separateChaining "github.com/lee-hen/Algorithms/3_searching/06_separate_chaining_hash_st"
+	linearProbing "github.com/lee-hen/Algorithms/3_searching/07_linear_probing_hash_st"
+	"github.com/lee-hen/Algorithms/util"
+
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+)
+
+/******************************************************************************
+ *  Dependencies: 06_separate_chaining_hash_st, 07_linear_probing_hash_st
+ *
+ *  Reads the words of the given file into both hash tables, counting
+ *  occurrences, and reports the shape of each table and how uniformly
+ *  util.String (CRC32) spreads the keys, compared with other hash functions
+ *  over the same number of buckets.
+ *
+ *  % go run hash_st_stats.go ../../data/tale.txt
+ *
+ *  Remarks
+ *  -------
+ *   - A p-value near 0 means the buckets are too uneven for a uniform hash.
+ *     If CRC32 fails the test and the other hashes pass, the hash is to blame;
+ *     if all of them fail, the keys themselves repeat.
+ *
+ ******************************************************************************/
+
+func histogram(counts []int) string {
+	s := ""
+	for length, count := range counts {
+		if count > 0 {
+			s += fmt.Sprintf(" %d:%d", length, count)
+		}
+	}
+	return s
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatalln("usage: hash_st_stats <file>")
+	}
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer file.Close()
+
+	chaining := separateChaining.NewHashST()
+	probing := linearProbing.NewHashST()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		word := scanner.Text()
+		words = append(words, word)
+
+		count, _ := chaining.Get(word)
+		chaining.Put(word, count+1)
+		count, _ = probing.Get(word)
+		probing.Put(word, count+1)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("%d words, %d distinct\n\n", len(words), chaining.Size())
+
+	cs := chaining.Stats()
+	fmt.Println("separate chaining")
+	fmt.Printf("  chains %d  load factor %.2f  resizes %d\n", cs.Chains, cs.LoadFactor, cs.Resizes)
+	fmt.Printf("  max chain %d  empty chains %d\n", cs.MaxChain, cs.EmptyChains)
+	fmt.Printf("  chain lengths (length:chains)%s\n", histogram(cs.ChainLengths))
+	fmt.Printf("  chi-square %.1f  df %d  p-value %.4f\n\n", cs.ChiSquare, cs.DegreesOfFreedom, cs.PValue)
+
+	ps := probing.Stats()
+	fmt.Println("linear probing")
+	fmt.Printf("  capacity %d  load factor %.2f  resizes %d\n", ps.Capacity, ps.LoadFactor, ps.Resizes)
+	fmt.Printf("  clusters %d  avg cluster %.2f  max cluster %d\n", ps.Clusters, ps.AvgCluster, ps.MaxCluster)
+	fmt.Printf("  cluster lengths (length:clusters)%s\n", histogram(ps.ClusterLengths))
+	fmt.Printf("  avg probe %.2f  max probe %d\n", ps.AvgProbe, ps.MaxProbe)
+	fmt.Printf("  chi-square %.1f  df %d  p-value %.4f\n\n", ps.ChiSquare, ps.DegreesOfFreedom, ps.PValue)
+
+	// the same keys over the same number of buckets with other hash functions
+	keys := chaining.Keys()
+	fmt.Printf("hash quality over %d buckets\n", cs.Chains)
+	for _, hash := range []struct {
+		name string
+		fn   util.Hasher
+	}{
+		{"crc32", util.CRC32},
+		{"fnv1a", util.FNV1a},
+		{"xxhash64", util.Seeded(0)},
+	} {
+		buckets := make([]int, cs.Chains)
+		for _, key := range keys {
+			buckets[hash.fn(key)%uint64(cs.Chains)]++
+		}
+		stat, df, p := util.ChiSquare(buckets)
+		fmt.Printf("  %-9s chi-square %8.1f  df %d  p-value %.4f\n", hash.name, stat, df, p)
+	}
+}
@@ -0,0 +1,128 @@
+package concurrent_st
+
+import (
+	separateChaining "github.com/lee-hen/Algorithms/3_searching/06_separate_chaining_hash_st"
+	persistent "github.com/lee-hen/Algorithms/3_searching/20_persistent_red_black_bst"
+	"github.com/stretchr/testify/require"
+
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// run these with go test -race
+
+func TestCase1(t *testing.T) {
+	h := NewShardedHashST(8)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(g*1000 + i)
+				h.Put(key, i)
+				val, found := h.Get(key)
+				require.True(t, found)
+				require.Equal(t, i, val)
+				if i%2 == 1 {
+					h.Delete(key)
+				}
+				h.Update("counter", func(value int, _ bool) int { return value + 1 })
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	require.Equal(t, 4001, h.Size())
+	counter, _ := h.Get("counter")
+	require.Equal(t, 8000, counter)
+	require.Equal(t, 4001, len(h.Snapshot()))
+}
+
+func TestCase2(t *testing.T) {
+	// a snapshot is taken at one point in time: "b" is always written after "a",
+	// so no snapshot may see a newer "b" than "a"
+	h := NewShardedHashSTFunc(4, func() ST { return separateChaining.NewHashST() })
+	h.Put("a", 0)
+	h.Put("b", 0)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			h.Put("a", i)
+			h.Put("b", i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			snapshot := h.Snapshot()
+			require.Equal(t, 2, len(snapshot))
+			require.LessOrEqual(t, snapshot["b"], snapshot["a"])
+		}
+	}()
+	wg.Wait()
+
+	n := 0
+	h.Range(func(key string, value int) bool {
+		require.Equal(t, 1000, value)
+		n++
+		return true
+	})
+	require.Equal(t, 2, n)
+}
+
+func TestCase3(t *testing.T) {
+	tree := NewCOWRedBlackBST()
+	var wg sync.WaitGroup
+
+	// writers keep the invariant that keys are always added in pairs
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				tree.Update(func(tree *persistent.RedBlackBST) *persistent.RedBlackBST {
+					key := strconv.Itoa(g*100 + i)
+					return tree.Put("a"+key, i).Put("b"+key, i)
+				})
+			}
+		}(g)
+	}
+
+	// readers see whole versions only
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				version := tree.Load()
+				require.Equal(t, 0, version.Size()%2)
+				require.Equal(t, version.Size()/2, len(version.KeysBetween("a", "a~")))
+				require.True(t, persistent.Check(version))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 800, tree.Size())
+	old := tree.Load()
+	tree.Del("a0")
+	require.False(t, tree.Contains("a0"))
+	require.True(t, old.Contains("a0"))
+}
+
+func TestCase4(t *testing.T) {
+	// the default shards accept the empty string as a key
+	h := NewShardedHashST(4)
+	require.False(t, h.Contains(""))
+	h.Put("", 1)
+	require.Equal(t, 2, h.Update("", func(value int, _ bool) int { return value + 1 }))
+	require.Equal(t, 1, h.Size())
+	h.Delete("")
+	require.False(t, h.Contains(""))
+	require.True(t, h.IsEmpty())
+}
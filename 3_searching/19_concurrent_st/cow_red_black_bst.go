@@ -0,0 +1,81 @@
+package concurrent_st
+
+import (
+	persistent "github.com/lee-hen/Algorithms/3_searching/20_persistent_red_black_bst"
+
+	"sync"
+	"sync/atomic"
+)
+
+// COWRedBlackBST
+// A red-black BST for read-mostly data whose readers never block. The current version is a
+// 20_persistent_red_black_bst.RedBlackBST published through an atomic pointer: a reader loads it
+// and reads without any lock, while a writer derives a new version from it, which copies only the
+// O(log n) nodes on the paths it changes (path copying), and publishes the new version
+// (read-copy-update). Writers are serialized by a mutex. Readers that loaded an old version
+// keep a consistent view of it.
+type COWRedBlackBST struct {
+	mu   sync.Mutex // serializes writers
+	tree atomic.Pointer[persistent.RedBlackBST]
+}
+
+// NewCOWRedBlackBST
+// Initializes an empty symbol table.
+func NewCOWRedBlackBST() *COWRedBlackBST {
+	t := &COWRedBlackBST{}
+	t.tree.Store(persistent.NewRedBlackBST())
+	return t
+}
+
+// Load
+// Returns the current version of the tree, for any number of reads that must see the same
+// version, e.g. Rank followed by Select.
+func (t *COWRedBlackBST) Load() *persistent.RedBlackBST {
+	return t.tree.Load()
+}
+
+// Update
+// Publishes the version f derives from the current one; f may make any number of changes,
+// and readers see either all of them or none.
+func (t *COWRedBlackBST) Update(f func(tree *persistent.RedBlackBST) *persistent.RedBlackBST) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tree.Store(f(t.tree.Load()))
+}
+
+func (t *COWRedBlackBST) Put(key string, value int) {
+	t.Update(func(tree *persistent.RedBlackBST) *persistent.RedBlackBST {
+		return tree.Put(key, value)
+	})
+}
+
+func (t *COWRedBlackBST) Del(key string) {
+	t.Update(func(tree *persistent.RedBlackBST) *persistent.RedBlackBST {
+		return tree.Del(key)
+	})
+}
+
+func (t *COWRedBlackBST) Get(key string) (int, bool) {
+	return t.Load().Get(key)
+}
+
+func (t *COWRedBlackBST) Contains(key string) bool {
+	return t.Load().Contains(key)
+}
+
+func (t *COWRedBlackBST) Size() int {
+	return t.Load().Size()
+}
+
+func (t *COWRedBlackBST) IsEmpty() bool {
+	return t.Load().IsEmpty()
+}
+
+func (t *COWRedBlackBST) Keys() []string {
+	return t.Load().Keys()
+}
+
+func (t *COWRedBlackBST) KeysBetween(lo, hi string) []string {
+	return t.Load().KeysBetween(lo, hi)
+}
@@ -0,0 +1,168 @@
+package concurrent_st
+
+import (
+	robinHood "github.com/lee-hen/Algorithms/3_searching/15_robin_hood_hash_st"
+	"github.com/lee-hen/Algorithms/util"
+
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// ST
+// The symbol table operations a shard needs; 06_separate_chaining_hash_st.HashST,
+// 07_linear_probing_hash_st.HashST and the open addressing tables in 15-17 all provide them.
+type ST interface {
+	Size() int
+	Get(key string) (int, bool)
+	Put(key string, value int)
+	Delete(key string)
+	Keys() []string
+}
+
+type shard struct {
+	sync.RWMutex
+	st ST
+}
+
+// ShardedHashST
+// A symbol table that can be shared between goroutines. Keys are spread over N shards by a hash
+// independent of the one inside the shards, and each shard has its own read-write lock, so
+// goroutines working on different shards never wait for each other and readers of one shard
+// only wait for its writers. The size is kept in an atomic counter so Size takes no lock.
+type ShardedHashST struct {
+	shards []shard
+	size   atomic.Int64
+}
+
+// NewShardedHashST
+// Initializes an empty symbol table of n shards, each a 15_robin_hood_hash_st.HashST,
+// which unlike the tables of 06 and 07 accepts the empty string as a key.
+func NewShardedHashST(n int) *ShardedHashST {
+	return NewShardedHashSTFunc(n, func() ST {
+		return robinHood.NewHashST()
+	})
+}
+
+// NewShardedHashSTFunc
+// Initializes an empty symbol table of n shards made by newShard.
+func NewShardedHashSTFunc(n int, newShard func() ST) *ShardedHashST {
+	if n < 1 {
+		log.Fatalln("number of shards must be positive")
+	}
+
+	h := &ShardedHashST{shards: make([]shard, n)}
+	for i := range h.shards {
+		h.shards[i].st = newShard()
+	}
+	return h
+}
+
+func (h *ShardedHashST) shard(key string) *shard {
+	return &h.shards[util.FNV1a(key)%uint64(len(h.shards))]
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (h *ShardedHashST) Size() int {
+	return int(h.size.Load())
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty
+func (h *ShardedHashST) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Get
+// Returns the value associated with the specified key.
+func (h *ShardedHashST) Get(key string) (int, bool) {
+	s := h.shard(key)
+	s.RLock()
+	defer s.RUnlock()
+	return s.st.Get(key)
+}
+
+// Contains
+// Returns true if this symbol table contains the specified key.
+func (h *ShardedHashST) Contains(key string) bool {
+	_, found := h.Get(key)
+	return found
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (h *ShardedHashST) Put(key string, value int) {
+	s := h.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	n := s.st.Size()
+	s.st.Put(key, value)
+	h.size.Add(int64(s.st.Size() - n))
+}
+
+// Update
+// Atomically replaces the value of key with f(value, found) and returns the new value,
+// e.g. to increment a counter without losing concurrent increments.
+func (h *ShardedHashST) Update(key string, f func(value int, found bool) int) int {
+	s := h.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	value, found := s.st.Get(key)
+	value = f(value, found)
+	s.st.Put(key, value)
+	if !found {
+		h.size.Add(1)
+	}
+	return value
+}
+
+// Delete
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (h *ShardedHashST) Delete(key string) {
+	s := h.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	n := s.st.Size()
+	s.st.Delete(key)
+	h.size.Add(int64(s.st.Size() - n))
+}
+
+// Snapshot
+// Returns a copy of all key-value pairs as they were at a single point in time: the read locks
+// of all shards are taken, always in the same order, before any shard is copied.
+func (h *ShardedHashST) Snapshot() map[string]int {
+	for i := range h.shards {
+		h.shards[i].RLock()
+	}
+	defer func() {
+		for i := range h.shards {
+			h.shards[i].RUnlock()
+		}
+	}()
+
+	snapshot := make(map[string]int, h.Size())
+	for i := range h.shards {
+		st := h.shards[i].st
+		for _, key := range st.Keys() {
+			snapshot[key], _ = st.Get(key)
+		}
+	}
+	return snapshot
+}
+
+// Range
+// Calls f for every key-value pair of a snapshot, stopping early if f returns false.
+// f runs without any lock held, so it may use the symbol table.
+func (h *ShardedHashST) Range(f func(key string, value int) bool) {
+	for key, value := range h.Snapshot() {
+		if !f(key, value) {
+			return
+		}
+	}
+}
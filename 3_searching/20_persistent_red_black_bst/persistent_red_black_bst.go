@@ -0,0 +1,547 @@
+package persistent_red_black_bst
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"log"
+)
+
+// A fully persistent left-leaning red-black BST. A RedBlackBST value is an immutable version of
+// the symbol table: Put and Del leave it unchanged and return a new version. The update copies
+// only the nodes on the search path and the siblings whose colors or links change (path copying),
+// O(log n) new nodes, and shares every other node with the old version. Since no version is ever
+// modified, any number of goroutines can read any versions without locks, and keeping a snapshot
+// costs nothing more than keeping its pointer.
+// The algorithms are those of 05_red_black_bst with every node copied before it is changed.
+
+const (
+	RED   = true
+	BLACK = false
+)
+
+type RedBlackBST struct {
+	root *node
+}
+
+type node struct {
+	key         string
+	value, size int
+	color       bool // color of parent link
+	left, right *node
+}
+
+// NewRedBlackBST
+// Returns the empty version.
+func NewRedBlackBST() *RedBlackBST {
+	return &RedBlackBST{}
+}
+
+func (h *node) clone() *node {
+	x := *h
+	return &x
+}
+
+func (h *node) isRed() bool {
+	return h != nil && h.color == RED
+}
+
+func size(x *node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+// IsEmpty
+// Is this symbol table empty?
+func (bst *RedBlackBST) IsEmpty() bool {
+	return bst.root == nil
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (bst *RedBlackBST) Size() int {
+	return size(bst.root)
+}
+
+// Get
+// Returns the value associated with the given key.
+func (bst *RedBlackBST) Get(key string) (int, bool) {
+	for x := bst.root; x != nil; {
+		if key < x.key {
+			x = x.left
+		} else if key > x.key {
+			x = x.right
+		} else {
+			return x.value, true
+		}
+	}
+	return 0, false
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (bst *RedBlackBST) Contains(key string) bool {
+	_, found := bst.Get(key)
+	return found
+}
+
+// Red-black tree insertion.
+
+// Put
+// Returns a new version with the key-value pair inserted, overwriting the old value
+// if the symbol table already contains the key.
+func (bst *RedBlackBST) Put(key string, value int) *RedBlackBST {
+	if key == "" {
+		log.Fatalln("first argument to put() is null")
+	}
+
+	root := put(bst.root, key, value)
+	root.color = BLACK
+	return &RedBlackBST{root}
+}
+
+// insert the key-value pair in a copy of the subtree rooted at h
+func put(h *node, key string, value int) *node {
+	if h == nil {
+		return &node{key: key, value: value, size: 1, color: RED}
+	}
+
+	h = h.clone()
+	if key < h.key {
+		h.left = put(h.left, key, value)
+	} else if key > h.key {
+		h.right = put(h.right, key, value)
+	} else {
+		h.value = value
+	}
+
+	return balance(h)
+}
+
+// Red-black tree deletion.
+
+// Del
+// Returns a new version without the key, or this version if it does not contain the key.
+func (bst *RedBlackBST) Del(key string) *RedBlackBST {
+	if key == "" {
+		log.Fatalln("argument to delete() is null")
+	}
+
+	if !bst.Contains(key) {
+		return bst
+	}
+	return bst.delete(func(root *node) *node {
+		return del(root, key)
+	})
+}
+
+// DelMin
+// Returns a new version without the smallest key.
+func (bst *RedBlackBST) DelMin() *RedBlackBST {
+	if bst.IsEmpty() {
+		log.Fatalln("BST underflow")
+	}
+	return bst.delete(delMin)
+}
+
+// DelMax
+// Returns a new version without the largest key.
+func (bst *RedBlackBST) DelMax() *RedBlackBST {
+	if bst.IsEmpty() {
+		log.Fatalln("BST underflow")
+	}
+	return bst.delete(delMax)
+}
+
+func (bst *RedBlackBST) delete(del func(root *node) *node) *RedBlackBST {
+	root := bst.root.clone()
+
+	// if both children of root are black, set root to red
+	if !root.left.isRed() && !root.right.isRed() {
+		root.color = RED
+	}
+
+	root = del(root)
+	if root != nil {
+		root.color = BLACK
+	}
+	return &RedBlackBST{root}
+}
+
+// delete the key-value pair with the minimum key from a copy of the subtree rooted at h
+func delMin(h *node) *node {
+	h = h.clone()
+	if h.left == nil {
+		return nil
+	}
+
+	if !h.left.isRed() && !h.left.left.isRed() {
+		h = moveRedLeft(h)
+	}
+
+	h.left = delMin(h.left)
+	return balance(h)
+}
+
+// delete the key-value pair with the maximum key from a copy of the subtree rooted at h
+func delMax(h *node) *node {
+	h = h.clone()
+	if h.left.isRed() {
+		h = rotateRight(h)
+	}
+
+	if h.right == nil {
+		return nil
+	}
+
+	if !h.right.isRed() && !h.right.left.isRed() {
+		h = moveRedRight(h)
+	}
+
+	h.right = delMax(h.right)
+	return balance(h)
+}
+
+// delete the key-value pair with the given key from a copy of the subtree rooted at h
+func del(h *node, key string) *node {
+	h = h.clone()
+	if key < h.key {
+		if !h.left.isRed() && !h.left.left.isRed() {
+			h = moveRedLeft(h)
+		}
+		h.left = del(h.left, key)
+	} else {
+		if h.left.isRed() {
+			h = rotateRight(h)
+		}
+
+		if key == h.key && h.right == nil {
+			return nil
+		}
+
+		if !h.right.isRed() && !h.right.left.isRed() {
+			h = moveRedRight(h)
+		}
+
+		if key == h.key {
+			x := min(h.right)
+			h.key, h.value = x.key, x.value
+			h.right = delMin(h.right)
+		} else {
+			h.right = del(h.right, key)
+		}
+	}
+
+	return balance(h)
+}
+
+// Red-black tree helper functions. Each one takes a node that is already a private copy
+// and copies any other node before changing it.
+
+// make a left-leaning link lean to the right
+func rotateRight(h *node) *node {
+	x := h.left.clone()
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = RED
+	x.size = h.size
+	h.size = size(h.left) + size(h.right) + 1
+	return x
+}
+
+// make a right-leaning link lean to the left
+func rotateLeft(h *node) *node {
+	x := h.right.clone()
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = RED
+	x.size = h.size
+	h.size = size(h.left) + size(h.right) + 1
+	return x
+}
+
+// flip the colors of a node and its two children
+func flipColors(h *node) {
+	h.color = !h.color
+	h.left = h.left.clone()
+	h.left.color = !h.left.color
+	h.right = h.right.clone()
+	h.right.color = !h.right.color
+}
+
+// Assuming that h is red and both h.left and h.left.left
+// are black, make h.left or one of its children red.
+func moveRedLeft(h *node) *node {
+	flipColors(h)
+	if h.right.left.isRed() {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// Assuming that h is red and both h.right and h.right.left
+// are black, make h.right or one of its children red.
+func moveRedRight(h *node) *node {
+	flipColors(h)
+	if h.left.left.isRed() {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// restore red-black tree invariant
+func balance(h *node) *node {
+	if h.right.isRed() && !h.left.isRed() {
+		h = rotateLeft(h)
+	}
+
+	if h.left.isRed() && h.left.left.isRed() {
+		h = rotateRight(h)
+	}
+
+	if h.left.isRed() && h.right.isRed() {
+		flipColors(h)
+	}
+	h.size = size(h.left) + size(h.right) + 1
+
+	return h
+}
+
+// Ordered symbol table methods.
+
+// Height
+// Returns the height of the BST (for debugging).
+func (bst *RedBlackBST) Height() int {
+	return height(bst.root)
+}
+
+func height(x *node) int {
+	if x == nil {
+		return -1
+	}
+	return 1 + util.Max(height(x.left), height(x.right))
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (bst *RedBlackBST) Min() string {
+	if bst.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+	return min(bst.root).key
+}
+
+func min(x *node) *node {
+	for x.left != nil {
+		x = x.left
+	}
+	return x
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (bst *RedBlackBST) Max() string {
+	if bst.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+	x := bst.root
+	for x.right != nil {
+		x = x.right
+	}
+	return x.key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (bst *RedBlackBST) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+
+	floor := ""
+	for x := bst.root; x != nil; {
+		if key < x.key {
+			x = x.left
+		} else if key > x.key {
+			floor = x.key
+			x = x.right
+		} else {
+			return x.key
+		}
+	}
+	return floor
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (bst *RedBlackBST) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	ceiling := ""
+	for x := bst.root; x != nil; {
+		if key < x.key {
+			ceiling = x.key
+			x = x.left
+		} else if key > x.key {
+			x = x.right
+		} else {
+			return x.key
+		}
+	}
+	return ceiling
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (bst *RedBlackBST) Select(rank int) string {
+	if rank < 0 || rank >= bst.Size() {
+		log.Fatalf("argument to select() is invalid: : %d\n", rank)
+	}
+
+	x := bst.root
+	for {
+		leftSize := size(x.left)
+		if leftSize > rank {
+			x = x.left
+		} else if leftSize < rank {
+			rank -= leftSize + 1
+			x = x.right
+		} else {
+			return x.key
+		}
+	}
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (bst *RedBlackBST) Rank(key string) int {
+	rank := 0
+	for x := bst.root; x != nil; {
+		if key < x.key {
+			x = x.left
+		} else if key > x.key {
+			rank += 1 + size(x.left)
+			x = x.right
+		} else {
+			return rank + size(x.left)
+		}
+	}
+	return rank
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (bst *RedBlackBST) Keys() []string {
+	if bst.IsEmpty() {
+		return []string{}
+	}
+	return bst.KeysBetween(bst.Min(), bst.Max())
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (bst *RedBlackBST) KeysBetween(lo, hi string) []string {
+	keys := make([]string, 0)
+	collect(bst.root, &keys, lo, hi)
+	return keys
+}
+
+func collect(x *node, keys *[]string, lo, hi string) {
+	if x == nil {
+		return
+	}
+	if lo < x.key {
+		collect(x.left, keys, lo, hi)
+	}
+	if lo <= x.key && x.key <= hi {
+		*keys = append(*keys, x.key)
+	}
+	if hi > x.key {
+		collect(x.right, keys, lo, hi)
+	}
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (bst *RedBlackBST) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if bst.Contains(hi) {
+		return bst.Rank(hi) - bst.Rank(lo) + 1
+	}
+	return bst.Rank(hi) - bst.Rank(lo)
+}
+
+// Check
+// integrity check of the red-black tree data structure
+func Check(bst *RedBlackBST) bool {
+	return isBST(bst.root, "", "") && isSizeConsistent(bst.root) && is23(bst.root, bst.root) && isBalanced(bst.root)
+}
+
+// is the tree rooted at x a BST with all keys strictly between min and max ("" for no bound)?
+func isBST(x *node, min, max string) bool {
+	if x == nil {
+		return true
+	}
+	if min != "" && x.key <= min {
+		return false
+	}
+	if max != "" && x.key >= max {
+		return false
+	}
+	return isBST(x.left, min, x.key) && isBST(x.right, x.key, max)
+}
+
+func isSizeConsistent(x *node) bool {
+	if x == nil {
+		return true
+	}
+	if x.size != size(x.left)+size(x.right)+1 {
+		return false
+	}
+	return isSizeConsistent(x.left) && isSizeConsistent(x.right)
+}
+
+// does the tree have no red right links, and at most one (left) red link in a row on any path?
+func is23(x, root *node) bool {
+	if x == nil {
+		return true
+	}
+	if x.right.isRed() {
+		return false
+	}
+	if x != root && x.isRed() && x.left.isRed() {
+		return false
+	}
+	return is23(x.left, root) && is23(x.right, root)
+}
+
+// do all paths from root to leaf have same number of black edges?
+func isBalanced(root *node) bool {
+	black := 0
+	for x := root; x != nil; x = x.left {
+		if !x.isRed() {
+			black++
+		}
+	}
+	return isBalancedBlack(root, black)
+}
+
+func isBalancedBlack(x *node, black int) bool {
+	if x == nil {
+		return black == 0
+	}
+	if !x.isRed() {
+		black--
+	}
+	return isBalancedBlack(x.left, black) && isBalancedBlack(x.right, black)
+}
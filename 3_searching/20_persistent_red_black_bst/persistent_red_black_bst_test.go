@@ -0,0 +1,127 @@
+package persistent_red_black_bst
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	versions := []*RedBlackBST{NewRedBlackBST()}
+	for i := 0; i < len(keys); i++ {
+		versions = append(versions, versions[i].Put(keys[i], i))
+	}
+
+	for i, st := range versions {
+		require.True(t, Check(st))
+		// version i holds exactly the first i keys
+		for _, key := range keys {
+			require.Equal(t, slices.Contains(keys[:i], key), st.Contains(key))
+		}
+	}
+
+	st := versions[len(keys)]
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, st.Keys())
+	value, _ := st.Get("E")
+	require.Equal(t, 12, value)
+	value, _ = versions[7].Get("E")
+	require.Equal(t, 6, value)
+	value, _ = versions[2].Get("E")
+	require.Equal(t, 1, value)
+
+	require.Equal(t, "H", st.Floor("I"))
+	require.Equal(t, "L", st.Ceiling("I"))
+	require.Equal(t, "", st.Floor("0"))
+	require.Equal(t, "", st.Ceiling("Y"))
+	require.Equal(t, 4, st.Rank("I"))
+	require.Equal(t, "L", st.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, st.KeysBetween("F", "N"))
+	require.Equal(t, 3, st.SizeBetween("F", "N"))
+
+	deleted := st.Del("M").DelMin().DelMax()
+	require.True(t, Check(deleted))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, deleted.Keys())
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, st.Keys())
+	require.True(t, Check(st))
+	require.Same(t, deleted, deleted.Del("M"))
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	n := 2000
+
+	st := NewRedBlackBST()
+	model := make(map[string]int)
+	var versions []*RedBlackBST
+	var models []map[string]int
+
+	for i := 0; i < n; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(n/2))
+		if r.Intn(3) == 0 {
+			st = st.Del(key)
+			delete(model, key)
+		} else {
+			st = st.Put(key, i)
+			model[key] = i
+		}
+
+		if i%100 == 0 {
+			snapshot := make(map[string]int, len(model))
+			for k, v := range model {
+				snapshot[k] = v
+			}
+			versions = append(versions, st)
+			models = append(models, snapshot)
+		}
+	}
+
+	// every older version still matches the state it had when it was taken
+	for i, version := range versions {
+		require.True(t, Check(version))
+		require.Equal(t, len(models[i]), version.Size())
+		for key, value := range models[i] {
+			v, found := version.Get(key)
+			require.True(t, found)
+			require.Equal(t, value, v)
+		}
+	}
+}
+
+func TestCase3(t *testing.T) {
+	st := NewRedBlackBST()
+	for i := 0; i < 1000; i++ {
+		st = st.Put(fmt.Sprintf("%04d", i), i)
+	}
+
+	// readers on a version race with a writer deriving new versions from it
+	wg := sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				value, found := st.Get(fmt.Sprintf("%04d", i))
+				require.True(t, found)
+				require.Equal(t, i, value)
+			}
+		}()
+	}
+
+	next := st
+	for i := 0; i < 1000; i += 2 {
+		next = next.Del(fmt.Sprintf("%04d", i)).Put(fmt.Sprintf("%04d", i+1), -i)
+	}
+	wg.Wait()
+
+	require.Equal(t, 1000, st.Size())
+	require.Equal(t, 500, next.Size())
+	require.True(t, Check(next))
+}
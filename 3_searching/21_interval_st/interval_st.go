@@ -0,0 +1,438 @@
+package interval_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"fmt"
+	"log"
+)
+
+// An interval search tree: a left-leaning red-black BST keyed by closed intervals of ints,
+// ordered by left endpoint and then by right endpoint. 05_red_black_bst is keyed by strings,
+// so the tree keeps its own copy of the balancing code. Each node keeps the largest right
+// endpoint in its subtree, the aggregate of util.MaxMonoid over right endpoints. A subtree
+// whose largest right endpoint is less than the left endpoint of a query cannot hold an
+// overlapping interval, so Overlapping reports the k intervals that overlap a query in
+// O(k log n).
+
+const (
+	RED   = true
+	BLACK = false
+)
+
+// Interval
+// The closed interval [Lo, Hi].
+type Interval struct {
+	Lo, Hi int
+}
+
+// NewInterval
+// Returns the interval [lo, hi].
+func NewInterval(lo, hi int) Interval {
+	if lo > hi {
+		log.Fatalf("illegal interval [%d, %d]\n", lo, hi)
+	}
+	return Interval{lo, hi}
+}
+
+// Intersects
+// Does this interval overlap that one?
+func (i Interval) Intersects(that Interval) bool {
+	return i.Lo <= that.Hi && that.Lo <= i.Hi
+}
+
+// Contains
+// Does this interval contain the point x?
+func (i Interval) Contains(x int) bool {
+	return i.Lo <= x && x <= i.Hi
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%d, %d]", i.Lo, i.Hi)
+}
+
+func (i Interval) less(that Interval) bool {
+	return i.Lo < that.Lo || i.Lo == that.Lo && i.Hi < that.Hi
+}
+
+type IntervalST struct {
+	root *node
+}
+
+// NewIntervalST
+// Initializes an empty interval symbol table.
+func NewIntervalST() *IntervalST {
+	return &IntervalST{}
+}
+
+type node struct {
+	key         Interval
+	value, size int
+	max         int  // largest right endpoint in the subtree
+	color       bool // color of parent link
+	left, right *node
+}
+
+func (h *node) isRed() bool {
+	return h != nil && h.color == RED
+}
+
+func size(x *node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+// max right endpoint of the subtree rooted at x
+func maxHi(x *node) int {
+	if x == nil {
+		return util.MaxMonoid.Identity
+	}
+	return x.max
+}
+
+// recompute the size and max right endpoint of h from its children
+func (h *node) update() {
+	h.size = 1 + size(h.left) + size(h.right)
+	h.max = util.MaxMonoid.Combine(h.key.Hi, util.MaxMonoid.Combine(maxHi(h.left), maxHi(h.right)))
+}
+
+// IsEmpty
+// Is this symbol table empty?
+func (st *IntervalST) IsEmpty() bool {
+	return st.root == nil
+}
+
+// Size
+// Returns the number of intervals in this symbol table.
+func (st *IntervalST) Size() int {
+	return size(st.root)
+}
+
+// Get
+// Returns the value associated with the interval.
+func (st *IntervalST) Get(interval Interval) (int, bool) {
+	for x := st.root; x != nil; {
+		if interval.less(x.key) {
+			x = x.left
+		} else if x.key.less(interval) {
+			x = x.right
+		} else {
+			return x.value, true
+		}
+	}
+	return 0, false
+}
+
+// Contains
+// Does this symbol table contain the interval?
+func (st *IntervalST) Contains(interval Interval) bool {
+	_, found := st.Get(interval)
+	return found
+}
+
+// Put
+// Inserts the interval with the value, overwriting the old value
+// if the symbol table already contains the interval.
+func (st *IntervalST) Put(interval Interval, value int) {
+	st.root = put(st.root, interval, value)
+	st.root.color = BLACK
+}
+
+func put(h *node, key Interval, value int) *node {
+	if h == nil {
+		return &node{key: key, value: value, size: 1, max: key.Hi, color: RED}
+	}
+
+	if key.less(h.key) {
+		h.left = put(h.left, key, value)
+	} else if h.key.less(key) {
+		h.right = put(h.right, key, value)
+	} else {
+		h.value = value
+	}
+
+	return balance(h)
+}
+
+// Del
+// Removes the interval and its value from the symbol table (if the interval is in the symbol table).
+func (st *IntervalST) Del(interval Interval) {
+	if !st.Contains(interval) {
+		return
+	}
+
+	// if both children of root are black, set root to red
+	if !st.root.left.isRed() && !st.root.right.isRed() {
+		st.root.color = RED
+	}
+
+	st.root = del(st.root, interval)
+	if !st.IsEmpty() {
+		st.root.color = BLACK
+	}
+}
+
+func del(h *node, key Interval) *node {
+	if key.less(h.key) {
+		if !h.left.isRed() && !h.left.left.isRed() {
+			h = moveRedLeft(h)
+		}
+		h.left = del(h.left, key)
+	} else {
+		if h.left.isRed() {
+			h = rotateRight(h)
+		}
+
+		if key == h.key && h.right == nil {
+			return nil
+		}
+
+		if !h.right.isRed() && !h.right.left.isRed() {
+			h = moveRedRight(h)
+		}
+
+		if key == h.key {
+			x := h.right
+			for x.left != nil {
+				x = x.left
+			}
+			h.key, h.value = x.key, x.value
+			h.right = delMin(h.right)
+		} else {
+			h.right = del(h.right, key)
+		}
+	}
+
+	return balance(h)
+}
+
+func delMin(h *node) *node {
+	if h.left == nil {
+		return nil
+	}
+
+	if !h.left.isRed() && !h.left.left.isRed() {
+		h = moveRedLeft(h)
+	}
+
+	h.left = delMin(h.left)
+	return balance(h)
+}
+
+func rotateRight(h *node) *node {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.color = h.color
+	h.color = RED
+	h.update()
+	x.update()
+	return x
+}
+
+func rotateLeft(h *node) *node {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.color = h.color
+	h.color = RED
+	h.update()
+	x.update()
+	return x
+}
+
+func flipColors(h *node) {
+	h.color = !h.color
+	h.left.color = !h.left.color
+	h.right.color = !h.right.color
+}
+
+func moveRedLeft(h *node) *node {
+	flipColors(h)
+	if h.right.left.isRed() {
+		h.right = rotateRight(h.right)
+		h = rotateLeft(h)
+		flipColors(h)
+	}
+	return h
+}
+
+func moveRedRight(h *node) *node {
+	flipColors(h)
+	if h.left.left.isRed() {
+		h = rotateRight(h)
+		flipColors(h)
+	}
+	return h
+}
+
+// restore red-black tree invariant
+func balance(h *node) *node {
+	if h.right.isRed() && !h.left.isRed() {
+		h = rotateLeft(h)
+	}
+
+	if h.left.isRed() && h.left.left.isRed() {
+		h = rotateRight(h)
+	}
+
+	if h.left.isRed() && h.right.isRed() {
+		flipColors(h)
+	}
+	h.update()
+
+	return h
+}
+
+// Intervals
+// Returns all intervals in the symbol table in order.
+func (st *IntervalST) Intervals() []Interval {
+	intervals := make([]Interval, 0, st.Size())
+	var inorder func(x *node)
+	inorder = func(x *node) {
+		if x == nil {
+			return
+		}
+		inorder(x.left)
+		intervals = append(intervals, x.key)
+		inorder(x.right)
+	}
+	inorder(st.root)
+	return intervals
+}
+
+// Search
+// Returns an interval in the symbol table that overlaps the query, in O(log n).
+func (st *IntervalST) Search(query Interval) (Interval, bool) {
+	x := st.root
+	for x != nil {
+		if x.key.Intersects(query) {
+			return x.key, true
+		}
+
+		// if the left subtree reaches the query and has no overlap,
+		// every interval on the right starts after the query ends
+		if x.left != nil && x.left.max >= query.Lo {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	return Interval{}, false
+}
+
+// Overlapping
+// Returns all intervals in the symbol table that overlap the query, in order.
+func (st *IntervalST) Overlapping(query Interval) []Interval {
+	intervals := make([]Interval, 0)
+	overlapping(st.root, query, &intervals)
+	return intervals
+}
+
+func overlapping(x *node, query Interval, intervals *[]Interval) {
+	// nothing in the subtree ends at or after the start of the query
+	if x == nil || x.max < query.Lo {
+		return
+	}
+
+	overlapping(x.left, query, intervals)
+
+	// x and everything to its right starts after the query ends
+	if x.key.Lo > query.Hi {
+		return
+	}
+	if x.key.Intersects(query) {
+		*intervals = append(*intervals, x.key)
+	}
+
+	overlapping(x.right, query, intervals)
+}
+
+// Stabbing
+// Returns all intervals in the symbol table that contain the point, in order.
+func (st *IntervalST) Stabbing(x int) []Interval {
+	return st.Overlapping(Interval{x, x})
+}
+
+// Check
+// integrity check of the interval search tree
+func Check(st *IntervalST) bool {
+	if !isBST(st.root, nil, nil) {
+		fmt.Println("Not in symmetric order")
+	}
+
+	if !isAugmentConsistent(st.root) {
+		fmt.Println("Subtree counts or max endpoints not consistent")
+	}
+
+	if !is23(st.root, st.root) {
+		fmt.Println("Not a 2-3 tree")
+	}
+
+	if !isBalanced(st.root) {
+		fmt.Println("Not balanced")
+	}
+
+	return isBST(st.root, nil, nil) && isAugmentConsistent(st.root) && is23(st.root, st.root) && isBalanced(st.root)
+}
+
+// is the tree rooted at x a BST with all keys strictly between min and max (nil for no bound)?
+func isBST(x *node, min, max *Interval) bool {
+	if x == nil {
+		return true
+	}
+	if min != nil && !min.less(x.key) {
+		return false
+	}
+	if max != nil && !x.key.less(*max) {
+		return false
+	}
+	return isBST(x.left, min, &x.key) && isBST(x.right, &x.key, max)
+}
+
+func isAugmentConsistent(x *node) bool {
+	if x == nil {
+		return true
+	}
+	if x.size != 1+size(x.left)+size(x.right) || x.max != util.Max(x.key.Hi, maxHi(x.left), maxHi(x.right)) {
+		return false
+	}
+	return isAugmentConsistent(x.left) && isAugmentConsistent(x.right)
+}
+
+// does the tree have no red right links, and at most one (left) red link in a row on any path?
+func is23(x, root *node) bool {
+	if x == nil {
+		return true
+	}
+	if x.right.isRed() {
+		return false
+	}
+	if x != root && x.isRed() && x.left.isRed() {
+		return false
+	}
+	return is23(x.left, root) && is23(x.right, root)
+}
+
+// do all paths from root to leaf have same number of black edges?
+func isBalanced(root *node) bool {
+	black := 0
+	for x := root; x != nil; x = x.left {
+		if !x.isRed() {
+			black++
+		}
+	}
+	return isBalancedBlack(root, black)
+}
+
+func isBalancedBlack(x *node, black int) bool {
+	if x == nil {
+		return black == 0
+	}
+	if !x.isRed() {
+		black--
+	}
+	return isBalancedBlack(x.left, black) && isBalancedBlack(x.right, black)
+}
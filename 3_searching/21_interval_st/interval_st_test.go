@@ -0,0 +1,70 @@
+package interval_st
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	st := NewIntervalST()
+	intervals := []Interval{
+		NewInterval(17, 19), NewInterval(5, 8), NewInterval(21, 24),
+		NewInterval(4, 8), NewInterval(15, 18), NewInterval(7, 10),
+	}
+	for i, interval := range intervals {
+		st.Put(interval, i)
+	}
+	require.True(t, Check(st))
+	require.Equal(t, 6, st.Size())
+
+	require.Equal(t, []Interval{{4, 8}, {5, 8}, {7, 10}}, st.Overlapping(NewInterval(6, 7)))
+	require.Equal(t, []Interval{{15, 18}, {17, 19}, {21, 24}}, st.Overlapping(NewInterval(18, 23)))
+	require.Equal(t, []Interval{{17, 19}}, st.Stabbing(19))
+	require.Empty(t, st.Overlapping(NewInterval(11, 14)))
+
+	interval, found := st.Search(NewInterval(23, 25))
+	require.True(t, found)
+	require.Equal(t, NewInterval(21, 24), interval)
+	_, found = st.Search(NewInterval(25, 30))
+	require.False(t, found)
+
+	st.Del(NewInterval(5, 8))
+	require.True(t, Check(st))
+	require.Equal(t, []Interval{{4, 8}, {7, 10}}, st.Overlapping(NewInterval(6, 7)))
+	value, _ := st.Get(NewInterval(7, 10))
+	require.Equal(t, 5, value)
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := NewIntervalST()
+	for i := 0; i < 2000; i++ {
+		lo := r.Intn(1000)
+		interval := NewInterval(lo, lo+r.Intn(50))
+		if r.Intn(3) == 0 {
+			st.Del(interval)
+		} else {
+			st.Put(interval, i)
+		}
+	}
+	require.True(t, Check(st))
+
+	all := st.Intervals()
+	for i := 0; i < 200; i++ {
+		lo := r.Intn(1100)
+		query := NewInterval(lo, lo+r.Intn(20))
+
+		expected := make([]Interval, 0)
+		for _, interval := range all {
+			if interval.Intersects(query) {
+				expected = append(expected, interval)
+			}
+		}
+		require.Equal(t, expected, st.Overlapping(query))
+
+		_, found := st.Search(query)
+		require.Equal(t, len(expected) > 0, found)
+	}
+}
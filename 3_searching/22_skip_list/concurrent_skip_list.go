@@ -0,0 +1,375 @@
+package skip_list
+
+import (
+	"log"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentSkipList
+// A lazy skip list (Herlihy, Lev, Luchangco and Shavit) that is safe for concurrent use.
+// Put and Del lock only the predecessors of the affected node on each of its levels and
+// validate them after locking, retrying if a concurrent update got in between; Get,
+// Contains and the ordered queries take no locks at all. A node is logically removed
+// by marking it before it is unlinked, and is only visible once it is linked on every
+// level, so Get is linearizable. Scans such as KeysBetween are weakly consistent: they
+// see every key present for the whole scan and may or may not see concurrent updates.
+// Span counts cannot be kept without a global lock, so Rank and Select are not provided.
+type ConcurrentSkipList struct {
+	head     *cnode
+	maxLevel int
+	n        atomic.Int64
+
+	mu   sync.Mutex // guards rand
+	rand *rand.Rand
+}
+
+type cnode struct {
+	key      string
+	value    atomic.Int64
+	next     []atomic.Pointer[cnode]
+	topLevel int
+
+	mu          sync.Mutex
+	marked      atomic.Bool // logically removed
+	fullyLinked atomic.Bool // linked on every level
+}
+
+// NewConcurrentSkipList
+// Initializes an empty symbol table that is safe for concurrent use.
+func NewConcurrentSkipList(opts ...Option) *ConcurrentSkipList {
+	o := newOptions(opts)
+	return &ConcurrentSkipList{
+		head:     newCNode("", 0, o.maxLevel),
+		maxLevel: o.maxLevel,
+		rand:     rand.New(rand.NewSource(o.seed)),
+	}
+}
+
+func newCNode(key string, value, level int) *cnode {
+	x := &cnode{
+		key:      key,
+		next:     make([]atomic.Pointer[cnode], level),
+		topLevel: level,
+	}
+	x.value.Store(int64(value))
+	return x
+}
+
+func (sl *ConcurrentSkipList) randomLevel() int {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return randomLevel(sl.rand, sl.maxLevel)
+}
+
+// IsEmpty
+// Is this symbol table empty?
+func (sl *ConcurrentSkipList) IsEmpty() bool {
+	return sl.Size() == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (sl *ConcurrentSkipList) Size() int {
+	return int(sl.n.Load())
+}
+
+// fill preds and succs with the nodes around key on each level; returns the highest
+// level on which a node with the key was found, or -1
+func (sl *ConcurrentSkipList) find(key string, preds, succs []*cnode) int {
+	found := -1
+	pred := sl.head
+	for level := sl.maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && curr.key < key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found == -1 && curr != nil && curr.key == key {
+			found = level
+		}
+		preds[level], succs[level] = pred, curr
+	}
+	return found
+}
+
+// Get
+// Returns the value associated with the given key.
+func (sl *ConcurrentSkipList) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	x := sl.head
+	for level := sl.maxLevel - 1; level >= 0; level-- {
+		for next := x.next[level].Load(); next != nil && next.key <= key; next = x.next[level].Load() {
+			x = next
+		}
+		if x != sl.head && x.key == key {
+			break
+		}
+	}
+
+	if x == sl.head || x.key != key || !x.fullyLinked.Load() || x.marked.Load() {
+		return 0, false
+	}
+	return int(x.value.Load()), true
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (sl *ConcurrentSkipList) Contains(key string) bool {
+	_, found := sl.Get(key)
+	return found
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (sl *ConcurrentSkipList) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("first argument to put() is null")
+	}
+
+	topLevel := sl.randomLevel()
+	preds := make([]*cnode, sl.maxLevel)
+	succs := make([]*cnode, sl.maxLevel)
+	for {
+		if found := sl.find(key, preds, succs); found != -1 {
+			x := succs[found]
+			if !x.marked.Load() {
+				// wait for a concurrent insert of the key to finish linking
+				for !x.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				x.value.Store(int64(value))
+				return
+			}
+			// a concurrent Del is unlinking the key; try again once it is gone
+			continue
+		}
+
+		// lock the predecessors bottom up and check that they are unchanged
+		highestLocked := -1
+		var prevPred *cnode
+		valid := true
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				highestLocked = level
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[level].Load() == succ
+		}
+
+		if valid {
+			x := newCNode(key, value, topLevel)
+			for level := 0; level < topLevel; level++ {
+				x.next[level].Store(succs[level])
+			}
+			for level := 0; level < topLevel; level++ {
+				preds[level].next[level].Store(x)
+			}
+			x.fullyLinked.Store(true)
+			sl.n.Add(1)
+		}
+
+		unlock(preds, highestLocked)
+		if valid {
+			return
+		}
+	}
+}
+
+// unlock the distinct predecessors on levels 0 through highest
+func unlock(preds []*cnode, highest int) {
+	var prevPred *cnode
+	for level := 0; level <= highest; level++ {
+		if preds[level] != prevPred {
+			preds[level].mu.Unlock()
+			prevPred = preds[level]
+		}
+	}
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (sl *ConcurrentSkipList) Del(key string) {
+	if key == "" {
+		log.Fatalln("calls delete() with a null key")
+	}
+
+	var victim *cnode
+	isMarked := false
+	preds := make([]*cnode, sl.maxLevel)
+	succs := make([]*cnode, sl.maxLevel)
+	for {
+		found := sl.find(key, preds, succs)
+		if !isMarked {
+			// only a fully linked node found on its top level can be removed
+			if found == -1 {
+				return
+			}
+			victim = succs[found]
+			if !victim.fullyLinked.Load() || victim.topLevel-1 != found || victim.marked.Load() {
+				return
+			}
+
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				victim.mu.Unlock()
+				return
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked := -1
+		var prevPred *cnode
+		valid := true
+		for level := 0; valid && level < victim.topLevel; level++ {
+			pred := preds[level]
+			if pred != prevPred {
+				pred.mu.Lock()
+				highestLocked = level
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == victim
+		}
+
+		if valid {
+			for level := victim.topLevel - 1; level >= 0; level-- {
+				preds[level].next[level].Store(victim.next[level].Load())
+			}
+			victim.mu.Unlock()
+			sl.n.Add(-1)
+		}
+
+		unlock(preds, highestLocked)
+		if valid {
+			return
+		}
+	}
+}
+
+// is x a key of the symbol table, not just being inserted or removed?
+func (x *cnode) live() bool {
+	return x.fullyLinked.Load() && !x.marked.Load()
+}
+
+// the first live node on the bottom level with a key greater than or equal to key
+func (sl *ConcurrentSkipList) ceiling(key string) *cnode {
+	x := sl.head
+	for level := sl.maxLevel - 1; level >= 0; level-- {
+		for next := x.next[level].Load(); next != nil && next.key < key; next = x.next[level].Load() {
+			x = next
+		}
+	}
+
+	x = x.next[0].Load()
+	for x != nil && !x.live() {
+		x = x.next[0].Load()
+	}
+	return x
+}
+
+// the last live node with a key less than key, or less than or equal to it if inclusive;
+// there are no back links, so a dead candidate restarts the search below its key
+func (sl *ConcurrentSkipList) floor(key string, inclusive bool) *cnode {
+	for {
+		x := sl.head
+		for level := sl.maxLevel - 1; level >= 0; level-- {
+			for next := x.next[level].Load(); next != nil && (next.key < key || inclusive && next.key == key); next = x.next[level].Load() {
+				x = next
+			}
+		}
+
+		if x == sl.head || x.live() {
+			return x
+		}
+		key, inclusive = x.key, false
+	}
+}
+
+// Min
+// Returns the smallest key in the symbol table, or "" if it is empty.
+func (sl *ConcurrentSkipList) Min() string {
+	for x := sl.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+		if x.live() {
+			return x.key
+		}
+	}
+	return ""
+}
+
+// Max
+// Returns the largest key in the symbol table, or "" if it is empty.
+func (sl *ConcurrentSkipList) Max() string {
+	x := sl.head
+	for level := sl.maxLevel - 1; level >= 0; level-- {
+		for next := x.next[level].Load(); next != nil; next = x.next[level].Load() {
+			x = next
+		}
+	}
+	if x == sl.head || x.live() {
+		return x.key
+	}
+	return sl.floor(x.key, false).key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (sl *ConcurrentSkipList) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+	return sl.floor(key, true).key
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (sl *ConcurrentSkipList) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	if x := sl.ceiling(key); x != nil {
+		return x.key
+	}
+	return ""
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (sl *ConcurrentSkipList) Keys() []string {
+	keys := make([]string, 0, sl.Size())
+	for x := sl.head.next[0].Load(); x != nil; x = x.next[0].Load() {
+		if x.live() {
+			keys = append(keys, x.key)
+		}
+	}
+	return keys
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (sl *ConcurrentSkipList) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	for x := sl.ceiling(lo); x != nil && x.key <= hi; x = x.next[0].Load() {
+		if x.live() {
+			keys = append(keys, x.key)
+		}
+	}
+	return keys
+}
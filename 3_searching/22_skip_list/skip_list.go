@@ -0,0 +1,449 @@
+package skip_list
+
+import (
+	"fmt"
+	"iter"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// A skip list is a sorted linked list with express lanes: every node is on level 0, and each
+// node that is on level i is also on level i+1 with probability 1/2, so a search starting at the
+// top level skips about half the remaining nodes per level and takes ~2 lg n compares on average.
+// Balance comes from the coin flips rather than from rotations, which is what makes the concurrent
+// version in concurrent_skip_list.go practical.
+// Every link also records its span, the number of level-0 nodes it skips, as in an indexable
+// skip list, so that Rank and Select take logarithmic expected time.
+
+const defaultMaxLevel = 32
+
+type SkipList struct {
+	head     *node // sentinel before the smallest key, on every level
+	level    int   // number of levels in use
+	n        int   // number of key-value pairs
+	maxLevel int
+	rand     *rand.Rand
+}
+
+type node struct {
+	key   string
+	value int
+	next  []*node
+	span  []int // number of positions link i advances; a nil link spans to one past the end
+}
+
+// Option
+// Configures a SkipList or a ConcurrentSkipList at construction.
+type Option func(*options)
+
+type options struct {
+	seed     int64
+	maxLevel int
+}
+
+// WithSeed
+// Seeds the coin flips that choose the levels of the nodes, so that the shape of
+// the list, and hence the number of compares, is reproducible.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithMaxLevel
+// Caps the number of levels; lg n levels suffice for n keys.
+func WithMaxLevel(level int) Option {
+	return func(o *options) {
+		if level < 1 {
+			log.Fatalln("max level must be at least 1")
+		}
+		o.maxLevel = level
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{seed: time.Now().UnixNano(), maxLevel: defaultMaxLevel}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewSkipList
+// Initializes an empty symbol table.
+func NewSkipList(opts ...Option) *SkipList {
+	o := newOptions(opts)
+	return &SkipList{
+		head:     newNode("", 0, o.maxLevel),
+		maxLevel: o.maxLevel,
+		rand:     rand.New(rand.NewSource(o.seed)),
+	}
+}
+
+func newNode(key string, value, level int) *node {
+	return &node{
+		key:   key,
+		value: value,
+		next:  make([]*node, level),
+		span:  make([]int, level),
+	}
+}
+
+// number of levels of a new node: 1 plus the number of heads in a row
+func randomLevel(r *rand.Rand, maxLevel int) int {
+	level := 1
+	for level < maxLevel && r.Int63()&1 == 1 {
+		level++
+	}
+	return level
+}
+
+// IsEmpty
+// Is this symbol table empty?
+func (sl *SkipList) IsEmpty() bool {
+	return sl.n == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (sl *SkipList) Size() int {
+	return sl.n
+}
+
+// Level
+// Returns the number of levels in use (for debugging).
+func (sl *SkipList) Level() int {
+	return sl.level
+}
+
+// the last node on the bottom level with a key less than key
+func (sl *SkipList) before(key string) *node {
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+	}
+	return x
+}
+
+// the last node on the bottom level with a key less than or equal to key
+func (sl *SkipList) atOrBefore(key string) *node {
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key <= key {
+			x = x.next[i]
+		}
+	}
+	return x
+}
+
+// Get
+// Returns the value associated with the given key.
+func (sl *SkipList) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	if x := sl.before(key).next[0]; x != nil && x.key == key {
+		return x.value, true
+	}
+	return 0, false
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (sl *SkipList) Contains(key string) bool {
+	_, found := sl.Get(key)
+	return found
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (sl *SkipList) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("first argument to put() is null")
+	}
+
+	// the last node before key on each level, and its position
+	update := make([]*node, sl.maxLevel)
+	rank := make([]int, sl.maxLevel)
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.next[i] != nil && x.next[i].key < key {
+			rank[i] += x.span[i]
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	if x = x.next[0]; x != nil && x.key == key {
+		x.value = value
+		return
+	}
+
+	level := randomLevel(sl.rand, sl.maxLevel)
+	for i := sl.level; i < level; i++ {
+		update[i] = sl.head
+		sl.head.span[i] = sl.n + 1
+	}
+	if level > sl.level {
+		sl.level = level
+	}
+
+	x = newNode(key, value, level)
+	for i := 0; i < level; i++ {
+		x.next[i] = update[i].next[i]
+		update[i].next[i] = x
+
+		// the new node is at position rank[0]+1
+		x.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+
+	// higher links now jump over one more node
+	for i := level; i < sl.level; i++ {
+		update[i].span[i]++
+	}
+	sl.n++
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (sl *SkipList) Del(key string) {
+	if key == "" {
+		log.Fatalln("calls delete() with a null key")
+	}
+
+	update := make([]*node, sl.level)
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	if x = x.next[0]; x == nil || x.key != key {
+		return
+	}
+
+	for i := 0; i < sl.level; i++ {
+		if update[i].next[i] == x {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+
+	for sl.level > 0 && sl.head.next[sl.level-1] == nil {
+		sl.level--
+	}
+	sl.n--
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (sl *SkipList) DelMin() {
+	sl.Del(sl.Min())
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (sl *SkipList) DelMax() {
+	sl.Del(sl.Max())
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (sl *SkipList) Min() string {
+	if sl.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+	return sl.head.next[0].key
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (sl *SkipList) Max() string {
+	if sl.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	return x.key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (sl *SkipList) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+	return sl.atOrBefore(key).key
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (sl *SkipList) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	if x := sl.before(key).next[0]; x != nil {
+		return x.key
+	}
+	return ""
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (sl *SkipList) Rank(key string) int {
+	if key == "" {
+		log.Fatalln("argument to rank() is null")
+	}
+
+	rank := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && x.next[i].key < key {
+			rank += x.span[i]
+			x = x.next[i]
+		}
+	}
+	return rank
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (sl *SkipList) Select(rank int) string {
+	if rank < 0 || rank >= sl.Size() {
+		log.Fatalf("argument to select() is invalid: %d\n", rank)
+	}
+
+	// the key of rank r is at position r+1
+	position := 0
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && position+x.span[i] <= rank+1 {
+			position += x.span[i]
+			x = x.next[i]
+		}
+		if position == rank+1 {
+			break
+		}
+	}
+	return x.key
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (sl *SkipList) Keys() []string {
+	keys := make([]string, 0, sl.n)
+	for x := sl.head.next[0]; x != nil; x = x.next[0] {
+		keys = append(keys, x.key)
+	}
+	return keys
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (sl *SkipList) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	for key := range sl.Range(lo, hi) {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (sl *SkipList) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if sl.Contains(hi) {
+		return sl.Rank(hi) - sl.Rank(lo) + 1
+	}
+	return sl.Rank(hi) - sl.Rank(lo)
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order.
+func (sl *SkipList) All() iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		for x := sl.head.next[0]; x != nil && yield(x.key, x.value); x = x.next[0] {
+		}
+	}
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+func (sl *SkipList) Range(lo, hi string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		for x := sl.before(lo).next[0]; x != nil && x.key <= hi && yield(x.key, x.value); x = x.next[0] {
+		}
+	}
+}
+
+// Check
+// integrity check of the skip list: every level is sorted, every node on a level
+// is also on the level below, and the spans match the positions
+func Check(sl *SkipList) bool {
+	position := make(map[*node]int, sl.n)
+	i := 0
+	for x := sl.head.next[0]; x != nil; x = x.next[0] {
+		i++
+		position[x] = i
+	}
+	if i != sl.n {
+		fmt.Println("Size not consistent")
+		return false
+	}
+
+	for level := 0; level < sl.level; level++ {
+		for x := sl.head; x != nil; x = x.next[level] {
+			next := x.next[level]
+			if next != nil && x != sl.head && x.key >= next.key {
+				fmt.Println("Not in order")
+				return false
+			}
+
+			end := sl.n + 1
+			if next != nil {
+				end = position[next]
+			}
+			if x.span[level] != end-position[x] {
+				fmt.Println("Spans not consistent")
+				return false
+			}
+		}
+	}
+
+	for level := sl.level; level < sl.maxLevel; level++ {
+		if sl.head.next[level] != nil {
+			fmt.Println("Level not consistent")
+			return false
+		}
+	}
+	return true
+}
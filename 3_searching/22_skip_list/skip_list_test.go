@@ -0,0 +1,143 @@
+package skip_list
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	sl := NewSkipList(WithSeed(1))
+	for i := 0; i < len(keys); i++ {
+		sl.Put(keys[i], i)
+	}
+	require.True(t, Check(sl))
+
+	// the same seed builds the same list
+	other := NewSkipList(WithSeed(1))
+	for i := 0; i < len(keys); i++ {
+		other.Put(keys[i], i)
+	}
+	for x, y := sl.head, other.head; x != nil || y != nil; x, y = x.next[0], y.next[0] {
+		require.Equal(t, len(x.next), len(y.next))
+	}
+
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, sl.Keys())
+	value, _ := sl.Get("E")
+	require.Equal(t, 12, value)
+	require.Equal(t, "A", sl.Min())
+	require.Equal(t, "X", sl.Max())
+	require.Equal(t, "H", sl.Floor("I"))
+	require.Equal(t, "L", sl.Ceiling("I"))
+	require.Equal(t, "", sl.Floor("0"))
+	require.Equal(t, "", sl.Ceiling("Y"))
+	require.Equal(t, 4, sl.Rank("I"))
+	require.Equal(t, "L", sl.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, sl.KeysBetween("F", "N"))
+	require.Equal(t, 3, sl.SizeBetween("F", "N"))
+
+	sl.Del("M")
+	sl.DelMin()
+	sl.DelMax()
+	require.True(t, Check(sl))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, sl.Keys())
+
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sl := NewSkipList(WithSeed(2))
+	values := make(map[string]int)
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1000))
+		if r.Intn(3) == 0 {
+			sl.Del(key)
+			delete(values, key)
+		} else {
+			sl.Put(key, i)
+			values[key] = i
+		}
+	}
+	require.True(t, Check(sl))
+	require.Equal(t, len(values), sl.Size())
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		v, found := sl.Get(key)
+		require.True(t, found)
+		require.Equal(t, value, v)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	require.Equal(t, keys, sl.Keys())
+	for i, key := range keys {
+		require.Equal(t, i, sl.Rank(key))
+		require.Equal(t, key, sl.Select(i))
+	}
+
+	for !sl.IsEmpty() {
+		sl.DelMax()
+	}
+	require.True(t, Check(sl))
+	require.Equal(t, 0, sl.Level())
+}
+
+func TestCase3(t *testing.T) {
+	sl := NewConcurrentSkipList(WithSeed(1))
+	goroutines, n := 8, 2000
+
+	// each goroutine inserts its own keys and fights over a shared range
+	wg := sync.WaitGroup{}
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(g)))
+			for i := 0; i < n; i++ {
+				sl.Put(fmt.Sprintf("own-%d-%04d", g, i), i)
+
+				shared := fmt.Sprintf("shared-%03d", r.Intn(100))
+				if r.Intn(2) == 0 {
+					sl.Put(shared, i)
+				} else {
+					sl.Del(shared)
+				}
+				sl.Floor(shared)
+				sl.Ceiling(shared)
+			}
+			for i := 0; i < n; i += 2 {
+				sl.Del(fmt.Sprintf("own-%d-%04d", g, i))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	keys := sl.Keys()
+	require.True(t, sort.StringsAreSorted(keys))
+	require.Equal(t, len(keys), sl.Size())
+
+	own := sl.KeysBetween("own-", "own-~")
+	require.Len(t, own, goroutines*n/2)
+	for _, key := range own {
+		var g, i int
+		_, err := fmt.Sscanf(key, "own-%d-%d", &g, &i)
+		require.NoError(t, err)
+		require.Equal(t, 1, i%2)
+		value, found := sl.Get(key)
+		require.True(t, found)
+		require.Equal(t, i, value)
+	}
+
+	require.Equal(t, keys[0], sl.Min())
+	require.Equal(t, keys[len(keys)-1], sl.Max())
+	require.Equal(t, "own-0-0001", sl.Ceiling("own-0-0000"))
+	require.Equal(t, "own-0-0001", sl.Floor("own-0-0002"))
+}
@@ -0,0 +1,512 @@
+package treap
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// A treap is a BST on the keys that is at the same time a heap on random priorities drawn when
+// the keys are inserted: every node has a priority no larger than its parent's. The shape is
+// therefore the shape of the BST built by inserting the keys in decreasing order of priority,
+// that is, in random order, so by Proposition C of 03_BST every operation takes O(log n) expected
+// time whatever the order of the operations. Because the shape depends only on the priorities,
+// cutting a treap at a key (Split) and gluing two treaps whose keys do not interleave (Join)
+// are single walks down a spine.
+
+type Treap struct {
+	root *Node
+	rand *rand.Rand
+}
+
+// Node
+// Treap helper node data type
+type Node struct {
+	Key string // sorted by key
+	// Value associated data
+	// size number of nodes in subtree
+	Value, size int
+	priority    int64 // heap ordered, larger on top
+
+	Left, Right *Node // left and right subtrees
+}
+
+// Option
+// Configures a Treap at construction.
+type Option func(*options)
+
+type options struct {
+	seed int64
+}
+
+// WithSeed
+// Seeds the priorities, so that the shape of the treap is reproducible.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// NewTreap
+// Initializes an empty symbol table.
+func NewTreap(opts ...Option) *Treap {
+	o := options{seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Treap{rand: rand.New(rand.NewSource(o.seed))}
+}
+
+func (t *Treap) newNode(key string, value int) *Node {
+	return &Node{Key: key, Value: value, size: 1, priority: t.rand.Int63()}
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty.
+func (t *Treap) IsEmpty() bool {
+	return size(t.root) == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (t *Treap) Size() int {
+	return size(t.root)
+}
+
+func size(x *Node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+func (x *Node) update() *Node {
+	x.size = 1 + size(x.Left) + size(x.Right)
+	return x
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (t *Treap) Contains(key string) bool {
+	_, found := t.Get(key)
+	return found
+}
+
+// Get
+// Returns the value associated with the given key.
+func (t *Treap) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	for x := t.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			x = x.Right
+		} else {
+			return x.Value, true
+		}
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (t *Treap) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("calls put() with a null key")
+	}
+
+	t.root = t.put(t.root, key, value)
+}
+
+// insert at the bottom as in 03_BST, then rotate the new node up while it beats its parent
+func (t *Treap) put(x *Node, key string, value int) *Node {
+	if x == nil {
+		return t.newNode(key, value)
+	}
+
+	if key < x.Key {
+		x.Left = t.put(x.Left, key, value)
+		if x.Left.priority > x.priority {
+			x = rotateRight(x)
+		}
+	} else if key > x.Key {
+		x.Right = t.put(x.Right, key, value)
+		if x.Right.priority > x.priority {
+			x = rotateLeft(x)
+		}
+	} else {
+		x.Value = value
+	}
+
+	return x.update()
+}
+
+func rotateRight(h *Node) *Node {
+	x := h.Left
+	h.Left = x.Right
+	x.Right = h.update()
+	return x.update()
+}
+
+func rotateLeft(h *Node) *Node {
+	x := h.Right
+	h.Right = x.Left
+	x.Left = h.update()
+	return x.update()
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (t *Treap) DelMin() {
+	if t.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	t.Del(t.Min())
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (t *Treap) DelMax() {
+	if t.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	t.Del(t.Max())
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (t *Treap) Del(key string) {
+	if key == "" {
+		log.Fatalln("calls delete() with a null key")
+	}
+
+	t.root = del(t.root, key)
+}
+
+// the node is replaced by the join of its subtrees
+func del(x *Node, key string) *Node {
+	if x == nil {
+		return nil
+	}
+
+	if key < x.Key {
+		x.Left = del(x.Left, key)
+	} else if key > x.Key {
+		x.Right = del(x.Right, key)
+	} else {
+		return join(x.Left, x.Right)
+	}
+	return x.update()
+}
+
+// Split
+// Moves the keys greater than or equal to key into a new treap, which it returns,
+// and keeps the smaller keys. Takes O(log n) expected time.
+func (t *Treap) Split(key string) *Treap {
+	if key == "" {
+		log.Fatalln("calls split() with a null key")
+	}
+
+	var right *Node
+	t.root, right = split(t.root, key)
+	return &Treap{root: right, rand: t.rand}
+}
+
+// split the subtree rooted at x into the keys less than key and the rest
+func split(x *Node, key string) (*Node, *Node) {
+	if x == nil {
+		return nil, nil
+	}
+
+	if x.Key < key {
+		var right *Node
+		x.Right, right = split(x.Right, key)
+		return x.update(), right
+	}
+
+	var left *Node
+	left, x.Left = split(x.Left, key)
+	return left, x.update()
+}
+
+// Join
+// Moves all keys of that treap, which must all be greater than the keys of this one,
+// into this treap, leaving that one empty. Takes O(log n) expected time.
+func (t *Treap) Join(that *Treap) {
+	if !t.IsEmpty() && !that.IsEmpty() && t.Max() >= that.Min() {
+		log.Fatalln("calls join() with keys that are not all greater")
+	}
+
+	t.root = join(t.root, that.root)
+	that.root = nil
+}
+
+// join two subtrees with all keys of a less than all keys of b: the root with the larger priority stays on top
+func join(a, b *Node) *Node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if a.priority > b.priority {
+		a.Right = join(a.Right, b)
+		return a.update()
+	}
+	b.Left = join(a, b.Left)
+	return b.update()
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (t *Treap) Min() string {
+	if t.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+
+	x := t.root
+	for x.Left != nil {
+		x = x.Left
+	}
+	return x.Key
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (t *Treap) Max() string {
+	if t.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+
+	x := t.root
+	for x.Right != nil {
+		x = x.Right
+	}
+	return x.Key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (t *Treap) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+
+	floor := ""
+	for x := t.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			floor = x.Key
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+	return floor
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (t *Treap) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	ceiling := ""
+	for x := t.root; x != nil; {
+		if key < x.Key {
+			ceiling = x.Key
+			x = x.Left
+		} else if key > x.Key {
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+	return ceiling
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (t *Treap) Select(rank int) string {
+	if rank < 0 || rank >= t.Size() {
+		log.Fatalf("argument to select() is invalid: %d\n", rank)
+	}
+
+	x := t.root
+	for {
+		leftSize := size(x.Left)
+		if leftSize > rank {
+			x = x.Left
+		} else if leftSize < rank {
+			rank -= leftSize + 1
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (t *Treap) Rank(key string) int {
+	if key == "" {
+		log.Fatalln("argument to rank() is null")
+	}
+
+	rank := 0
+	for x := t.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			rank += 1 + size(x.Left)
+			x = x.Right
+		} else {
+			return rank + size(x.Left)
+		}
+	}
+	return rank
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (t *Treap) Keys() []string {
+	if t.IsEmpty() {
+		return []string{}
+	}
+	return t.KeysBetween(t.Min(), t.Max())
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (t *Treap) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	t.root.keys(&keys, lo, hi)
+	return keys
+}
+
+func (x *Node) keys(keys *[]string, lo, hi string) {
+	if x == nil {
+		return
+	}
+	if lo < x.Key {
+		x.Left.keys(keys, lo, hi)
+	}
+	if lo <= x.Key && hi >= x.Key {
+		*keys = append(*keys, x.Key)
+	}
+	if hi > x.Key {
+		x.Right.keys(keys, lo, hi)
+	}
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (t *Treap) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if t.Contains(hi) {
+		return t.Rank(hi) - t.Rank(lo) + 1
+	}
+	return t.Rank(hi) - t.Rank(lo)
+}
+
+// Height
+// Returns the height of the treap (for debugging).
+func (t *Treap) Height() int {
+	return height(t.root)
+}
+
+func height(x *Node) int {
+	if x == nil {
+		return -1
+	}
+	return 1 + util.Max(height(x.Left), height(x.Right))
+}
+
+// Check
+// the invariants of 03_BST, and heap order on the priorities
+func Check(t *Treap) bool {
+	if !isBST(t.root, "", "") {
+		fmt.Println("Not in symmetric order")
+	}
+
+	if !isSizeConsistent(t.root) {
+		fmt.Println("Subtree counts not consistent")
+	}
+
+	if !t.isRankConsistent() {
+		fmt.Println("Ranks not consistent")
+	}
+
+	if !isHeapOrdered(t.root) {
+		fmt.Println("Priorities not heap ordered")
+	}
+
+	return isBST(t.root, "", "") && isSizeConsistent(t.root) && t.isRankConsistent() && isHeapOrdered(t.root)
+}
+
+func isBST(x *Node, min, max string) bool {
+	if x == nil {
+		return true
+	}
+	if min != "" && x.Key <= min {
+		return false
+	}
+	if max != "" && x.Key >= max {
+		return false
+	}
+	return isBST(x.Left, min, x.Key) && isBST(x.Right, x.Key, max)
+}
+
+func isSizeConsistent(x *Node) bool {
+	if x == nil {
+		return true
+	}
+	if x.size != size(x.Left)+size(x.Right)+1 {
+		return false
+	}
+	return isSizeConsistent(x.Left) && isSizeConsistent(x.Right)
+}
+
+func (t *Treap) isRankConsistent() bool {
+	for i := 0; i < t.Size(); i++ {
+		if i != t.Rank(t.Select(i)) {
+			return false
+		}
+	}
+	for _, key := range t.Keys() {
+		if key != t.Select(t.Rank(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHeapOrdered(x *Node) bool {
+	if x == nil {
+		return true
+	}
+	if (x.Left != nil && x.Left.priority > x.priority) || (x.Right != nil && x.Right.priority > x.priority) {
+		return false
+	}
+	return isHeapOrdered(x.Left) && isHeapOrdered(x.Right)
+}
@@ -0,0 +1,107 @@
+package treap
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	st := NewTreap(WithSeed(1))
+	for i := 0; i < len(keys); i++ {
+		st.Put(keys[i], i)
+	}
+	require.True(t, Check(st))
+
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, st.Keys())
+	value, _ := st.Get("E")
+	require.Equal(t, 12, value)
+	require.Equal(t, "A", st.Min())
+	require.Equal(t, "X", st.Max())
+	require.Equal(t, "H", st.Floor("I"))
+	require.Equal(t, "L", st.Ceiling("I"))
+	require.Equal(t, "", st.Floor("0"))
+	require.Equal(t, "", st.Ceiling("Y"))
+	require.Equal(t, 4, st.Rank("I"))
+	require.Equal(t, "L", st.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, st.KeysBetween("F", "N"))
+	require.Equal(t, 3, st.SizeBetween("F", "N"))
+
+	st.Del("M")
+	st.DelMin()
+	st.DelMax()
+	require.True(t, Check(st))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, st.Keys())
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := NewTreap(WithSeed(1))
+	values := make(map[string]int)
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1000))
+		if r.Intn(3) == 0 {
+			st.Del(key)
+			delete(values, key)
+		} else {
+			st.Put(key, i)
+			values[key] = i
+		}
+	}
+	require.True(t, Check(st))
+	require.Equal(t, len(values), st.Size())
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		v, found := st.Get(key)
+		require.True(t, found)
+		require.Equal(t, value, v)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	require.Equal(t, keys, st.Keys())
+
+	for !st.IsEmpty() {
+		st.DelMin()
+	}
+	require.True(t, Check(st))
+}
+
+func TestCase3(t *testing.T) {
+	st := NewTreap(WithSeed(2))
+	for i := 0; i < 1000; i++ {
+		st.Put(fmt.Sprintf("%04d", i), i)
+	}
+	// sorted inserts still give a logarithmic expected height
+	require.Less(t, st.Height(), 40)
+
+	right := st.Split("0600")
+	require.True(t, Check(st))
+	require.True(t, Check(right))
+	require.Equal(t, 600, st.Size())
+	require.Equal(t, 400, right.Size())
+	require.Equal(t, "0599", st.Max())
+	require.Equal(t, "0600", right.Min())
+
+	right.Del("0700")
+	st.Join(right)
+	require.True(t, Check(st))
+	require.True(t, right.IsEmpty())
+	require.Equal(t, 999, st.Size())
+	require.False(t, st.Contains("0700"))
+
+	empty := st.Split("1000")
+	require.True(t, empty.IsEmpty())
+	require.Equal(t, 999, st.Size())
+
+	all := st.Split("0000")
+	require.True(t, st.IsEmpty())
+	require.Equal(t, 999, all.Size())
+}
@@ -0,0 +1,475 @@
+package splay_tree
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"fmt"
+	"log"
+)
+
+// A splay tree is a BST that moves every key it searches for to the root with a sequence of
+// rotations (zig-zig and zig-zag steps) that also roughly halves the depth of the nodes on the
+// search path. No balance information is kept, and a single operation can take linear time,
+// but any sequence of m operations takes O(m log n) time in total, and keys that are accessed
+// often or close together in time stay near the root: a working set of k hot keys is served in
+// O(log k) amortized time each, which suits skewed access patterns such as cache metadata.
+// Get, Contains, Floor and Ceiling all splay, so reads modify the tree as well.
+
+type SplayTree struct {
+	root *Node // root of the splay tree
+}
+
+// Node
+// Splay tree helper node data type
+type Node struct {
+	Key string // sorted by key
+	// Value associated data
+	// size number of nodes in subtree
+	Value, size int
+
+	Left, Right *Node // left and right subtrees
+}
+
+// NewSplayTree
+// Initializes an empty symbol table.
+func NewSplayTree() *SplayTree {
+	return &SplayTree{}
+}
+
+func newNode(key string, value int) *Node {
+	return &Node{Key: key, Value: value, size: 1}
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty.
+func (st *SplayTree) IsEmpty() bool {
+	return size(st.root) == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (st *SplayTree) Size() int {
+	return size(st.root)
+}
+
+func size(x *Node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+func (x *Node) update() *Node {
+	x.size = 1 + size(x.Left) + size(x.Right)
+	return x
+}
+
+func rotateRight(h *Node) *Node {
+	x := h.Left
+	h.Left = x.Right
+	x.Right = h.update()
+	return x.update()
+}
+
+func rotateLeft(h *Node) *Node {
+	x := h.Right
+	h.Right = x.Left
+	x.Left = h.update()
+	return x.update()
+}
+
+// splay key in the subtree rooted at h: if key is in the subtree, it ends up at the root;
+// if not, the last node on the search path, the floor or the ceiling of key, does
+func splay(h *Node, key string) *Node {
+	if h == nil {
+		return nil
+	}
+
+	if key < h.Key {
+		if h.Left == nil {
+			return h
+		}
+
+		if key < h.Left.Key { // zig-zig
+			h.Left.Left = splay(h.Left.Left, key)
+			h = rotateRight(h)
+		} else if key > h.Left.Key { // zig-zag
+			h.Left.Right = splay(h.Left.Right, key)
+			if h.Left.Right != nil {
+				h.Left = rotateLeft(h.Left)
+			}
+		}
+
+		if h.Left == nil {
+			return h
+		}
+		return rotateRight(h)
+	}
+
+	if key > h.Key {
+		if h.Right == nil {
+			return h
+		}
+
+		if key < h.Right.Key { // zig-zag
+			h.Right.Left = splay(h.Right.Left, key)
+			if h.Right.Left != nil {
+				h.Right = rotateRight(h.Right)
+			}
+		} else if key > h.Right.Key { // zig-zig
+			h.Right.Right = splay(h.Right.Right, key)
+			h = rotateLeft(h)
+		}
+
+		if h.Right == nil {
+			return h
+		}
+		return rotateLeft(h)
+	}
+
+	return h
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (st *SplayTree) Contains(key string) bool {
+	_, found := st.Get(key)
+	return found
+}
+
+// Get
+// Returns the value associated with the given key, after splaying it to the root.
+func (st *SplayTree) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	st.root = splay(st.root, key)
+	if st.root != nil && st.root.Key == key {
+		return st.root.Value, true
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+// The key ends up at the root.
+func (st *SplayTree) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("calls put() with a null key")
+	}
+
+	if st.root == nil {
+		st.root = newNode(key, value)
+		return
+	}
+
+	st.root = splay(st.root, key)
+	if key == st.root.Key {
+		st.root.Value = value
+		return
+	}
+
+	// the root is the floor or the ceiling of key: split around it
+	x := newNode(key, value)
+	if key < st.root.Key {
+		x.Left = st.root.Left
+		x.Right = st.root
+		st.root.Left = nil
+	} else {
+		x.Right = st.root.Right
+		x.Left = st.root
+		st.root.Right = nil
+	}
+	st.root.update()
+	st.root = x.update()
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (st *SplayTree) DelMin() {
+	if st.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	st.Del(st.Min())
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (st *SplayTree) DelMax() {
+	if st.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	st.Del(st.Max())
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (st *SplayTree) Del(key string) {
+	if key == "" {
+		log.Fatalln("calls delete() with a null key")
+	}
+
+	if st.root == nil {
+		return
+	}
+
+	st.root = splay(st.root, key)
+	if key != st.root.Key {
+		return
+	}
+
+	if st.root.Left == nil {
+		st.root = st.root.Right
+		return
+	}
+
+	// splaying key in the left subtree brings its largest key, which has no right child, to the top
+	right := st.root.Right
+	st.root = splay(st.root.Left, key)
+	st.root.Right = right
+	st.root.update()
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (st *SplayTree) Min() string {
+	if st.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+
+	x := st.root
+	for x.Left != nil {
+		x = x.Left
+	}
+	return x.Key
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (st *SplayTree) Max() string {
+	if st.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+
+	x := st.root
+	for x.Right != nil {
+		x = x.Right
+	}
+	return x.Key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (st *SplayTree) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+
+	if st.root == nil {
+		return ""
+	}
+
+	st.root = splay(st.root, key)
+	if st.root.Key <= key {
+		return st.root.Key
+	}
+	// the root is the ceiling; the floor is the largest key to its left
+	if x := st.root.Left; x != nil {
+		for x.Right != nil {
+			x = x.Right
+		}
+		return x.Key
+	}
+	return ""
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (st *SplayTree) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	if st.root == nil {
+		return ""
+	}
+
+	st.root = splay(st.root, key)
+	if st.root.Key >= key {
+		return st.root.Key
+	}
+	// the root is the floor; the ceiling is the smallest key to its right
+	if x := st.root.Right; x != nil {
+		for x.Left != nil {
+			x = x.Left
+		}
+		return x.Key
+	}
+	return ""
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (st *SplayTree) Select(rank int) string {
+	if rank < 0 || rank >= st.Size() {
+		log.Fatalf("argument to select() is invalid: %d\n", rank)
+	}
+
+	x := st.root
+	for {
+		leftSize := size(x.Left)
+		if leftSize > rank {
+			x = x.Left
+		} else if leftSize < rank {
+			rank -= leftSize + 1
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (st *SplayTree) Rank(key string) int {
+	if key == "" {
+		log.Fatalln("argument to rank() is null")
+	}
+
+	rank := 0
+	for x := st.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			rank += 1 + size(x.Left)
+			x = x.Right
+		} else {
+			return rank + size(x.Left)
+		}
+	}
+	return rank
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (st *SplayTree) Keys() []string {
+	if st.IsEmpty() {
+		return []string{}
+	}
+	return st.KeysBetween(st.Min(), st.Max())
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (st *SplayTree) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	st.root.keys(&keys, lo, hi)
+	return keys
+}
+
+func (x *Node) keys(keys *[]string, lo, hi string) {
+	if x == nil {
+		return
+	}
+	if lo < x.Key {
+		x.Left.keys(keys, lo, hi)
+	}
+	if lo <= x.Key && hi >= x.Key {
+		*keys = append(*keys, x.Key)
+	}
+	if hi > x.Key {
+		x.Right.keys(keys, lo, hi)
+	}
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (st *SplayTree) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if st.Contains(hi) {
+		return st.Rank(hi) - st.Rank(lo) + 1
+	}
+	return st.Rank(hi) - st.Rank(lo)
+}
+
+// Height
+// Returns the height of the splay tree (for debugging).
+func (st *SplayTree) Height() int {
+	return height(st.root)
+}
+
+func height(x *Node) int {
+	if x == nil {
+		return -1
+	}
+	return 1 + util.Max(height(x.Left), height(x.Right))
+}
+
+// Check
+// the invariants of 03_BST; a splay tree has no balance invariant
+func Check(st *SplayTree) bool {
+	if !isBST(st.root, "", "") {
+		fmt.Println("Not in symmetric order")
+	}
+
+	if !isSizeConsistent(st.root) {
+		fmt.Println("Subtree counts not consistent")
+	}
+
+	if !st.isRankConsistent() {
+		fmt.Println("Ranks not consistent")
+	}
+
+	return isBST(st.root, "", "") && isSizeConsistent(st.root) && st.isRankConsistent()
+}
+
+func isBST(x *Node, min, max string) bool {
+	if x == nil {
+		return true
+	}
+	if min != "" && x.Key <= min {
+		return false
+	}
+	if max != "" && x.Key >= max {
+		return false
+	}
+	return isBST(x.Left, min, x.Key) && isBST(x.Right, x.Key, max)
+}
+
+func isSizeConsistent(x *Node) bool {
+	if x == nil {
+		return true
+	}
+	if x.size != size(x.Left)+size(x.Right)+1 {
+		return false
+	}
+	return isSizeConsistent(x.Left) && isSizeConsistent(x.Right)
+}
+
+// Rank and Select do not splay, so checking does not reshape the tree
+func (st *SplayTree) isRankConsistent() bool {
+	for i := 0; i < st.Size(); i++ {
+		if i != st.Rank(st.Select(i)) {
+			return false
+		}
+	}
+	for i := 0; i < st.Size(); i++ {
+		key := st.Select(i)
+		if key != st.Select(st.Rank(key)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package splay_tree
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	st := NewSplayTree()
+	for i := 0; i < len(keys); i++ {
+		st.Put(keys[i], i)
+	}
+	require.True(t, Check(st))
+
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, st.Keys())
+	value, _ := st.Get("E")
+	require.Equal(t, 12, value)
+	require.Equal(t, "A", st.Min())
+	require.Equal(t, "X", st.Max())
+	require.Equal(t, "H", st.Floor("I"))
+	require.Equal(t, "L", st.Ceiling("I"))
+	require.Equal(t, "", st.Floor("0"))
+	require.Equal(t, "", st.Ceiling("Y"))
+	require.Equal(t, 4, st.Rank("I"))
+	require.Equal(t, "L", st.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, st.KeysBetween("F", "N"))
+	require.Equal(t, 3, st.SizeBetween("F", "N"))
+
+	st.Del("M")
+	st.DelMin()
+	st.DelMax()
+	require.True(t, Check(st))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, st.Keys())
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := NewSplayTree()
+	values := make(map[string]int)
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1000))
+		if r.Intn(3) == 0 {
+			st.Del(key)
+			delete(values, key)
+		} else {
+			st.Put(key, i)
+			values[key] = i
+		}
+	}
+	require.True(t, Check(st))
+	require.Equal(t, len(values), st.Size())
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		v, found := st.Get(key)
+		require.True(t, found)
+		require.Equal(t, value, v)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	require.Equal(t, keys, st.Keys())
+
+	for !st.IsEmpty() {
+		st.DelMin()
+	}
+	require.True(t, Check(st))
+}
+
+func TestCase3(t *testing.T) {
+	st := NewSplayTree()
+	for i := 0; i < 1000; i++ {
+		st.Put(fmt.Sprintf("%04d", i), i)
+	}
+	// sorted inserts leave a path, which the first accesses fold up
+	require.Equal(t, 999, st.Height())
+	require.Equal(t, "0999", st.root.Key)
+
+	st.Get("0000")
+	require.Equal(t, "0000", st.root.Key)
+	require.Less(t, st.Height(), 999)
+	require.True(t, Check(st))
+
+	// a hot key stays at the root while it is being accessed
+	for i := 0; i < 10; i++ {
+		value, found := st.Get("0500")
+		require.True(t, found)
+		require.Equal(t, 500, value)
+		require.Equal(t, "0500", st.root.Key)
+	}
+
+	require.Equal(t, "0500", st.Floor("0500x"))
+	require.Equal(t, "0501", st.Ceiling("0500x"))
+	// a missing key splays its floor or its ceiling
+	require.Contains(t, []string{"0500", "0501"}, st.root.Key)
+	require.True(t, Check(st))
+}
@@ -0,0 +1,513 @@
+package scapegoat_tree
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"fmt"
+	"log"
+	"math"
+)
+
+// A scapegoat tree (Galperin and Rivest) is a plain BST, with no balance information in its
+// nodes beyond the subtree counts 03_BST keeps anyway, whose height stays below log_{1/α} q,
+// where q is an upper bound on the size since the last full rebuild and 1/2 < α < 1. When an
+// insert lands deeper than that, some ancestor of the new node, the scapegoat, must have a
+// child holding more than α of its keys, and that subtree is rebuilt into a perfectly balanced
+// one in linear time; when deletes shrink the tree below α q, the whole tree is rebuilt.
+// The rebuilds cost O(log n) amortized per operation and searches take O(log n) worst case.
+
+type ScapegoatTree struct {
+	root    *Node   // root of the tree
+	alpha   float64 // weight-balance parameter
+	maxSize int     // q: at least the size, at most 1/α times the size
+}
+
+// Node
+// Scapegoat tree helper node data type
+type Node struct {
+	Key string // sorted by key
+	// Value associated data
+	// size number of nodes in subtree
+	Value, size int
+
+	Left, Right *Node // left and right subtrees
+}
+
+// Option
+// Configures a ScapegoatTree at construction.
+type Option func(*options)
+
+type options struct {
+	alpha float64
+}
+
+// WithAlpha
+// Sets the weight-balance parameter α in (1/2, 1): smaller values keep the tree lower
+// at the cost of more frequent rebuilds.
+func WithAlpha(alpha float64) Option {
+	return func(o *options) {
+		if alpha <= 0.5 || alpha >= 1 {
+			log.Fatalln("alpha must be in (1/2, 1)")
+		}
+		o.alpha = alpha
+	}
+}
+
+// NewScapegoatTree
+// Initializes an empty symbol table.
+func NewScapegoatTree(opts ...Option) *ScapegoatTree {
+	o := options{alpha: 2.0 / 3}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &ScapegoatTree{alpha: o.alpha}
+}
+
+func newNode(key string, value int) *Node {
+	return &Node{Key: key, Value: value, size: 1}
+}
+
+// IsEmpty
+// Returns true if this symbol table is empty.
+func (st *ScapegoatTree) IsEmpty() bool {
+	return size(st.root) == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (st *ScapegoatTree) Size() int {
+	return size(st.root)
+}
+
+func size(x *Node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+// is depth more than log_{1/α} q?
+func (st *ScapegoatTree) tooDeep(depth int) bool {
+	return math.Pow(1/st.alpha, float64(depth)) > float64(st.maxSize)
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (st *ScapegoatTree) Contains(key string) bool {
+	_, found := st.Get(key)
+	return found
+}
+
+// Get
+// Returns the value associated with the given key.
+func (st *ScapegoatTree) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	for x := st.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			x = x.Right
+		} else {
+			return x.Value, true
+		}
+	}
+	return 0, false
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (st *ScapegoatTree) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("calls put() with a null key")
+	}
+
+	// descend as in 03_BST, remembering the path
+	path := make([]*Node, 0)
+	for x := st.root; x != nil; {
+		if key == x.Key {
+			x.Value = value
+			return
+		}
+		path = append(path, x)
+		if key < x.Key {
+			x = x.Left
+		} else {
+			x = x.Right
+		}
+	}
+
+	x := newNode(key, value)
+	if len(path) == 0 {
+		st.root = x
+	} else if parent := path[len(path)-1]; key < parent.Key {
+		parent.Left = x
+	} else {
+		parent.Right = x
+	}
+	for _, y := range path {
+		y.size++
+	}
+	st.maxSize = util.Max(st.maxSize, st.Size())
+
+	if !st.tooDeep(len(path)) {
+		return
+	}
+
+	// walk back up to the first ancestor that is not α-weight-balanced
+	child := x
+	for i := len(path) - 1; i >= 0; i-- {
+		if float64(size(child)) > st.alpha*float64(path[i].size) {
+			rebuilt := rebuild(path[i])
+			if i == 0 {
+				st.root = rebuilt
+			} else if parent := path[i-1]; parent.Left == path[i] {
+				parent.Left = rebuilt
+			} else {
+				parent.Right = rebuilt
+			}
+			return
+		}
+		child = path[i]
+	}
+}
+
+// rebuild the subtree rooted at x into a perfectly balanced BST with the same nodes
+func rebuild(x *Node) *Node {
+	nodes := make([]*Node, 0, size(x))
+	var flatten func(x *Node)
+	flatten = func(x *Node) {
+		if x == nil {
+			return
+		}
+		flatten(x.Left)
+		nodes = append(nodes, x)
+		flatten(x.Right)
+	}
+	flatten(x)
+	return build(nodes)
+}
+
+func build(nodes []*Node) *Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	x := nodes[mid]
+	x.Left = build(nodes[:mid])
+	x.Right = build(nodes[mid+1:])
+	x.size = len(nodes)
+	return x
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (st *ScapegoatTree) DelMin() {
+	if st.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	st.Del(st.Min())
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (st *ScapegoatTree) DelMax() {
+	if st.IsEmpty() {
+		log.Fatalln("Symbol table underflow")
+	}
+	st.Del(st.Max())
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (st *ScapegoatTree) Del(key string) {
+	if key == "" {
+		log.Fatalln("calls delete() with a null key")
+	}
+
+	if !st.Contains(key) {
+		return
+	}
+
+	st.root = del(st.root, key)
+	if float64(st.Size()) < st.alpha*float64(st.maxSize) {
+		st.root = rebuild(st.root)
+		st.maxSize = st.Size()
+	}
+}
+
+// Hibbard deletion as in 03_BST
+func del(x *Node, key string) *Node {
+	if key < x.Key {
+		x.Left = del(x.Left, key)
+	} else if key > x.Key {
+		x.Right = del(x.Right, key)
+	} else {
+		if x.Right == nil {
+			return x.Left
+		}
+		if x.Left == nil {
+			return x.Right
+		}
+
+		t := x
+		x = min(t.Right)
+		x.Right = delMin(t.Right)
+		x.Left = t.Left
+	}
+
+	x.size = size(x.Left) + size(x.Right) + 1
+	return x
+}
+
+func delMin(x *Node) *Node {
+	if x.Left == nil {
+		return x.Right
+	}
+	x.Left = delMin(x.Left)
+	x.size = size(x.Left) + size(x.Right) + 1
+	return x
+}
+
+func min(x *Node) *Node {
+	for x.Left != nil {
+		x = x.Left
+	}
+	return x
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (st *ScapegoatTree) Min() string {
+	if st.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+	return min(st.root).Key
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (st *ScapegoatTree) Max() string {
+	if st.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+
+	x := st.root
+	for x.Right != nil {
+		x = x.Right
+	}
+	return x.Key
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (st *ScapegoatTree) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+
+	floor := ""
+	for x := st.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			floor = x.Key
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+	return floor
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (st *ScapegoatTree) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	ceiling := ""
+	for x := st.root; x != nil; {
+		if key < x.Key {
+			ceiling = x.Key
+			x = x.Left
+		} else if key > x.Key {
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+	return ceiling
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (st *ScapegoatTree) Select(rank int) string {
+	if rank < 0 || rank >= st.Size() {
+		log.Fatalf("argument to select() is invalid: %d\n", rank)
+	}
+
+	x := st.root
+	for {
+		leftSize := size(x.Left)
+		if leftSize > rank {
+			x = x.Left
+		} else if leftSize < rank {
+			rank -= leftSize + 1
+			x = x.Right
+		} else {
+			return x.Key
+		}
+	}
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (st *ScapegoatTree) Rank(key string) int {
+	if key == "" {
+		log.Fatalln("argument to rank() is null")
+	}
+
+	rank := 0
+	for x := st.root; x != nil; {
+		if key < x.Key {
+			x = x.Left
+		} else if key > x.Key {
+			rank += 1 + size(x.Left)
+			x = x.Right
+		} else {
+			return rank + size(x.Left)
+		}
+	}
+	return rank
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (st *ScapegoatTree) Keys() []string {
+	if st.IsEmpty() {
+		return []string{}
+	}
+	return st.KeysBetween(st.Min(), st.Max())
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (st *ScapegoatTree) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	st.root.keys(&keys, lo, hi)
+	return keys
+}
+
+func (x *Node) keys(keys *[]string, lo, hi string) {
+	if x == nil {
+		return
+	}
+	if lo < x.Key {
+		x.Left.keys(keys, lo, hi)
+	}
+	if lo <= x.Key && hi >= x.Key {
+		*keys = append(*keys, x.Key)
+	}
+	if hi > x.Key {
+		x.Right.keys(keys, lo, hi)
+	}
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (st *ScapegoatTree) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if st.Contains(hi) {
+		return st.Rank(hi) - st.Rank(lo) + 1
+	}
+	return st.Rank(hi) - st.Rank(lo)
+}
+
+// Height
+// Returns the height of the tree (for debugging).
+func (st *ScapegoatTree) Height() int {
+	return height(st.root)
+}
+
+func height(x *Node) int {
+	if x == nil {
+		return -1
+	}
+	return 1 + util.Max(height(x.Left), height(x.Right))
+}
+
+// Check
+// the invariants of 03_BST, and the height bound log_{1/α} q
+func Check(st *ScapegoatTree) bool {
+	if !isBST(st.root, "", "") {
+		fmt.Println("Not in symmetric order")
+	}
+
+	if !isSizeConsistent(st.root) {
+		fmt.Println("Subtree counts not consistent")
+	}
+
+	if !st.isRankConsistent() {
+		fmt.Println("Ranks not consistent")
+	}
+
+	balanced := st.IsEmpty() || !st.tooDeep(st.Height())
+	if !balanced {
+		fmt.Println("Too high")
+	}
+
+	return isBST(st.root, "", "") && isSizeConsistent(st.root) && st.isRankConsistent() && balanced
+}
+
+func isBST(x *Node, min, max string) bool {
+	if x == nil {
+		return true
+	}
+	if min != "" && x.Key <= min {
+		return false
+	}
+	if max != "" && x.Key >= max {
+		return false
+	}
+	return isBST(x.Left, min, x.Key) && isBST(x.Right, x.Key, max)
+}
+
+func isSizeConsistent(x *Node) bool {
+	if x == nil {
+		return true
+	}
+	if x.size != size(x.Left)+size(x.Right)+1 {
+		return false
+	}
+	return isSizeConsistent(x.Left) && isSizeConsistent(x.Right)
+}
+
+func (st *ScapegoatTree) isRankConsistent() bool {
+	for i := 0; i < st.Size(); i++ {
+		if i != st.Rank(st.Select(i)) {
+			return false
+		}
+	}
+	for _, key := range st.Keys() {
+		if key != st.Select(st.Rank(key)) {
+			return false
+		}
+	}
+	return true
+}
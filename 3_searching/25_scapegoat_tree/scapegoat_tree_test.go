@@ -0,0 +1,94 @@
+package scapegoat_tree
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	st := NewScapegoatTree()
+	for i := 0; i < len(keys); i++ {
+		st.Put(keys[i], i)
+	}
+	require.True(t, Check(st))
+
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, st.Keys())
+	value, _ := st.Get("E")
+	require.Equal(t, 12, value)
+	require.Equal(t, "A", st.Min())
+	require.Equal(t, "X", st.Max())
+	require.Equal(t, "H", st.Floor("I"))
+	require.Equal(t, "L", st.Ceiling("I"))
+	require.Equal(t, "", st.Floor("0"))
+	require.Equal(t, "", st.Ceiling("Y"))
+	require.Equal(t, 4, st.Rank("I"))
+	require.Equal(t, "L", st.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, st.KeysBetween("F", "N"))
+	require.Equal(t, 3, st.SizeBetween("F", "N"))
+
+	st.Del("M")
+	st.DelMin()
+	st.DelMax()
+	require.True(t, Check(st))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, st.Keys())
+}
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := NewScapegoatTree()
+	values := make(map[string]int)
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1000))
+		if r.Intn(3) == 0 {
+			st.Del(key)
+			delete(values, key)
+		} else {
+			st.Put(key, i)
+			values[key] = i
+		}
+	}
+	require.True(t, Check(st))
+	require.Equal(t, len(values), st.Size())
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		v, found := st.Get(key)
+		require.True(t, found)
+		require.Equal(t, value, v)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	require.Equal(t, keys, st.Keys())
+
+	for !st.IsEmpty() {
+		st.DelMin()
+	}
+	require.True(t, Check(st))
+}
+
+func TestCase3(t *testing.T) {
+	for _, alpha := range []float64{0.55, 2.0 / 3, 0.9} {
+		st := NewScapegoatTree(WithAlpha(alpha))
+		for i := 0; i < 4096; i++ {
+			st.Put(fmt.Sprintf("%04d", i), i)
+			require.False(t, st.tooDeep(st.Height()))
+		}
+		require.True(t, Check(st))
+
+		// deleting most keys rebuilds the whole tree
+		for i := 0; i < 4000; i++ {
+			st.Del(fmt.Sprintf("%04d", i))
+		}
+		require.True(t, Check(st))
+		require.Equal(t, 96, st.Size())
+		require.LessOrEqual(t, st.maxSize, int(float64(st.Size())/alpha))
+	}
+}
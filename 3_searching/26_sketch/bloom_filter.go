@@ -0,0 +1,160 @@
+package sketch
+
+import (
+	"log"
+	"math"
+	"math/bits"
+)
+
+// BloomFilter
+// A set of keys in m bits that supports Add and Contains but not Del. Each key sets k bits
+// chosen by hashing; Contains reports whether all k are set, so it never misses a key that
+// was added, and claims one that was not with probability about (1 - e^{-kn/m})^k after n
+// adds. For a target rate p that is smallest with m = -n ln p / (ln 2)^2 bits, about 9.6
+// bits per key for 1%, and k = (m/n) ln 2 hash functions.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    int    // number of hash functions
+	n    uint64 // number of adds
+	seed uint64
+}
+
+// more hash functions than this only pay off for false-positive rates below 10^-19
+const maxHashes = 64
+
+// OptimalBloomSize
+// Returns the number of bits and of hash functions that minimize the size of a Bloom filter
+// for n keys with false-positive rate p. The number of hash functions is at most 64.
+func OptimalBloomSize(n int, p float64) (m, k int) {
+	if n <= 0 {
+		log.Fatalln("number of keys must be positive")
+	}
+	if p <= 0 || p >= 1 {
+		log.Fatalln("false-positive rate must be in (0, 1)")
+	}
+
+	m = int(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return m, min(max(k, 1), maxHashes)
+}
+
+// NewBloomFilter
+// Initializes an empty Bloom filter sized for n keys at false-positive rate p.
+func NewBloomFilter(n int, p float64, opts ...Option) *BloomFilter {
+	m, k := OptimalBloomSize(n, p)
+	return NewBloomFilterSize(m, k, opts...)
+}
+
+// NewBloomFilterSize
+// Initializes an empty Bloom filter with m bits and k hash functions, at most 64.
+func NewBloomFilterSize(m, k int, opts ...Option) *BloomFilter {
+	if m <= 0 || k <= 0 {
+		log.Fatalln("number of bits and of hash functions must be positive")
+	}
+	if k > maxHashes {
+		log.Fatalln("number of hash functions must be at most 64")
+	}
+
+	o := newOptions(opts)
+	return &BloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    uint64(m),
+		k:    k,
+		seed: o.seed,
+	}
+}
+
+// Bits
+// Returns the number of bits m.
+func (bf *BloomFilter) Bits() int {
+	return int(bf.m)
+}
+
+// Hashes
+// Returns the number of hash functions k.
+func (bf *BloomFilter) Hashes() int {
+	return bf.k
+}
+
+// Count
+// Returns the number of adds, repeated keys included.
+func (bf *BloomFilter) Count() int {
+	return int(bf.n)
+}
+
+// Add
+// Adds the key to the set.
+func (bf *BloomFilter) Add(key string) {
+	h1, h2 := hash2(key, bf.seed)
+	for i := 0; i < bf.k; i++ {
+		j := (h1 + uint64(i)*h2) % bf.m
+		bf.bits[j/64] |= 1 << (j % 64)
+	}
+	bf.n++
+}
+
+// Contains
+// Returns false if the key was certainly not added, true if it probably was.
+func (bf *BloomFilter) Contains(key string) bool {
+	h1, h2 := hash2(key, bf.seed)
+	for i := 0; i < bf.k; i++ {
+		j := (h1 + uint64(i)*h2) % bf.m
+		if bf.bits[j/64]&(1<<(j%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FalsePositiveRate
+// Returns the probability that Contains claims a key that was not added, estimated from the
+// fraction of bits that are set.
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, word := range bf.bits {
+		set += bits.OnesCount64(word)
+	}
+	return math.Pow(float64(set)/float64(bf.m), float64(bf.k))
+}
+
+// Merge
+// Adds the keys of that filter, which must have the same size and seed, to this one.
+func (bf *BloomFilter) Merge(that *BloomFilter) {
+	if bf.m != that.m || bf.k != that.k || bf.seed != that.seed {
+		log.Fatalln("calls merge() with a Bloom filter of another size or seed")
+	}
+
+	for i := range bf.bits {
+		bf.bits[i] |= that.bits[i]
+	}
+	bf.n += that.n
+}
+
+// MarshalBinary
+// Encodes the filter to bytes.
+func (bf *BloomFilter) MarshalBinary() ([]byte, error) {
+	return marshal("BLM1", bf.seed, bf.m, uint64(bf.k), bf.n, bf.bits)
+}
+
+// UnmarshalBinary
+// Decodes a filter encoded by MarshalBinary into bf.
+func (bf *BloomFilter) UnmarshalBinary(data []byte) error {
+	var m, k, n uint64
+	seed, r, err := unmarshal(data, "BLM1", &m, &k, &n)
+	if err != nil {
+		return err
+	}
+	// the number of words comes from the length of the data, so a huge m cannot overflow
+	w := uint64(r.Len()) / 8
+	if w == 0 || w*8 != uint64(r.Len()) || m <= (w-1)*64 || m > w*64 || k == 0 || k > maxHashes {
+		return ErrMalformed
+	}
+
+	words := make([]uint64, w)
+	if err = readAll(r, words); err != nil {
+		return err
+	}
+	*bf = BloomFilter{bits: words, m: m, k: int(k), n: n, seed: seed}
+	return nil
+}
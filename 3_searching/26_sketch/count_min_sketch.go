@@ -0,0 +1,205 @@
+package sketch
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// CountMinSketch
+// Estimates the frequencies of keys in a stream (Cormode and Muthukrishnan) with a d × w
+// table of counters: each row hashes a key to one counter, Add increments the key's counter
+// in every row, and Count returns the smallest of them. Collisions only add, so the
+// estimate is never too small, and with w = ⌈e/ε⌉ and d = ⌈ln 1/δ⌉ it exceeds the true
+// count by more than ε times the total of all counts with probability at most δ.
+type CountMinSketch struct {
+	counters []uint64 // row i is counters[i*w : (i+1)*w]
+	w, d     int
+	total    uint64
+	seed     uint64
+}
+
+// NewCountMinSketch
+// Initializes an empty sketch whose estimates exceed the true counts by at most epsilon
+// times the total with probability 1 - delta.
+func NewCountMinSketch(epsilon, delta float64, opts ...Option) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		log.Fatalln("epsilon must be in (0, 1)")
+	}
+	if delta <= 0 || delta >= 1 {
+		log.Fatalln("delta must be in (0, 1)")
+	}
+
+	w := int(math.Ceil(math.E / epsilon))
+	d := int(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketchSize(w, d, opts...)
+}
+
+// NewCountMinSketchSize
+// Initializes an empty sketch with d rows of w counters.
+func NewCountMinSketchSize(w, d int, opts ...Option) *CountMinSketch {
+	if w <= 0 || d <= 0 {
+		log.Fatalln("width and depth must be positive")
+	}
+
+	o := newOptions(opts)
+	return &CountMinSketch{counters: make([]uint64, w*d), w: w, d: d, seed: o.seed}
+}
+
+// Width
+// Returns the number of counters per row w.
+func (cms *CountMinSketch) Width() int {
+	return cms.w
+}
+
+// Depth
+// Returns the number of rows d.
+func (cms *CountMinSketch) Depth() int {
+	return cms.d
+}
+
+// Total
+// Returns the sum of all counts added.
+func (cms *CountMinSketch) Total() int {
+	return int(cms.total)
+}
+
+// Add
+// Adds count occurrences of the key.
+func (cms *CountMinSketch) Add(key string, count int) {
+	if count < 0 {
+		log.Fatalln("calls add() with a negative count")
+	}
+
+	h1, h2 := hash2(key, cms.seed)
+	for i := 0; i < cms.d; i++ {
+		cms.counters[i*cms.w+int((h1+uint64(i)*h2)%uint64(cms.w))] += uint64(count)
+	}
+	cms.total += uint64(count)
+}
+
+// Count
+// Returns an estimate of the number of occurrences of the key, which is never too small.
+func (cms *CountMinSketch) Count(key string) int {
+	h1, h2 := hash2(key, cms.seed)
+	count := uint64(math.MaxUint64)
+	for i := 0; i < cms.d; i++ {
+		count = min(count, cms.counters[i*cms.w+int((h1+uint64(i)*h2)%uint64(cms.w))])
+	}
+	return int(count)
+}
+
+// Merge
+// Adds the counts of that sketch, which must have the same size and seed, to this one.
+func (cms *CountMinSketch) Merge(that *CountMinSketch) {
+	if cms.w != that.w || cms.d != that.d || cms.seed != that.seed {
+		log.Fatalln("calls merge() with a Count-Min sketch of another size or seed")
+	}
+
+	for i, c := range that.counters {
+		cms.counters[i] += c
+	}
+	cms.total += that.total
+}
+
+// MarshalBinary
+// Encodes the sketch to bytes.
+func (cms *CountMinSketch) MarshalBinary() ([]byte, error) {
+	return marshal("CMS1", cms.seed, uint64(cms.w), uint64(cms.d), cms.total, cms.counters)
+}
+
+// UnmarshalBinary
+// Decodes a sketch encoded by MarshalBinary into cms.
+func (cms *CountMinSketch) UnmarshalBinary(data []byte) error {
+	var w, d, total uint64
+	seed, r, err := unmarshal(data, "CMS1", &w, &d, &total)
+	if err != nil {
+		return err
+	}
+	n := uint64(r.Len()) / 8
+	if w == 0 || d == 0 || n*8 != uint64(r.Len()) || n%w != 0 || n/w != d {
+		return ErrMalformed
+	}
+
+	counters := make([]uint64, w*d)
+	if err = readAll(r, counters); err != nil {
+		return err
+	}
+	*cms = CountMinSketch{counters: counters, w: int(w), d: int(d), total: total, seed: seed}
+	return nil
+}
+
+// HeavyHitters
+// Finds the keys that make up more than a fraction phi of a stream with a Count-Min sketch.
+// The sketch cannot list its keys, so the keys whose estimate is above the threshold when
+// they are added are kept as candidates; a candidate that falls below the threshold, which
+// only grows, is dropped until it is added again. Every heavy hitter is reported, along
+// with keys whose estimates are inflated by collisions, which an epsilon well below phi
+// keeps few.
+type HeavyHitters struct {
+	sketch     *CountMinSketch
+	phi        float64
+	candidates map[string]bool
+}
+
+// NewHeavyHitters
+// Initializes a tracker for the keys with more than a fraction phi of the occurrences,
+// over a sketch with the given error parameters.
+func NewHeavyHitters(phi, epsilon, delta float64, opts ...Option) *HeavyHitters {
+	if phi <= 0 || phi >= 1 {
+		log.Fatalln("phi must be in (0, 1)")
+	}
+
+	return &HeavyHitters{
+		sketch:     NewCountMinSketch(epsilon, delta, opts...),
+		phi:        phi,
+		candidates: make(map[string]bool),
+	}
+}
+
+// Sketch
+// Returns the underlying Count-Min sketch.
+func (hh *HeavyHitters) Sketch() *CountMinSketch {
+	return hh.sketch
+}
+
+func (hh *HeavyHitters) threshold() float64 {
+	return hh.phi * float64(hh.sketch.Total())
+}
+
+// Add
+// Adds count occurrences of the key.
+func (hh *HeavyHitters) Add(key string, count int) {
+	hh.sketch.Add(key, count)
+	if float64(hh.sketch.Count(key)) > hh.threshold() {
+		hh.candidates[key] = true
+	}
+
+	// at most 1/phi keys are above the threshold; prune when the candidates outgrow that
+	if float64(len(hh.candidates)) > 2/hh.phi {
+		hh.prune()
+	}
+}
+
+func (hh *HeavyHitters) prune() {
+	for key := range hh.candidates {
+		if float64(hh.sketch.Count(key)) <= hh.threshold() {
+			delete(hh.candidates, key)
+		}
+	}
+}
+
+// Keys
+// Returns the keys whose estimated count is more than phi times the total, by decreasing estimate.
+func (hh *HeavyHitters) Keys() []string {
+	hh.prune()
+	keys := make([]string, 0, len(hh.candidates))
+	for key := range hh.candidates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ci, cj := hh.sketch.Count(keys[i]), hh.sketch.Count(keys[j])
+		return ci > cj || ci == cj && keys[i] < keys[j]
+	})
+	return keys
+}
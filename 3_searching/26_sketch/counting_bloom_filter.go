@@ -0,0 +1,135 @@
+package sketch
+
+import "log"
+
+// CountingBloomFilter
+// A Bloom filter (Fan, Cao, Almeida and Broder) whose bits are replaced by 4-bit counters,
+// so that Del can undo Add: Add increments the k counters of the key and Del decrements
+// them. Four bits overflow with negligible probability at the optimal load; a counter
+// that does reach 15 sticks there, since it no longer knows how many keys it counts, which
+// can only cause false positives. Deleting a key that was never added can cause false
+// negatives, so Del ignores keys that Contains rejects. Uses four times the memory of a
+// BloomFilter of the same rate.
+type CountingBloomFilter struct {
+	counters []uint64 // 16 counters per word
+	m        uint64   // number of counters
+	k        int      // number of hash functions
+	seed     uint64
+}
+
+const maxCounter = 15
+
+// NewCountingBloomFilter
+// Initializes an empty counting Bloom filter sized for n keys at false-positive rate p.
+func NewCountingBloomFilter(n int, p float64, opts ...Option) *CountingBloomFilter {
+	m, k := OptimalBloomSize(n, p)
+	o := newOptions(opts)
+	return &CountingBloomFilter{
+		counters: make([]uint64, (m+15)/16),
+		m:        uint64(m),
+		k:        k,
+		seed:     o.seed,
+	}
+}
+
+func (cbf *CountingBloomFilter) counter(j uint64) uint64 {
+	return cbf.counters[j/16] >> (j % 16 * 4) & maxCounter
+}
+
+func (cbf *CountingBloomFilter) setCounter(j, c uint64) {
+	shift := j % 16 * 4
+	cbf.counters[j/16] = cbf.counters[j/16]&^(maxCounter<<shift) | c<<shift
+}
+
+// the counters of key
+func (cbf *CountingBloomFilter) indexes(key string) []uint64 {
+	h1, h2 := hash2(key, cbf.seed)
+	js := make([]uint64, cbf.k)
+	for i := range js {
+		js[i] = (h1 + uint64(i)*h2) % cbf.m
+	}
+	return js
+}
+
+// Add
+// Adds the key to the multiset.
+func (cbf *CountingBloomFilter) Add(key string) {
+	for _, j := range cbf.indexes(key) {
+		if c := cbf.counter(j); c < maxCounter {
+			cbf.setCounter(j, c+1)
+		}
+	}
+}
+
+// Del
+// Removes one copy of the key, if the filter probably contains it.
+func (cbf *CountingBloomFilter) Del(key string) {
+	js := cbf.indexes(key)
+	for _, j := range js {
+		if cbf.counter(j) == 0 {
+			return
+		}
+	}
+	for _, j := range js {
+		if c := cbf.counter(j); c < maxCounter {
+			cbf.setCounter(j, c-1)
+		}
+	}
+}
+
+// Contains
+// Returns false if the key is certainly not in the multiset, true if it probably is.
+func (cbf *CountingBloomFilter) Contains(key string) bool {
+	return cbf.Count(key) > 0
+}
+
+// Count
+// Returns an upper bound on the number of copies of the key, up to 15, which is exact
+// unless other keys share all of its counters.
+func (cbf *CountingBloomFilter) Count(key string) int {
+	count := uint64(maxCounter)
+	for _, j := range cbf.indexes(key) {
+		count = min(count, cbf.counter(j))
+	}
+	return int(count)
+}
+
+// Merge
+// Adds the keys of that filter, which must have the same size and seed, to this one.
+func (cbf *CountingBloomFilter) Merge(that *CountingBloomFilter) {
+	if cbf.m != that.m || cbf.k != that.k || cbf.seed != that.seed {
+		log.Fatalln("calls merge() with a counting Bloom filter of another size or seed")
+	}
+
+	for j := uint64(0); j < cbf.m; j++ {
+		cbf.setCounter(j, min(cbf.counter(j)+that.counter(j), maxCounter))
+	}
+}
+
+// MarshalBinary
+// Encodes the filter to bytes.
+func (cbf *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	return marshal("CBF1", cbf.seed, cbf.m, uint64(cbf.k), cbf.counters)
+}
+
+// UnmarshalBinary
+// Decodes a filter encoded by MarshalBinary into cbf.
+func (cbf *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	var m, k uint64
+	seed, r, err := unmarshal(data, "CBF1", &m, &k)
+	if err != nil {
+		return err
+	}
+	// the number of words comes from the length of the data, so a huge m cannot overflow
+	w := uint64(r.Len()) / 8
+	if w == 0 || w*8 != uint64(r.Len()) || m <= (w-1)*16 || m > w*16 || k == 0 || k > maxHashes {
+		return ErrMalformed
+	}
+
+	counters := make([]uint64, w)
+	if err = readAll(r, counters); err != nil {
+		return err
+	}
+	*cbf = CountingBloomFilter{counters: counters, m: m, k: int(k), seed: seed}
+	return nil
+}
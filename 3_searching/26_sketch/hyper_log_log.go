@@ -0,0 +1,126 @@
+package sketch
+
+import (
+	"log"
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog
+// Estimates the number of distinct keys (Flajolet, Fusy, Gandouet and Meunier) in m = 2^p
+// one-byte registers. The first p bits of the hash of a key pick a register, which keeps the
+// largest number of leading zeros plus one seen in the rest of the hash; a register that saw
+// d keys holds about lg d. The harmonic mean of 2^register over the registers, scaled, has
+// a standard error of 1.04/√m: 1.6% in 4 KB for p = 12. Small counts, where many registers
+// are still empty, are estimated by linear counting instead. Adding a key twice changes
+// nothing, and the union of two streams is estimated by merging their registers.
+type HyperLogLog struct {
+	registers []uint8
+	p         uint8 // m = 2^p registers
+	seed      uint64
+}
+
+// NewHyperLogLog
+// Initializes an empty sketch with 2^precision registers; precision is in [4, 18].
+func NewHyperLogLog(precision int, opts ...Option) *HyperLogLog {
+	if precision < 4 || precision > 18 {
+		log.Fatalln("precision must be in [4, 18]")
+	}
+
+	o := newOptions(opts)
+	return &HyperLogLog{
+		registers: make([]uint8, 1<<precision),
+		p:         uint8(precision),
+		seed:      o.seed,
+	}
+}
+
+// Add
+// Adds the key to the stream.
+func (hll *HyperLogLog) Add(key string) {
+	h, _ := hash2(key, hll.seed)
+	i := h >> (64 - hll.p)
+	// the sentinel bit caps the rank at 64-p+1 for a hash whose remaining bits are all 0
+	rank := uint8(bits.LeadingZeros64(h<<hll.p|1<<(hll.p-1)) + 1)
+	if rank > hll.registers[i] {
+		hll.registers[i] = rank
+	}
+}
+
+// Count
+// Returns the estimated number of distinct keys added.
+func (hll *HyperLogLog) Count() int {
+	m := float64(len(hll.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range hll.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(hll.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting: the expected number of empty registers after n keys is m e^{-n/m}
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// the bias correction of the raw estimate for m registers
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// Merge
+// Adds the keys of that sketch, which must have the same precision and seed, to this one,
+// so that Count estimates the number of distinct keys in either.
+func (hll *HyperLogLog) Merge(that *HyperLogLog) {
+	if hll.p != that.p || hll.seed != that.seed {
+		log.Fatalln("calls merge() with a HyperLogLog of another precision or seed")
+	}
+
+	for i, r := range that.registers {
+		hll.registers[i] = max(hll.registers[i], r)
+	}
+}
+
+// MarshalBinary
+// Encodes the sketch to bytes.
+func (hll *HyperLogLog) MarshalBinary() ([]byte, error) {
+	return marshal("HLL1", hll.seed, hll.p, hll.registers)
+}
+
+// UnmarshalBinary
+// Decodes a sketch encoded by MarshalBinary into hll.
+func (hll *HyperLogLog) UnmarshalBinary(data []byte) error {
+	var p uint8
+	seed, r, err := unmarshal(data, "HLL1", &p)
+	if err != nil {
+		return err
+	}
+	if p < 4 || p > 18 || r.Len() != 1<<p {
+		return ErrMalformed
+	}
+
+	registers := make([]uint8, 1<<p)
+	if err = readAll(r, registers); err != nil {
+		return err
+	}
+	for _, rank := range registers {
+		if int(rank) > 64-int(p)+1 {
+			return ErrMalformed
+		}
+	}
+	*hll = HyperLogLog{registers: registers, p: p, seed: seed}
+	return nil
+}
@@ -0,0 +1,95 @@
+package sketch
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// Sketches answer membership, cardinality and frequency queries about a stream of keys in
+// a fixed amount of memory, independent of the number of keys, at the price of a bounded
+// error: a Bloom filter may claim a key it never saw, HyperLogLog is off by a few percent,
+// and Count-Min overestimates frequencies by a small fraction of the total.
+// All of them hash keys with util.XXHash64 under a seed, so that two sketches built with
+// the same parameters and seed can be merged, and encode themselves, seed included, with
+// MarshalBinary.
+
+// ErrMalformed is returned by UnmarshalBinary for bytes that do not encode a sketch of that kind.
+var ErrMalformed = errors.New("sketch: malformed encoding")
+
+// Option
+// Configures a sketch at construction.
+type Option func(*options)
+
+type options struct {
+	seed uint64
+}
+
+// WithSeed
+// Seeds the hash functions. Sketches can only be merged with sketches of the same seed.
+func WithSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// two independent 64-bit hashes of key; the i-th index of double hashing is h1 + i h2,
+// which is as good as i independent hash functions for Bloom filters (Kirsch and Mitzenmacher)
+func hash2(key string, seed uint64) (uint64, uint64) {
+	return util.XXHash64(key, seed), util.XXHash64(key, ^seed) | 1
+}
+
+// the header every encoding starts with
+type header struct {
+	Magic [4]byte
+	Seed  uint64
+}
+
+// encode the header, the fixed-size fields and the data in order
+func marshal(magic string, seed uint64, fields ...any) ([]byte, error) {
+	var buf bytes.Buffer
+	h := header{Seed: seed}
+	copy(h.Magic[:], magic)
+	if err := binary.Write(&buf, binary.LittleEndian, h); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if err := binary.Write(&buf, binary.LittleEndian, field); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// decode the header and the fixed-size fields, and return the reader positioned at the data
+func unmarshal(data []byte, magic string, fields ...any) (uint64, *bytes.Reader, error) {
+	r := bytes.NewReader(data)
+	var h header
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil || string(h.Magic[:]) != magic {
+		return 0, nil, ErrMalformed
+	}
+	for _, field := range fields {
+		if err := binary.Read(r, binary.LittleEndian, field); err != nil {
+			return 0, nil, ErrMalformed
+		}
+	}
+	return h.Seed, r, nil
+}
+
+// read exactly the data slice and check that nothing follows it
+func readAll(r *bytes.Reader, data any) error {
+	if err := binary.Read(r, binary.LittleEndian, data); err != nil || r.Len() != 0 {
+		return ErrMalformed
+	}
+	return nil
+}
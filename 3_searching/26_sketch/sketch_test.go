@@ -0,0 +1,225 @@
+package sketch
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	n, p := 10000, 0.01
+	bf := NewBloomFilter(n, p, WithSeed(1))
+	m, k := OptimalBloomSize(n, p)
+	require.Equal(t, m, bf.Bits())
+	require.Equal(t, 7, k)
+	require.Equal(t, k, bf.Hashes())
+
+	for i := 0; i < n; i++ {
+		bf.Add(fmt.Sprintf("key-%d", i))
+	}
+	require.Equal(t, n, bf.Count())
+	for i := 0; i < n; i++ {
+		require.True(t, bf.Contains(fmt.Sprintf("key-%d", i)))
+	}
+
+	falsePositives := 0
+	for i := 0; i < 100000; i++ {
+		if bf.Contains(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	rate := float64(falsePositives) / 100000
+	require.InDelta(t, p, rate, p/2)
+	require.InDelta(t, p, bf.FalsePositiveRate(), p/4)
+
+	data, err := bf.MarshalBinary()
+	require.NoError(t, err)
+	var decoded BloomFilter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, bf, &decoded)
+
+	other := NewBloomFilter(n, p, WithSeed(1))
+	other.Add("extra")
+	require.False(t, bf.Contains("extra"))
+	bf.Merge(other)
+	require.True(t, bf.Contains("extra"))
+}
+
+func TestCase2(t *testing.T) {
+	cbf := NewCountingBloomFilter(1000, 0.01, WithSeed(2))
+	for i := 0; i < 1000; i++ {
+		cbf.Add(fmt.Sprintf("key-%d", i))
+	}
+	cbf.Add("key-0")
+	require.GreaterOrEqual(t, cbf.Count("key-0"), 2)
+
+	for i := 0; i < 1000; i += 2 {
+		cbf.Del(fmt.Sprintf("key-%d", i))
+	}
+	require.True(t, cbf.Contains("key-0"))
+	for i := 1; i < 1000; i += 2 {
+		require.True(t, cbf.Contains(fmt.Sprintf("key-%d", i)))
+	}
+	present := 0
+	for i := 2; i < 1000; i += 2 {
+		if cbf.Contains(fmt.Sprintf("key-%d", i)) {
+			present++
+		}
+	}
+	require.Less(t, present, 20)
+
+	// deleting a key that the filter rejects leaves the others alone
+	for i := 0; i < 1000; i++ {
+		if key := fmt.Sprintf("other-%d", i); !cbf.Contains(key) {
+			cbf.Del(key)
+		}
+	}
+	for i := 1; i < 1000; i += 2 {
+		require.True(t, cbf.Contains(fmt.Sprintf("key-%d", i)))
+	}
+
+	data, err := cbf.MarshalBinary()
+	require.NoError(t, err)
+	var decoded CountingBloomFilter
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, cbf, &decoded)
+}
+
+func TestCase3(t *testing.T) {
+	for _, precision := range []int{4, 10, 14} {
+		a := NewHyperLogLog(precision, WithSeed(3))
+		b := NewHyperLogLog(precision, WithSeed(3))
+		require.Equal(t, 0, a.Count())
+
+		for i := 0; i < 100000; i++ {
+			a.Add(fmt.Sprintf("key-%d", i))
+			a.Add(fmt.Sprintf("key-%d", i/2))
+			b.Add(fmt.Sprintf("key-%d", i+50000))
+		}
+
+		// within three standard errors
+		stderr := 1.04 / math.Sqrt(float64(int(1)<<precision))
+		require.InEpsilon(t, 100000, a.Count(), 3*stderr)
+		a.Merge(b)
+		require.InEpsilon(t, 150000, a.Count(), 3*stderr)
+
+		small := NewHyperLogLog(precision)
+		for i := 0; i < 10; i++ {
+			small.Add(fmt.Sprintf("key-%d", i))
+		}
+		require.InDelta(t, 10, small.Count(), 3)
+
+		data, err := a.MarshalBinary()
+		require.NoError(t, err)
+		var decoded HyperLogLog
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.Equal(t, a, &decoded)
+	}
+}
+
+func TestCase4(t *testing.T) {
+	epsilon := 0.001
+	cms := NewCountMinSketch(epsilon, 0.01, WithSeed(4))
+	require.Equal(t, 2719, cms.Width())
+	require.Equal(t, 5, cms.Depth())
+
+	counts := make(map[string]int)
+	for i := 0; i < 100000; i++ {
+		// a Zipf-like stream: key j occurs about 1/j as often as key 1
+		key := fmt.Sprintf("key-%d", 1+i%1000*(i%7+1)%997)
+		counts[key]++
+		cms.Add(key, 1)
+	}
+	require.Equal(t, 100000, cms.Total())
+
+	within := 0
+	for key, count := range counts {
+		estimate := cms.Count(key)
+		require.GreaterOrEqual(t, estimate, count)
+		if float64(estimate-count) <= epsilon*100000 {
+			within++
+		}
+	}
+	require.GreaterOrEqual(t, float64(within), 0.99*float64(len(counts)))
+	require.Equal(t, 0, NewCountMinSketch(epsilon, 0.01, WithSeed(4)).Count("key-1"))
+
+	data, err := cms.MarshalBinary()
+	require.NoError(t, err)
+	var decoded CountMinSketch
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, cms, &decoded)
+
+	cms.Merge(&decoded)
+	require.Equal(t, 200000, cms.Total())
+	require.Equal(t, 2*decoded.Count("key-1"), cms.Count("key-1"))
+}
+
+func TestCase5(t *testing.T) {
+	hh := NewHeavyHitters(0.05, 0.001, 0.01, WithSeed(5))
+	for i := 0; i < 100000; i++ {
+		switch {
+		case i%10 == 0:
+			hh.Add("hot", 1)
+		case i%16 == 1:
+			hh.Add("warm", 1)
+		default:
+			hh.Add(fmt.Sprintf("cold-%d", i), 1)
+		}
+	}
+	require.Equal(t, []string{"hot", "warm"}, hh.Keys())
+	require.GreaterOrEqual(t, hh.Sketch().Count("hot"), 10000)
+
+	for _, data := range [][]byte{nil, []byte("BLM1"), []byte("not a sketch at all")} {
+		require.ErrorIs(t, new(BloomFilter).UnmarshalBinary(data), ErrMalformed)
+		require.ErrorIs(t, new(CountingBloomFilter).UnmarshalBinary(data), ErrMalformed)
+		require.ErrorIs(t, new(HyperLogLog).UnmarshalBinary(data), ErrMalformed)
+		require.ErrorIs(t, new(CountMinSketch).UnmarshalBinary(data), ErrMalformed)
+	}
+
+	data, err := NewHyperLogLog(8).MarshalBinary()
+	require.NoError(t, err)
+	require.ErrorIs(t, new(HyperLogLog).UnmarshalBinary(data[:len(data)-1]), ErrMalformed)
+	require.ErrorIs(t, new(BloomFilter).UnmarshalBinary(data), ErrMalformed)
+
+	// sizes that would overflow, data that does not match the size, and too many hash functions
+	encode := func(magic string, fields ...any) []byte {
+		data, err := marshal(magic, 0, fields...)
+		require.NoError(t, err)
+		return data
+	}
+	for _, data := range [][]byte{
+		encode("BLM1", uint64(math.MaxUint64), uint64(3), uint64(0)),
+		encode("BLM1", uint64(math.MaxUint64), uint64(3), uint64(0), []uint64{1}),
+		encode("BLM1", uint64(65), uint64(3), uint64(0), []uint64{1}),
+		encode("BLM1", uint64(64), uint64(3), uint64(0), []uint64{1, 2}),
+		encode("BLM1", uint64(64), uint64(3), uint64(0), []uint64{1}, uint8(0)),
+		encode("BLM1", uint64(64), uint64(0), uint64(0), []uint64{1}),
+		encode("BLM1", uint64(64), uint64(65), uint64(0), []uint64{1}),
+		encode("BLM1", uint64(64), uint64(math.MaxUint64), uint64(0), []uint64{1}),
+	} {
+		require.ErrorIs(t, new(BloomFilter).UnmarshalBinary(data), ErrMalformed)
+	}
+	for _, data := range [][]byte{
+		encode("CBF1", uint64(math.MaxUint64-3), uint64(3)),
+		encode("CBF1", uint64(math.MaxUint64-3), uint64(3), []uint64{1}),
+		encode("CBF1", uint64(17), uint64(3), []uint64{1}),
+		encode("CBF1", uint64(16), uint64(3), []uint64{1, 2}),
+		encode("CBF1", uint64(16), uint64(1<<63), []uint64{1}),
+	} {
+		require.ErrorIs(t, new(CountingBloomFilter).UnmarshalBinary(data), ErrMalformed)
+	}
+
+	// the largest filters that are allowed still decode
+	bf := NewBloomFilterSize(65, 64)
+	bf.Add("x")
+	data, err = bf.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(BloomFilter)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, 64, decoded.Hashes())
+	require.True(t, decoded.Contains("x"))
+	_, k := OptimalBloomSize(10, 1e-30)
+	require.Equal(t, 64, k)
+}
@@ -0,0 +1,241 @@
+package cursor
+
+import "iter"
+
+// A cursor is a position in an ordered symbol table that moves in key order without
+// materializing the keys. This package has the traversal shared by the ordered symbol tables:
+// TreeCursor, the cursor of any binary search tree (03_BST, 05_red_black_bst, 02_avl_tree_st),
+// and the range-over-func iterators built on any cursor (those and 02_binary_search_st).
+
+// Interface
+// A cursor over the keys of an ordered symbol table. It is not positioned until First,
+// Last, Seek or SeekFloor; each move returns true if the cursor is then positioned at a key.
+// Modifying the symbol table invalidates its cursors.
+type Interface interface {
+	Valid() bool
+	Key() string
+	Value() int
+	First() bool
+	Last() bool
+	Seek(key string) bool      // to the smallest key greater than or equal to key
+	SeekFloor(key string) bool // to the largest key less than or equal to key
+	Next() bool
+	Prev() bool
+}
+
+// Node
+// A node of a binary search tree as a TreeCursor sees it. N is the node type itself,
+// a pointer whose zero value is the empty subtree.
+type Node[N comparable] interface {
+	comparable
+	Entry() (key string, value int)
+	Children() (left, right N)
+}
+
+// TreeCursor
+// The cursor of a binary search tree. It keeps the path from the root to the current node
+// on a stack, so Next and Prev take amortized constant time and Seek takes time proportional
+// to the height.
+type TreeCursor[N Node[N]] struct {
+	root  N
+	stack []N // path from the root to the current node
+}
+
+// NewTreeCursor
+// Returns a cursor over the tree rooted at root that is not positioned yet.
+func NewTreeCursor[N Node[N]](root N) *TreeCursor[N] {
+	return &TreeCursor[N]{root: root}
+}
+
+// Valid
+// Returns true if the cursor is positioned at a key.
+func (c *TreeCursor[N]) Valid() bool {
+	return len(c.stack) > 0
+}
+
+// Key
+// Returns the key at the cursor.
+func (c *TreeCursor[N]) Key() string {
+	key, _ := c.top().Entry()
+	return key
+}
+
+// Value
+// Returns the value at the cursor.
+func (c *TreeCursor[N]) Value() int {
+	_, value := c.top().Entry()
+	return value
+}
+
+// First
+// Moves to the smallest key.
+func (c *TreeCursor[N]) First() bool {
+	c.stack = c.stack[:0]
+	c.pushLeft(c.root)
+	return c.Valid()
+}
+
+// Last
+// Moves to the largest key.
+func (c *TreeCursor[N]) Last() bool {
+	c.stack = c.stack[:0]
+	c.pushRight(c.root)
+	return c.Valid()
+}
+
+// Seek
+// Moves to the smallest key greater than or equal to key.
+func (c *TreeCursor[N]) Seek(key string) bool {
+	if !c.search(key) {
+		return false
+	}
+	if c.Key() < key {
+		return c.Next()
+	}
+	return true
+}
+
+// SeekFloor
+// Moves to the largest key less than or equal to key.
+func (c *TreeCursor[N]) SeekFloor(key string) bool {
+	if !c.search(key) {
+		return false
+	}
+	if c.Key() > key {
+		return c.Prev()
+	}
+	return true
+}
+
+// Next
+// Moves to the next larger key.
+func (c *TreeCursor[N]) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+
+	var none N
+	if _, right := c.top().Children(); right != none {
+		c.pushLeft(right)
+		return true
+	}
+
+	// go up until coming from a left subtree
+	x := c.pop()
+	for c.Valid() {
+		if _, right := c.top().Children(); right != x {
+			break
+		}
+		x = c.pop()
+	}
+	return c.Valid()
+}
+
+// Prev
+// Moves to the next smaller key.
+func (c *TreeCursor[N]) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+
+	var none N
+	if left, _ := c.top().Children(); left != none {
+		c.pushRight(left)
+		return true
+	}
+
+	// go up until coming from a right subtree
+	x := c.pop()
+	for c.Valid() {
+		if left, _ := c.top().Children(); left != x {
+			break
+		}
+		x = c.pop()
+	}
+	return c.Valid()
+}
+
+// descend towards key, leaving the path on the stack; the last node is the floor or the ceiling of key
+func (c *TreeCursor[N]) search(key string) bool {
+	c.stack = c.stack[:0]
+	var none N
+	for x := c.root; x != none; {
+		c.stack = append(c.stack, x)
+		k, _ := x.Entry()
+		left, right := x.Children()
+		if key < k {
+			x = left
+		} else if key > k {
+			x = right
+		} else {
+			break
+		}
+	}
+	return c.Valid()
+}
+
+func (c *TreeCursor[N]) pushLeft(x N) {
+	var none N
+	for ; x != none; x, _ = x.Children() {
+		c.stack = append(c.stack, x)
+	}
+}
+
+func (c *TreeCursor[N]) pushRight(x N) {
+	var none N
+	for ; x != none; _, x = x.Children() {
+		c.stack = append(c.stack, x)
+	}
+}
+
+func (c *TreeCursor[N]) top() N {
+	return c.stack[len(c.stack)-1]
+}
+
+func (c *TreeCursor[N]) pop() N {
+	x := c.top()
+	c.stack = c.stack[:len(c.stack)-1]
+	return x
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order,
+// each iteration with a new cursor from cursor.
+func All[C Interface](cursor func() C) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := cursor()
+		for ok := c.First(); ok && yield(c.Key(), c.Value()); ok = c.Next() {
+		}
+	}
+}
+
+// Backward
+// Returns an iterator over the key-value pairs in descending key order.
+func Backward[C Interface](cursor func() C) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := cursor()
+		for ok := c.Last(); ok && yield(c.Key(), c.Value()); ok = c.Prev() {
+		}
+	}
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+// Only the pairs actually consumed are visited, so taking the first few keys after lo is cheap.
+func Range[C Interface](cursor func() C, lo, hi string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := cursor()
+		for ok := c.Seek(lo); ok && c.Key() <= hi && yield(c.Key(), c.Value()); ok = c.Next() {
+		}
+	}
+}
+
+// RangeReverse
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in descending order.
+func RangeReverse[C Interface](cursor func() C, lo, hi string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := cursor()
+		for ok := c.SeekFloor(hi); ok && c.Key() >= lo && yield(c.Key(), c.Value()); ok = c.Prev() {
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cursor_test
+
+import (
+	binarySearchST "github.com/lee-hen/Algorithms/3_searching/02_binary_search_st"
+	BST "github.com/lee-hen/Algorithms/3_searching/03_BST"
+	redBlackBST "github.com/lee-hen/Algorithms/3_searching/05_red_black_bst"
+	"github.com/lee-hen/Algorithms/3_searching/27_cursor"
+	avlTreeST "github.com/lee-hen/Algorithms/5_context_or_beyond/02_avl_tree_st"
+	"github.com/stretchr/testify/require"
+
+	"iter"
+	"strings"
+	"testing"
+)
+
+// the ordered symbol tables with cursors
+type orderedST interface {
+	Put(key string, value int)
+	Get(key string) (int, bool)
+	All() iter.Seq2[string, int]
+	Backward() iter.Seq2[string, int]
+	Range(lo, hi string) iter.Seq2[string, int]
+	RangeReverse(lo, hi string) iter.Seq2[string, int]
+}
+
+func symbolTables() map[string]struct {
+	st     orderedST
+	cursor func() cursor.Interface
+} {
+	bs := binarySearchST.NewBinarySearchST(2)
+	bst := BST.NewBST()
+	rb := redBlackBST.NewRedBlackBST()
+	avl := avlTreeST.NewAVLTree()
+	return map[string]struct {
+		st     orderedST
+		cursor func() cursor.Interface
+	}{
+		"binary search": {bs, func() cursor.Interface { return bs.Cursor() }},
+		"BST":           {bst, func() cursor.Interface { return bst.Cursor() }},
+		"red-black BST": {rb, func() cursor.Interface { return rb.Cursor() }},
+		"AVL tree":      {avl, func() cursor.Interface { return avl.Cursor() }},
+	}
+}
+
+func keys(seq iter.Seq2[string, int]) []string {
+	var keys []string
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	input := strings.Split(test, " ")
+
+	for name, tc := range symbolTables() {
+		st := tc.st
+		for i := 0; i < len(input); i++ {
+			st.Put(input[i], i)
+		}
+
+		for key, value := range st.All() {
+			val, _ := st.Get(key)
+			require.Equal(t, val, value, name)
+		}
+		require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, keys(st.All()), name)
+		require.Equal(t, []string{"X", "S", "R", "P", "M", "L", "H", "E", "C", "A"}, keys(st.Backward()), name)
+
+		// range bounds need not be keys
+		require.Equal(t, []string{"E", "H", "L", "M"}, keys(st.Range("D", "N")), name)
+		require.Equal(t, []string{"M", "L", "H", "E"}, keys(st.RangeReverse("D", "N")), name)
+
+		// stop after the first two keys from a point
+		var first []string
+		for key := range st.Range("I", "Z") {
+			first = append(first, key)
+			if len(first) == 2 {
+				break
+			}
+		}
+		require.Equal(t, []string{"L", "M"}, first, name)
+
+		c := tc.cursor()
+		require.False(t, c.Valid(), name)
+		require.True(t, c.Seek("N"), name)
+		require.Equal(t, "P", c.Key(), name)
+		require.True(t, c.Prev(), name)
+		require.Equal(t, "M", c.Key(), name)
+		require.True(t, c.SeekFloor("N"), name)
+		require.Equal(t, "M", c.Key(), name)
+		require.True(t, c.SeekFloor("S"), name)
+		require.Equal(t, "S", c.Key(), name)
+		require.True(t, c.Next(), name)
+		require.Equal(t, "X", c.Key(), name)
+		require.False(t, c.Next(), name)
+		require.False(t, c.Seek("Y"), name)
+		require.False(t, c.SeekFloor("0"), name)
+		require.True(t, c.Last(), name)
+		require.Equal(t, "X", c.Key(), name)
+		require.Equal(t, 7, c.Value(), name)
+		require.True(t, c.First(), name)
+		require.Equal(t, "A", c.Key(), name)
+		require.False(t, c.Prev(), name)
+	}
+}
+
+func TestCase2(t *testing.T) {
+	// an empty symbol table
+	for name, tc := range symbolTables() {
+		require.Empty(t, keys(tc.st.All()), name)
+		require.Empty(t, keys(tc.st.RangeReverse("A", "Z")), name)
+		c := tc.cursor()
+		require.False(t, c.First(), name)
+		require.False(t, c.Last(), name)
+		require.False(t, c.Seek("A"), name)
+	}
+}
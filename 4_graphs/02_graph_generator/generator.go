@@ -0,0 +1,113 @@
+package graph_generator
+
+import (
+	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
+	"github.com/lee-hen/Algorithms/util"
+)
+
+// Generator
+// Generates random graphs, drawing from its own util.Random, so that the graphs generated
+// replay from the seed of that Random. The functions of the package draw from util.Default().
+type Generator struct {
+	random *util.Random
+}
+
+// New
+// Returns a Generator that draws from random.
+func New(random *util.Random) *Generator {
+	return &Generator{random: random}
+}
+
+func defaultGenerator() *Generator {
+	return New(util.Default())
+}
+
+// Simple
+// As Generator.Simple, drawing from util.Default().
+func Simple(v, e int) *graph.Graph {
+	return defaultGenerator().Simple(v, e)
+}
+
+// SimpleProb
+// As Generator.SimpleProb, drawing from util.Default().
+func SimpleProb(v int, p float64) *graph.Graph {
+	return defaultGenerator().SimpleProb(v, p)
+}
+
+// Complete
+// As Generator.Complete, drawing from util.Default().
+func Complete(v int) *graph.Graph {
+	return defaultGenerator().Complete(v)
+}
+
+// CompleteBipartite
+// As Generator.CompleteBipartite, drawing from util.Default().
+func CompleteBipartite(v1, v2 int) *graph.Graph {
+	return defaultGenerator().CompleteBipartite(v1, v2)
+}
+
+// Bipartite
+// As Generator.Bipartite, drawing from util.Default().
+func Bipartite(v1, v2, e int) *graph.Graph {
+	return defaultGenerator().Bipartite(v1, v2, e)
+}
+
+// BipartiteProb
+// As Generator.BipartiteProb, drawing from util.Default().
+func BipartiteProb(v1, v2 int, p float64) *graph.Graph {
+	return defaultGenerator().BipartiteProb(v1, v2, p)
+}
+
+// Path
+// As Generator.Path, drawing from util.Default().
+func Path(v int) *graph.Graph {
+	return defaultGenerator().Path(v)
+}
+
+// BinaryTree
+// As Generator.BinaryTree, drawing from util.Default().
+func BinaryTree(v int) *graph.Graph {
+	return defaultGenerator().BinaryTree(v)
+}
+
+// Cycle
+// As Generator.Cycle, drawing from util.Default().
+func Cycle(v int) *graph.Graph {
+	return defaultGenerator().Cycle(v)
+}
+
+// EulerianCycle
+// As Generator.EulerianCycle, drawing from util.Default().
+func EulerianCycle(v, e int) *graph.Graph {
+	return defaultGenerator().EulerianCycle(v, e)
+}
+
+// EulerianPath
+// As Generator.EulerianPath, drawing from util.Default().
+func EulerianPath(v, e int) *graph.Graph {
+	return defaultGenerator().EulerianPath(v, e)
+}
+
+// Wheel
+// As Generator.Wheel, drawing from util.Default().
+func Wheel(v int) *graph.Graph {
+	return defaultGenerator().Wheel(v)
+}
+
+// Star
+// As Generator.Star, drawing from util.Default().
+func Star(v int) *graph.Graph {
+	return defaultGenerator().Star(v)
+}
+
+// Regular
+// As Generator.Regular, drawing from util.Default().
+func Regular(v, k int) *graph.Graph {
+	return defaultGenerator().Regular(v, k)
+}
+
+// Tree
+// As Generator.Tree, drawing from util.Default().
+func Tree(v int) *graph.Graph {
+	return defaultGenerator().Tree(v)
+}
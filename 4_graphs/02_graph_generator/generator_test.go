@@ -0,0 +1,41 @@
+package graph_generator
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// every random graph of the generator, printed
+func generate(gen *Generator) []string {
+	var graphs []string
+	for _, g := range []interface{ String() string }{
+		gen.Simple(10, 20),
+		gen.SimpleProb(10, 0.3),
+		gen.Bipartite(5, 6, 12),
+		gen.BipartiteProb(5, 6, 0.4),
+		gen.Path(10),
+		gen.BinaryTree(10),
+		gen.Cycle(10),
+		gen.EulerianCycle(10, 15),
+		gen.EulerianPath(10, 15),
+		gen.Wheel(10),
+		gen.Star(10),
+		gen.Regular(10, 3),
+		gen.Tree(10),
+	} {
+		graphs = append(graphs, g.String())
+	}
+	return graphs
+}
+
+func TestCase1(t *testing.T) {
+	// the graphs replay from the seed of the Random
+	graphs := generate(New(util.NewRandom(5)))
+	require.Equal(t, graphs, generate(New(util.NewRandom(5))))
+	require.NotEqual(t, graphs, generate(New(util.NewRandom(6))))
+
+	util.Seed(5)
+	require.Equal(t, graphs[0], Simple(10, 20).String())
+}
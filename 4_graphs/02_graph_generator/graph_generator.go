@@ -3,12 +3,9 @@ package graph_generator
 import (
 	minPQ "github.com/lee-hen/Algorithms/2_sorting/21_min_pq"
 	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
-	"github.com/lee-hen/Algorithms/util"
 
 	"fmt"
 	"log"
-	"math/rand"
-	"time"
 )
 
 // A bipartite graph is a graph whose vertices we can divide into two sets such that all edges connect a vertex in one set with a vertex in the other set.
@@ -27,7 +24,7 @@ func newEdge(v, w int) *Edge {
 
 // Simple
 // Returns a random simple graph containing v vertices and e edges.
-func Simple(v, e int) *graph.Graph {
+func (gen *Generator) Simple(v, e int) *graph.Graph {
 	if e > v * (v - 1) / 2 {
 		log.Fatalln("Too many edges")
 	}
@@ -36,10 +33,8 @@ func Simple(v, e int) *graph.Graph {
 	}
 	g := graph.NewGraph(v)
 	set := make(map[string]struct{})
-
-	rand.Seed(time.Now().UnixNano())
 	for g.E < e {
-		v, w := rand.Intn(v), rand.Intn(v)
+		v, w := gen.random.Intn(v), gen.random.Intn(v)
 		edge := newEdge(v, w)
 		if _, ok := set[key(edge)]; !ok && v != w {
 			set[key(edge)] = struct{}{}
@@ -53,14 +48,14 @@ func Simple(v, e int) *graph.Graph {
 // Returns a random simple graph on V vertices, with an
 // edge between any two vertices with probability p. This is sometimes
 // referred to as the Erdos-Renyi random graph model.
-func SimpleProb(v int, p float64) *graph.Graph {
+func (gen *Generator) SimpleProb(v int, p float64) *graph.Graph {
 	if p < 0.0 || p > 1.0 {
 		log.Fatalln("Probability must be between 0 and 1")
 	}
 	g := graph.NewGraph(v)
 	for v := 0; v < g.V; v++ {
 		for w := v+1; w < g.V; w++ {
-			if util.Bernoulli(p) {
+			if gen.random.Bernoulli(p) {
 				g.AddEdge(v, w)
 			}
 		}
@@ -71,20 +66,20 @@ func SimpleProb(v int, p float64) *graph.Graph {
 
 // Complete
 // Returns the complete graph on v vertices.
-func Complete(v int) *graph.Graph {
-	return SimpleProb(v, 1.0)
+func (gen *Generator) Complete(v int) *graph.Graph {
+	return gen.SimpleProb(v, 1.0)
 }
 
 // CompleteBipartite
 // Returns a complete bipartite graph on v1 and v2 vertices.
-func CompleteBipartite(v1, v2 int) *graph.Graph {
-	return Bipartite(v1, v2, v1*v2)
+func (gen *Generator) CompleteBipartite(v1, v2 int) *graph.Graph {
+	return gen.Bipartite(v1, v2, v1*v2)
 }
 
 // Bipartite
 // Returns a random simple bipartite graph on v1 and v2 vertices
 // with e edges.
-func Bipartite(v1, v2, e int) *graph.Graph {
+func (gen *Generator) Bipartite(v1, v2, e int) *graph.Graph {
 	if e > v1 * v2 {
 		log.Fatalln("Too many edges")
 	}
@@ -97,13 +92,12 @@ func Bipartite(v1, v2, e int) *graph.Graph {
 	for i := 0; i < v1 + v2; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	set := make(map[string]struct{})
-	rand.Seed(time.Now().UnixNano())
 	for g.E < e {
-		i := rand.Intn(v1)
-		j := v1 + rand.Intn(v2)
+		i := gen.random.Intn(v1)
+		j := v1 + gen.random.Intn(v2)
 		edge := newEdge(vertices[i], vertices[j])
 		if _, ok := set[key(edge)]; !ok {
 			set[key(edge)] = struct{}{}
@@ -116,7 +110,7 @@ func Bipartite(v1, v2, e int) *graph.Graph {
 // BipartiteProb
 // Returns a random simple bipartite graph on v1 and v2 vertices,
 // containing each possible edge with probability p.
-func BipartiteProb(v1, v2 int, p float64) *graph.Graph {
+func (gen *Generator) BipartiteProb(v1, v2 int, p float64) *graph.Graph {
 	if p < 0.0 || p > 1.0 {
 		log.Fatalln("Probability must be between 0 and 1")
 	}
@@ -124,12 +118,12 @@ func BipartiteProb(v1, v2 int, p float64) *graph.Graph {
 	for i := 0; i < v1 + v2; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	g := graph.NewGraph(v1 + v2)
 	for i := 0; i < v1; i++ {
 		for j := 0; j < v2; j++ {
-			if util.Bernoulli(p) {
+			if gen.random.Bernoulli(p) {
 				g.AddEdge(vertices[i], vertices[v1 + j])
 			}
 		}
@@ -140,13 +134,13 @@ func BipartiteProb(v1, v2 int, p float64) *graph.Graph {
 
 // Path
 // Returns a path graph on V vertices.
-func Path(v int) *graph.Graph {
+func (gen *Generator) Path(v int) *graph.Graph {
 	g := graph.NewGraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 0; i < v-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -157,13 +151,13 @@ func Path(v int) *graph.Graph {
 
 // BinaryTree
 // Returns a complete binary tree graph on v vertices.
-func BinaryTree(v int) *graph.Graph {
+func (gen *Generator) BinaryTree(v int) *graph.Graph {
 	g := graph.NewGraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 1; i < v; i++ {
 		g.AddEdge(vertices[i], vertices[i-1]/2)
@@ -173,13 +167,13 @@ func BinaryTree(v int) *graph.Graph {
 
 // Cycle
 // Returns a cycle graph on v vertices.
-func Cycle(v int) *graph.Graph {
+func (gen *Generator) Cycle(v int) *graph.Graph {
 	g := graph.NewGraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 0; i < v-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -191,7 +185,7 @@ func Cycle(v int) *graph.Graph {
 
 // EulerianCycle
 // Returns an Eulerian cycle graph on v vertices.
-func EulerianCycle(v, e int) *graph.Graph {
+func (gen *Generator) EulerianCycle(v, e int) *graph.Graph {
 	if e <= 0 {
 		log.Fatalln("An Eulerian cycle must have at least one edge")
 	}
@@ -202,9 +196,8 @@ func EulerianCycle(v, e int) *graph.Graph {
 
 	g := graph.NewGraph(v)
 	vertices := make([]int, e, e)
-	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < e; i++ {
-		vertices[i] = rand.Intn(v)
+		vertices[i] = gen.random.Intn(v)
 	}
 	for i := 0; i < e-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -216,7 +209,7 @@ func EulerianCycle(v, e int) *graph.Graph {
 
 // EulerianPath
 // Returns an Eulerian cycle graph on v vertices.
-func EulerianPath(v, e int) *graph.Graph {
+func (gen *Generator) EulerianPath(v, e int) *graph.Graph {
 	if e < 0 {
 		log.Fatalln("An Eulerian cycle must have at least one edge")
 	}
@@ -227,9 +220,8 @@ func EulerianPath(v, e int) *graph.Graph {
 
 	g := graph.NewGraph(v)
 	vertices := make([]int, e+1, e+1)
-	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < e+1; i++ {
-		vertices[i] = rand.Intn(v)
+		vertices[i] = gen.random.Intn(v)
 	}
 	for i := 0; i < e; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -240,7 +232,7 @@ func EulerianPath(v, e int) *graph.Graph {
 
 // Wheel
 // returns  a wheel graph on V vertices.
-func Wheel(v int) *graph.Graph {
+func (gen *Generator) Wheel(v int) *graph.Graph {
 	if v <= 1 {
 		log.Fatalln("Number of vertices must be at least 2")
 	}
@@ -249,7 +241,7 @@ func Wheel(v int) *graph.Graph {
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	// simple cycle on V-1 vertices
 	for i := 1; i < v-1; i++ {
@@ -267,7 +259,7 @@ func Wheel(v int) *graph.Graph {
 
 // Star
 // Returns a star graph on v vertices.
-func Star(v int) *graph.Graph {
+func (gen *Generator) Star(v int) *graph.Graph {
 	if v <= 0 {
 		log.Fatalln("Number of vertices must be at least 1")
 	}
@@ -276,7 +268,7 @@ func Star(v int) *graph.Graph {
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	// connect vertices[0] to every other vertex
 	for i := 1; i < v; i++ {
@@ -289,7 +281,7 @@ func Star(v int) *graph.Graph {
 // Returns a uniformly random k-regular graph on v vertices
 // (not necessarily simple). The graph is simple with probability only about e^(-k^2/4),
 // which is tiny when k = 14.
-func Regular(v, k int) *graph.Graph {
+func (gen *Generator) Regular(v, k int) *graph.Graph {
 	if v*k % 2 != 0 {
 		log.Fatalln("Number of vertices * k must be even")
 	}
@@ -304,7 +296,7 @@ func Regular(v, k int) *graph.Graph {
 	}
 
 	// pick a random perfect matching
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 	for i := 0; i < g.V*k/2; i++ {
 		g.AddEdge(vertices[2*i], vertices[2*i+1])
 	}
@@ -316,21 +308,19 @@ func Regular(v, k int) *graph.Graph {
 // http://citeseerx.ist.psu.edu/viewdoc/download?doi=10.1.1.36.6484&rep=rep1&type=pdf
 // Returns a uniformly random tree on V vertices.
 // This algorithm uses a Prufer sequence and takes time proportional to V log V.
-func Tree(v int) *graph.Graph {
+func (gen *Generator) Tree(v int) *graph.Graph {
 	g := graph.NewGraph(v)
 
 	// special case
 	if v == 1 { return g }
 
-	rand.Seed(time.Now().UnixNano())
-
 	// Cayley's theorem: there are V^(V-2) labeled trees on V vertices
 	// Prufer sequence: sequence of V-2 values between 0 and V-1
 	// Prufer's proof of Cayley's theorem: Prufer sequences are in 1-1
 	// with labeled trees on V vertices
 	prufer := make([]int, v-2, v-2)
 	for i := 0; i < v-2; i++ {
-		prufer[i] = rand.Intn(v)
+		prufer[i] = gen.random.Intn(v)
 	}
 
 	// degree of vertex v = 1 + number of times it appers in Prufer sequence
@@ -2,12 +2,9 @@ package digraph_generator
 
 import (
 	graph "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
-	"github.com/lee-hen/Algorithms/util"
 
 	"fmt"
 	"log"
-	"math/rand"
-	"time"
 )
 
 type Edge struct {
@@ -24,7 +21,7 @@ func newEdge(v, w int) *Edge {
 
 // Simple
 // Returns a random simple digraph containing V vertices and E edges.
-func Simple(v, e int) *graph.Digraph {
+func (gen *Generator) Simple(v, e int) *graph.Digraph {
 	if e > v*(v-1) {
 		log.Fatalln("Too many edges")
 	}
@@ -33,10 +30,8 @@ func Simple(v, e int) *graph.Digraph {
 	}
 	g := graph.NewDigraph(v)
 	set := make(map[string]struct{})
-
-	rand.Seed(time.Now().UnixNano())
 	for g.E < e {
-		v, w := rand.Intn(v), rand.Intn(v)
+		v, w := gen.random.Intn(v), gen.random.Intn(v)
 		edge := newEdge(v, w)
 		if _, ok := set[key(edge)]; !ok && v != w {
 			set[key(edge)] = struct{}{}
@@ -51,14 +46,14 @@ func Simple(v, e int) *graph.Digraph {
 // edge between any two vertices with probability p. This is sometimes
 // referred to as the Erdos-Renyi random digraph model.
 // This implementations takes time propotional to V^2 (even if p is small).
-func SimpleProb(v int, p float64) *graph.Digraph {
+func (gen *Generator) SimpleProb(v int, p float64) *graph.Digraph {
 	if p < 0.0 || p > 1.0 {
 		log.Fatalln("Probability must be between 0 and 1")
 	}
 	g := graph.NewDigraph(v)
 	for v := 0; v < g.V; v++ {
 		for w := v + 1; w < g.V; w++ {
-			if util.Bernoulli(p) {
+			if gen.random.Bernoulli(p) {
 				g.AddEdge(v, w)
 			}
 		}
@@ -71,7 +66,7 @@ func SimpleProb(v int, p float64) *graph.Digraph {
 // Returns the complete digraph on v vertices.
 // In a complete digraph, every pair of distinct vertices is connected
 // by two antiparallel edges. There are V*(V-1) edges.
-func Complete(v int) *graph.Digraph {
+func (gen *Generator) Complete(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 	for v := 0; v < g.V; v++ {
 		for w := 0; w < v; w++ {
@@ -87,7 +82,7 @@ func Complete(v int) *graph.Digraph {
 // Dag
 // Returns a random simple DAG containing v vertices and e edges.
 // Note: it is not uniformly selected at random among all such DAGs.
-func Dag(v, e int) *graph.Digraph {
+func (gen *Generator) Dag(v, e int) *graph.Digraph {
 	if e > v*(v-1)/2 {
 		log.Fatalln("Too many edges")
 	}
@@ -101,13 +96,11 @@ func Dag(v, e int) *graph.Digraph {
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
-
-	rand.Seed(time.Now().UnixNano())
+	gen.random.ShuffleIntSlice(vertices)
 	V := g.V
 	for g.E < e {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
+		v := gen.random.Intn(V)
+		w := gen.random.Intn(V)
 		edge := newEdge(v, w)
 		if _, ok := set[key(edge)]; !ok && v < w {
 			set[key(edge)] = struct{}{}
@@ -121,12 +114,11 @@ func Dag(v, e int) *graph.Digraph {
 // Returns a random tournament digraph on V vertices. A tournament digraph
 // is a digraph in which, for every pair of vertices, there is one and only one
 // directed edge connecting them. A tournament is an oriented complete graph.
-func Tournament(v int) *graph.Digraph {
+func (gen *Generator) Tournament(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
-	rand.Seed(time.Now().UnixNano())
 	for v := 0; v < g.V; v++ {
 		for w := v + 1; w < g.V; w++ {
-			if util.Bernoulli(0.5) {
+			if gen.random.Bernoulli(0.5) {
 				g.AddEdge(v, w)
 			} else {
 				g.AddEdge(w, v)
@@ -142,13 +134,13 @@ func Tournament(v int) *graph.Digraph {
 // A rooted in-tree is a DAG in which there is a single vertex
 // reachable from every other vertex. A complete rooted in-DAG
 // has V*(V-1)/2 edges.
-func CompleteRootedInDAG(v int) *graph.Digraph {
+func (gen *Generator) CompleteRootedInDAG(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 	vertices := make([]int, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 	for i := 0; i < g.V; i++ {
 		for j := i + 1; j < g.V; j++ {
 			g.AddEdge(vertices[i], vertices[j])
@@ -162,7 +154,7 @@ func CompleteRootedInDAG(v int) *graph.Digraph {
 // A rooted in-tree is a DAG in which there is a single vertex
 // reachable from every other vertex.
 // The DAG returned is not chosen uniformly at random among all such DAGs.
-func RootedInDAG(v, e int) *graph.Digraph {
+func (gen *Generator) RootedInDAG(v, e int) *graph.Digraph {
 	if e > v*(v-1)/2 {
 		log.Fatalln("Too many edges")
 	}
@@ -178,14 +170,13 @@ func RootedInDAG(v, e int) *graph.Digraph {
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
-	rand.Seed(time.Now().UnixNano())
+	gen.random.ShuffleIntSlice(vertices)
 
 	// one edge pointing from each vertex, other than the root = vertices[V-1]
 	for v := 0; v < g.V-1; v++ {
 		min := v + 1
 		max := g.V
-		w := rand.Intn(max-min) + min
+		w := gen.random.Intn(max-min) + min
 		edge := newEdge(v, w)
 		set[key(edge)] = struct{}{}
 		g.AddEdge(vertices[v], vertices[w])
@@ -193,8 +184,8 @@ func RootedInDAG(v, e int) *graph.Digraph {
 
 	V := g.V
 	for g.E < e {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
+		v := gen.random.Intn(V)
+		w := gen.random.Intn(V)
 		edge := newEdge(v, w)
 		if _, ok := set[key(edge)]; !ok && v < w {
 			set[key(edge)] = struct{}{}
@@ -208,14 +199,14 @@ func RootedInDAG(v, e int) *graph.Digraph {
 // Returns a complete rooted-out DAG on V vertices.
 // A rooted out-tree is a DAG in which every vertex is reachable
 // from a single vertex. A complete rooted in-DAG has V*(V-1)/2 edges.
-func CompleteRootedOutDAG(v, e int) *graph.Digraph {
+func (gen *Generator) CompleteRootedOutDAG(v, e int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 
 	vertices := make([]int, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 	for i := 0; i < g.V; i++ {
 		for j := i + 1; j < g.V; j++ {
 			g.AddEdge(vertices[j], vertices[i])
@@ -230,7 +221,7 @@ func CompleteRootedOutDAG(v, e int) *graph.Digraph {
 // A rooted out-tree is a DAG in which every vertex is reachable from a
 // single vertex.
 // The DAG returned is not chosen uniformly at random among all such DAGs.
-func RootedOutDAG(v, e int) *graph.Digraph {
+func (gen *Generator) RootedOutDAG(v, e int) *graph.Digraph {
 	if e > v*(v-1)/2 {
 		log.Fatalln("Too many edges")
 	}
@@ -246,14 +237,13 @@ func RootedOutDAG(v, e int) *graph.Digraph {
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
-	rand.Seed(time.Now().UnixNano())
+	gen.random.ShuffleIntSlice(vertices)
 
 	// one edge pointing from each vertex, other than the root = vertices[V-1]
 	for v := 0; v < g.V-1; v++ {
 		min := v + 1
 		max := g.V
-		w := rand.Intn(max-min) + min
+		w := gen.random.Intn(max-min) + min
 		edge := newEdge(w, v)
 		set[key(edge)] = struct{}{}
 		g.AddEdge(vertices[w], vertices[v])
@@ -261,8 +251,8 @@ func RootedOutDAG(v, e int) *graph.Digraph {
 
 	V := g.V
 	for g.E < e {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
+		v := gen.random.Intn(V)
+		w := gen.random.Intn(V)
 		edge := newEdge(w, v)
 		if _, ok := set[key(edge)]; !ok && v < w {
 			set[key(edge)] = struct{}{}
@@ -277,8 +267,8 @@ func RootedOutDAG(v, e int) *graph.Digraph {
 // A rooted in-tree is an oriented tree in which there is a single vertex
 // reachable from every other vertex.
 // The tree returned is not chosen uniformly at random among all such trees.
-func RootedInTree(v int) *graph.Digraph {
-	return RootedInDAG(v, v-1)
+func (gen *Generator) RootedInTree(v int) *graph.Digraph {
+	return gen.RootedInDAG(v, v-1)
 }
 
 // RootedOutTree
@@ -286,19 +276,19 @@ func RootedInTree(v int) *graph.Digraph {
 // is an oriented tree in which each vertex is reachable from a single vertex.
 // It is also known as a arborescence or branching
 // The tree returned is not chosen uniformly at random among all such trees.
-func RootedOutTree(v int) *graph.Digraph {
-	return RootedOutDAG(v, v-1)
+func (gen *Generator) RootedOutTree(v int) *graph.Digraph {
+	return gen.RootedOutDAG(v, v-1)
 }
 
 // Path
 // Returns a path digraph on V vertices.
-func Path(v int) *graph.Digraph {
+func (gen *Generator) Path(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 0; i < v-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -309,13 +299,13 @@ func Path(v int) *graph.Digraph {
 
 // BinaryTree
 // Returns a complete binary tree digraph on v vertices.
-func BinaryTree(v int) *graph.Digraph {
+func (gen *Generator) BinaryTree(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 1; i < v; i++ {
 		g.AddEdge(vertices[i], vertices[i-1]/2)
@@ -325,13 +315,13 @@ func BinaryTree(v int) *graph.Digraph {
 
 // Cycle
 // Returns a cycle digraph on v vertices.
-func Cycle(v int) *graph.Digraph {
+func (gen *Generator) Cycle(v int) *graph.Digraph {
 	g := graph.NewDigraph(v)
 	vertices := make([]int, v, v)
 	for i := 0; i < v; i++ {
 		vertices[i] = i
 	}
-	util.ShuffleIntSlice(vertices)
+	gen.random.ShuffleIntSlice(vertices)
 
 	for i := 0; i < v-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -343,7 +333,7 @@ func Cycle(v int) *graph.Digraph {
 
 // EulerianCycle
 // Returns an Eulerian cycle digraph on v vertices.
-func EulerianCycle(v, e int) *graph.Digraph {
+func (gen *Generator) EulerianCycle(v, e int) *graph.Digraph {
 	if e <= 0 {
 		log.Fatalln("An Eulerian cycle must have at least one edge")
 	}
@@ -354,9 +344,8 @@ func EulerianCycle(v, e int) *graph.Digraph {
 
 	g := graph.NewDigraph(v)
 	vertices := make([]int, e, e)
-	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < e; i++ {
-		vertices[i] = rand.Intn(v)
+		vertices[i] = gen.random.Intn(v)
 	}
 	for i := 0; i < e-1; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -368,7 +357,7 @@ func EulerianCycle(v, e int) *graph.Digraph {
 
 // EulerianPath
 // Returns an Eulerian cycle digraph on v vertices.
-func EulerianPath(v, e int) *graph.Digraph {
+func (gen *Generator) EulerianPath(v, e int) *graph.Digraph {
 	if e < 0 {
 		log.Fatalln("An Eulerian cycle must have at least one edge")
 	}
@@ -379,9 +368,8 @@ func EulerianPath(v, e int) *graph.Digraph {
 
 	g := graph.NewDigraph(v)
 	vertices := make([]int, e+1, e+1)
-	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < e+1; i++ {
-		vertices[i] = rand.Intn(v)
+		vertices[i] = gen.random.Intn(v)
 	}
 	for i := 0; i < e; i++ {
 		g.AddEdge(vertices[i], vertices[i+1])
@@ -399,7 +387,7 @@ func EulerianPath(v, e int) *graph.Digraph {
 // the same labels or from a vetex with a smaller label to a vertex with a
 // larger label). The number of components will be equal to the number of
 // distinct labels that are assigned to vertices.
-func Strong(v, e, c int) *graph.Digraph {
+func (gen *Generator) Strong(v, e, c int) *graph.Digraph {
 	if c >= v || c <= 0 {
 		log.Fatalln("Number of components must be between 1 and V")
 	}
@@ -417,10 +405,9 @@ func Strong(v, e, c int) *graph.Digraph {
 
 	// edges added to G (to avoid duplicate edges)
 	set := make(map[string]struct{})
-	rand.Seed(time.Now().UnixNano())
 	label := make([]int, v)
 	for v := 0; v < g.V; v++ {
-		label[v] = rand.Intn(c)
+		label[v] = gen.random.Intn(c)
 	}
 
 	// make all vertices with label c a strong component by
@@ -443,13 +430,13 @@ func Strong(v, e, c int) *graph.Digraph {
 				j++
 			}
 		}
-		util.ShuffleIntSlice(vertices)
+		gen.random.ShuffleIntSlice(vertices)
 
 		// rooted-in tree with root = vertices[count-1]
 		for v := 0; v < count-1; v++ {
 			min := v + 1
 			max := count
-			w := rand.Intn(max-min+1) + min
+			w := gen.random.Intn(max-min) + min
 			edge := newEdge(w, v)
 			set[key(edge)] = struct{}{}
 			g.AddEdge(vertices[w], vertices[v])
@@ -459,7 +446,7 @@ func Strong(v, e, c int) *graph.Digraph {
 		for v := 0; v < count-1; v++ {
 			min := v + 1
 			max := count
-			w := rand.Intn(max-min+1) + min
+			w := gen.random.Intn(max-min) + min
 			edge := newEdge(v, w)
 			set[key(edge)] = struct{}{}
 			g.AddEdge(vertices[v], vertices[w])
@@ -468,8 +455,8 @@ func Strong(v, e, c int) *graph.Digraph {
 
 	V := g.V
 	for g.E < e {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
+		v := gen.random.Intn(V)
+		w := gen.random.Intn(V)
 		edge := newEdge(v, w)
 		if _, ok := set[key(edge)]; !ok && v != w && label[v] <= label[w] {
 			set[key(edge)] = struct{}{}
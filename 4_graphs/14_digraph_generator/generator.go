@@ -0,0 +1,125 @@
+package digraph_generator
+
+import (
+	graph "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
+	"github.com/lee-hen/Algorithms/util"
+)
+
+// Generator
+// Generates random digraphs, drawing from its own util.Random, so that the digraphs generated
+// replay from the seed of that Random. The functions of the package draw from util.Default().
+type Generator struct {
+	random *util.Random
+}
+
+// New
+// Returns a Generator that draws from random.
+func New(random *util.Random) *Generator {
+	return &Generator{random: random}
+}
+
+func defaultGenerator() *Generator {
+	return New(util.Default())
+}
+
+// Simple
+// As Generator.Simple, drawing from util.Default().
+func Simple(v, e int) *graph.Digraph {
+	return defaultGenerator().Simple(v, e)
+}
+
+// SimpleProb
+// As Generator.SimpleProb, drawing from util.Default().
+func SimpleProb(v int, p float64) *graph.Digraph {
+	return defaultGenerator().SimpleProb(v, p)
+}
+
+// Complete
+// As Generator.Complete, drawing from util.Default().
+func Complete(v int) *graph.Digraph {
+	return defaultGenerator().Complete(v)
+}
+
+// Dag
+// As Generator.Dag, drawing from util.Default().
+func Dag(v, e int) *graph.Digraph {
+	return defaultGenerator().Dag(v, e)
+}
+
+// Tournament
+// As Generator.Tournament, drawing from util.Default().
+func Tournament(v int) *graph.Digraph {
+	return defaultGenerator().Tournament(v)
+}
+
+// CompleteRootedInDAG
+// As Generator.CompleteRootedInDAG, drawing from util.Default().
+func CompleteRootedInDAG(v int) *graph.Digraph {
+	return defaultGenerator().CompleteRootedInDAG(v)
+}
+
+// RootedInDAG
+// As Generator.RootedInDAG, drawing from util.Default().
+func RootedInDAG(v, e int) *graph.Digraph {
+	return defaultGenerator().RootedInDAG(v, e)
+}
+
+// CompleteRootedOutDAG
+// As Generator.CompleteRootedOutDAG, drawing from util.Default().
+func CompleteRootedOutDAG(v, e int) *graph.Digraph {
+	return defaultGenerator().CompleteRootedOutDAG(v, e)
+}
+
+// RootedOutDAG
+// As Generator.RootedOutDAG, drawing from util.Default().
+func RootedOutDAG(v, e int) *graph.Digraph {
+	return defaultGenerator().RootedOutDAG(v, e)
+}
+
+// RootedInTree
+// As Generator.RootedInTree, drawing from util.Default().
+func RootedInTree(v int) *graph.Digraph {
+	return defaultGenerator().RootedInTree(v)
+}
+
+// RootedOutTree
+// As Generator.RootedOutTree, drawing from util.Default().
+func RootedOutTree(v int) *graph.Digraph {
+	return defaultGenerator().RootedOutTree(v)
+}
+
+// Path
+// As Generator.Path, drawing from util.Default().
+func Path(v int) *graph.Digraph {
+	return defaultGenerator().Path(v)
+}
+
+// BinaryTree
+// As Generator.BinaryTree, drawing from util.Default().
+func BinaryTree(v int) *graph.Digraph {
+	return defaultGenerator().BinaryTree(v)
+}
+
+// Cycle
+// As Generator.Cycle, drawing from util.Default().
+func Cycle(v int) *graph.Digraph {
+	return defaultGenerator().Cycle(v)
+}
+
+// EulerianCycle
+// As Generator.EulerianCycle, drawing from util.Default().
+func EulerianCycle(v, e int) *graph.Digraph {
+	return defaultGenerator().EulerianCycle(v, e)
+}
+
+// EulerianPath
+// As Generator.EulerianPath, drawing from util.Default().
+func EulerianPath(v, e int) *graph.Digraph {
+	return defaultGenerator().EulerianPath(v, e)
+}
+
+// Strong
+// As Generator.Strong, drawing from util.Default().
+func Strong(v, e, c int) *graph.Digraph {
+	return defaultGenerator().Strong(v, e, c)
+}
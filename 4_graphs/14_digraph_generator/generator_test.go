@@ -0,0 +1,45 @@
+package digraph_generator
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// every random digraph of the generator, printed
+func generate(gen *Generator) []string {
+	var digraphs []string
+	for _, g := range []interface{ String() string }{
+		gen.Simple(10, 20),
+		gen.SimpleProb(10, 0.3),
+		gen.Complete(6),
+		gen.Dag(10, 20),
+		gen.Tournament(8),
+		gen.CompleteRootedInDAG(8),
+		gen.RootedInDAG(10, 15),
+		gen.CompleteRootedOutDAG(8, 10),
+		gen.RootedOutDAG(10, 15),
+		gen.RootedInTree(10),
+		gen.RootedOutTree(10),
+		gen.Path(10),
+		gen.BinaryTree(10),
+		gen.Cycle(10),
+		gen.EulerianCycle(10, 15),
+		gen.EulerianPath(10, 15),
+		gen.Strong(12, 30, 3),
+	} {
+		digraphs = append(digraphs, g.String())
+	}
+	return digraphs
+}
+
+func TestCase1(t *testing.T) {
+	// the digraphs replay from the seed of the Random
+	digraphs := generate(New(util.NewRandom(5)))
+	require.Equal(t, digraphs, generate(New(util.NewRandom(5))))
+	require.NotEqual(t, digraphs, generate(New(util.NewRandom(6))))
+
+	util.Seed(5)
+	require.Equal(t, digraphs[0], Simple(10, 20).String())
+}
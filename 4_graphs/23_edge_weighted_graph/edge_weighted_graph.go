@@ -2,13 +2,12 @@ package edge_weighted_graph
 
 import (
 	edge "github.com/lee-hen/Algorithms/4_graphs/21_edge"
+	"github.com/lee-hen/Algorithms/util"
 
 	"fmt"
 	"log"
 	"math"
-	"math/rand"
 	"strings"
-	"time"
 )
 
 const NEWLINE = "\n"
@@ -35,17 +34,22 @@ func NewEdgeWeightedGraph(v int) *EdgeWeightedGraph {
 // NewRandomEdgeWeightedGraph
 // Initializes a random edge-weighted graph with v vertices and e edges.
 func NewRandomEdgeWeightedGraph(v, e int) *EdgeWeightedGraph {
+	return NewRandomEdgeWeightedGraphFrom(util.Default(), v, e)
+}
+
+// NewRandomEdgeWeightedGraphFrom
+// Initializes a random edge-weighted graph with v vertices and e edges, drawing from random.
+func NewRandomEdgeWeightedGraphFrom(random *util.Random, v, e int) *EdgeWeightedGraph {
 	g := NewEdgeWeightedGraph(v)
 	if e < 0 {
 		log.Fatalln("Number of edges must be non-negative")
 	}
 
-	rand.Seed(time.Now().UnixNano())
 	V := g.V
 	for i := 0; i < e; i++ {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
-		weight :=  math.Round(rand.Float64() * 100) / 100.0
+		v := random.Intn(V)
+		w := random.Intn(V)
+		weight :=  math.Round(random.Float64() * 100) / 100.0
 
 		e := edge.NewEdge(v, w, weight)
 		g.AddEdge(e)
@@ -0,0 +1,22 @@
+package edge_weighted_graph
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	// the graph replays from the seed of the Random
+	g := NewRandomEdgeWeightedGraphFrom(util.NewRandom(9), 10, 25)
+	require.Equal(t, g.String(), NewRandomEdgeWeightedGraphFrom(util.NewRandom(9), 10, 25).String())
+	require.NotEqual(t, g.String(), NewRandomEdgeWeightedGraphFrom(util.NewRandom(10), 10, 25).String())
+	require.Len(t, g.Edges(), 25)
+	for _, e := range g.Edges() {
+		require.True(t, e.Weight() >= 0 && e.Weight() < 1)
+	}
+
+	util.Seed(9)
+	require.Equal(t, g.String(), NewRandomEdgeWeightedGraph(10, 25).String())
+}
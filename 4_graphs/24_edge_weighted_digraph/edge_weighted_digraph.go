@@ -2,12 +2,11 @@ package edge_weighted_digraph
 
 import (
 	directedEdge "github.com/lee-hen/Algorithms/4_graphs/22_directed_edge"
+	"github.com/lee-hen/Algorithms/util"
 
 	"fmt"
 	"log"
-	"math/rand"
 	"strings"
-	"time"
 )
 
 const NEWLINE = "\n"
@@ -37,17 +36,22 @@ func NewEdgeWeightedDigraph(v int) *EdgeWeightedDigraph {
 // NewRandomEdgeWeightedDigraph
 // Initializes a random edge-weighted digraph with v vertices and e edges.
 func NewRandomEdgeWeightedDigraph(v, e int) *EdgeWeightedDigraph {
+	return NewRandomEdgeWeightedDigraphFrom(util.Default(), v, e)
+}
+
+// NewRandomEdgeWeightedDigraphFrom
+// Initializes a random edge-weighted digraph with v vertices and e edges, drawing from random.
+func NewRandomEdgeWeightedDigraphFrom(random *util.Random, v, e int) *EdgeWeightedDigraph {
 	g := NewEdgeWeightedDigraph(v)
 	if e < 0 {
 		log.Fatalln("Number of edges in a Digraph must be non-negative")
 	}
 
-	rand.Seed(time.Now().UnixNano())
 	V := g.V
 	for i := 0; i < e; i++ {
-		v := rand.Intn(V)
-		w := rand.Intn(V)
-		weight :=  0.01 * float64(rand.Intn(100))
+		v := random.Intn(V)
+		w := random.Intn(V)
+		weight :=  0.01 * float64(random.Intn(100))
 		g.AddEdge(directedEdge.NewEdge(v, w, weight))
 	}
 
@@ -21,19 +21,37 @@ type PrimMST struct {
 	distTo []float64
 
 	marked map[int]bool
-	pq *minPQ.IndexMinPQ
+	pq IndexMinPQ
+}
+
+// IndexMinPQ
+// The vertex-indexed priority queue operations used by Prim's algorithm, provided by
+// 22_index_min_pq.IndexMinPQ and by the heaps in 35_mergeable_heap.
+type IndexMinPQ interface {
+	IsEmpty() bool
+	Contains(v int) bool
+	Insert(v int, x float64)
+	DecreasePriority(v int, x float64)
+	DelMin() int
 }
 
 // New
 // Compute a minimum spanning tree (or forest) of an edge-weighted graph.
 // G the edge-weighted graph
 func New(g *graph.EdgeWeightedGraph) *PrimMST {
+	return NewWithPQ(g, minPQ.NewIndexMinPQ(g.V))
+}
+
+// NewWithPQ
+// Computes a minimum spanning forest like New, using the empty priority queue pq.
+// With a Fibonacci heap the running time is E + V log V rather than E log V.
+func NewWithPQ(g *graph.EdgeWeightedGraph, pq IndexMinPQ) *PrimMST {
 	pm := PrimMST{}
 
 	pm.edgeTo = make(map[int]*edge.Edge)
 	pm.distTo = make([]float64, g.V, g.V)
 	pm.marked = make(map[int]bool)
-	pm.pq = minPQ.NewIndexMinPQ(g.V)
+	pm.pq = pq
 	for v := 0; v < g.V; v++ {
 		pm.distTo[v] = math.MaxFloat64
 	}
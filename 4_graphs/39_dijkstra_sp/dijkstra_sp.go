@@ -53,13 +53,31 @@ type DijkstraSP struct {
 	distTo []float64                  // distTo[v] = distance  of shortest s->v path
 	edgeTo map[int]*directedEdge.Edge // edgeTo[v] = last edge on shortest s->v path
 
-	pq *minPQ.IndexMinPQ
+	pq IndexMinPQ
+}
+
+// IndexMinPQ
+// The vertex-indexed priority queue operations used by Dijkstra's algorithm, provided by
+// 22_index_min_pq.IndexMinPQ and by the heaps in 35_mergeable_heap.
+type IndexMinPQ interface {
+	IsEmpty() bool
+	Contains(v int) bool
+	Insert(v int, x float64)
+	DecreasePriority(v int, x float64)
+	DelMin() int
 }
 
 // New
 // Computes a shortest-paths tree from the source vertex s to every other
 // vertex in the edge-weighted digraph G.
 func New(g *graph.EdgeWeightedDigraph, s int) *DijkstraSP {
+	return NewWithPQ(g, s, minPQ.NewIndexMinPQ(g.V))
+}
+
+// NewWithPQ
+// Computes a shortest-paths tree from s like New, using the empty priority queue pq.
+// With a Fibonacci heap the running time is E + V log V rather than E log V.
+func NewWithPQ(g *graph.EdgeWeightedDigraph, s int, pq IndexMinPQ) *DijkstraSP {
 	for _, e := range g.Edges() {
 		if e.Weight() < 0 {
 			log.Fatalln("edge ", e, "has negative weight")
@@ -77,7 +95,7 @@ func New(g *graph.EdgeWeightedDigraph, s int) *DijkstraSP {
 	sp.distTo[s] = 0.0
 
 	// relax vertices in order of distance from s
-	sp.pq = minPQ.NewIndexMinPQ(g.V)
+	sp.pq = pq
 	sp.pq.Insert(s, sp.distTo[s])
 
 	for !sp.pq.IsEmpty() {
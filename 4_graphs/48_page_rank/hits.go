@@ -0,0 +1,119 @@
+package page_rank
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+	D "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
+
+	"log"
+	"math"
+)
+
+// HITS (Kleinberg) gives every page two scores: a good authority is linked to by good hubs,
+// and a good hub links to good authorities. Starting from all ones, it alternates a <- A^T h
+// and h <- A a, normalizing both to unit length, where A is the adjacency matrix; the scores
+// converge to the principal eigenvectors of A^T A and A A^T. Unlike PageRank there is no
+// damping, so the scores of pages outside the dominant part of the graph go to 0, and a
+// graph whose A^T A has a repeated top eigenvalue has no unique answer.
+
+type HITS struct {
+	hub, authority []float64
+	iterations     int
+	converged      bool
+}
+
+// NewHITS
+// Computes the hub and authority scores of every vertex of the digraph g. WithTolerance
+// and WithMaxIterations apply; WithDamping and WithSeeds are ignored.
+func NewHITS(g *D.Digraph, opts ...Option) *HITS {
+	o := newOptions(opts)
+
+	adj := sv.NewSparseMatrix(g.V, g.V)
+	for v := 0; v < g.V; v++ {
+		for _, w := range g.Adj(v) {
+			adj.Put(v, w, adj.Get(v, w)+1)
+		}
+	}
+	a := adj.CSR()
+	at := a.Transpose()
+
+	h := &HITS{hub: make([]float64, g.V), authority: make([]float64, g.V)}
+	for v := range h.hub {
+		h.hub[v] = 1
+	}
+	normalize(h.hub)
+
+	for h.iterations < o.maxIterations && !h.converged {
+		authority := at.MulVec(h.hub)
+		normalize(authority)
+		hub := a.MulVec(authority)
+		normalize(hub)
+
+		change := 0.0
+		for v := range hub {
+			change += math.Abs(hub[v]-h.hub[v]) + math.Abs(authority[v]-h.authority[v])
+		}
+		h.hub, h.authority = hub, authority
+		h.iterations++
+		h.converged = change < o.tolerance
+	}
+	return h
+}
+
+// scale x to unit length, unless it is 0
+func normalize(x []float64) {
+	sum := 0.0
+	for _, value := range x {
+		sum += value * value
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for i := range x {
+		x[i] /= norm
+	}
+}
+
+// Hub
+// Returns the hub score of vertex v; the squares of the hub scores sum to 1.
+func (h *HITS) Hub(v int) float64 {
+	h.validateVertex(v)
+	return h.hub[v]
+}
+
+// Authority
+// Returns the authority score of vertex v; the squares of the authority scores sum to 1.
+func (h *HITS) Authority(v int) float64 {
+	h.validateVertex(v)
+	return h.authority[v]
+}
+
+// TopHubs
+// Returns the k vertices with the highest hub scores, highest first.
+func (h *HITS) TopHubs(k int) []int {
+	return top(h.hub, k)
+}
+
+// TopAuthorities
+// Returns the k vertices with the highest authority scores, highest first.
+func (h *HITS) TopAuthorities(k int) []int {
+	return top(h.authority, k)
+}
+
+// Iterations
+// Returns the number of iterations done.
+func (h *HITS) Iterations() int {
+	return h.iterations
+}
+
+// Converged
+// Returns true if the scores settled within the tolerance before the iteration cap.
+func (h *HITS) Converged() bool {
+	return h.converged
+}
+
+func (h *HITS) validateVertex(v int) {
+	if v < 0 || v >= len(h.hub) {
+		log.Fatalf("vertex %d is not between 0 and %d\n", v, len(h.hub)-1)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+	graph "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
+	symbolGraph "github.com/lee-hen/Algorithms/4_graphs/30_symbol_digraph"
+	pageRank "github.com/lee-hen/Algorithms/4_graphs/48_page_rank"
+
+	"fmt"
+	"os"
+)
+
+// a tiny web of 5 pages, with parallel links
+// 0 1
+// 1 2  1 2
+// 1 3  1 3  1 4
+// 2 3
+// 3 0
+// 4 0  4 2
+// with damping 0.9 the ranks are .2730 .2657 .1462 .2472 .0678
+func main() {
+	g := graph.NewDigraph(5)
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {1, 2}, {1, 3}, {1, 3}, {1, 4}, {2, 3}, {3, 0}, {4, 0}, {4, 2}} {
+		g.AddEdge(e[0], e[1])
+	}
+
+	pr := pageRank.NewPageRank(g, pageRank.WithDamping(0.9))
+	fmt.Printf("PageRank %.5f after %d iterations\n", pr.Ranks(), pr.Iterations())
+
+	personalized := pageRank.NewPageRank(g, pageRank.WithDamping(0.9), pageRank.WithSeeds(4))
+	fmt.Printf("PageRank from 4 %.5f\n", personalized.Ranks())
+
+	h := pageRank.NewHITS(g)
+	fmt.Println("hubs", h.TopHubs(5), "authorities", h.TopAuthorities(5))
+
+	// a random walk around a 3-cycle is periodic, but spends a third of its time in each state
+	cycle := sv.NewSparseMatrix(3, 3)
+	cycle.Put(0, 1, 1)
+	cycle.Put(1, 2, 1)
+	cycle.Put(2, 0, 1)
+	sd := pageRank.NewStationaryDistribution(cycle.CSR())
+	fmt.Printf("stationary distribution %.5f\n", sd.Distribution())
+	fmt.Println("----------------------------")
+
+	pwd, _ := os.Getwd()
+	f, _ := os.Open(pwd + "/data/digraph/routes.txt")
+	defer f.Close()
+	sg := symbolGraph.New(f, " ")
+	pr = pageRank.NewPageRank(sg.Graph())
+	for _, v := range pr.Top(5) {
+		fmt.Printf("%s %.5f\n", sg.NameOf(v), pr.Rank(v))
+	}
+
+	// ranks by airport name, jumping only back to JFK
+	ranks := pageRank.RanksByName(sg, pageRank.WithSeeds(sg.IndexOf("JFK")))
+	for _, name := range []string{"JFK", "ORD", "LAX"} {
+		fmt.Printf("from JFK %s %.5f\n", name, ranks[name])
+	}
+}
@@ -0,0 +1,91 @@
+package page_rank
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+
+	"log"
+	"math"
+)
+
+// A Markov chain with transition matrix P (entry (i, j) is the probability of moving from
+// state i to state j, and every row sums to 1) has a stationary distribution π = π P, which is
+// unique when the chain is irreducible. Power iteration finds it from any start when the chain
+// is also aperiodic; to cover periodic chains, such as a walk around a cycle, it iterates the
+// lazy chain (I + P) / 2 instead, which has the same stationary distribution and is always
+// aperiodic. PageRank is the special case of a chain built from links and jumps.
+
+type StationaryDistribution struct {
+	pi         []float64
+	iterations int
+	converged  bool
+}
+
+// NewStationaryDistribution
+// Computes the stationary distribution of the Markov chain with the given row-stochastic
+// transition matrix. WithTolerance and WithMaxIterations apply; WithDamping and WithSeeds
+// are ignored.
+func NewStationaryDistribution(p *sv.CSR, opts ...Option) *StationaryDistribution {
+	if p.Rows() != p.Cols() {
+		log.Fatalln("transition matrix is not square")
+	}
+
+	n := p.Rows()
+	ones := make([]float64, n)
+	for i := range ones {
+		ones[i] = 1
+	}
+	for i, sum := range p.MulVec(ones) {
+		if math.Abs(sum-1) > 1e-9 {
+			log.Fatalf("row %d of the transition matrix sums to %g, not 1\n", i, sum)
+		}
+	}
+
+	o := newOptions(opts)
+	pt := p.Transpose()
+	sd := &StationaryDistribution{pi: make([]float64, n)}
+	for i := range sd.pi {
+		sd.pi[i] = 1.0 / float64(n)
+	}
+
+	for sd.iterations < o.maxIterations && !sd.converged {
+		next := pt.MulVec(sd.pi)
+		change := 0.0
+		for i := range next {
+			next[i] = (next[i] + sd.pi[i]) / 2
+			change += math.Abs(next[i] - sd.pi[i])
+		}
+		sd.pi = next
+		sd.iterations++
+		sd.converged = change < o.tolerance
+	}
+	return sd
+}
+
+// Probability
+// Returns the long-run probability of being in state i.
+func (sd *StationaryDistribution) Probability(i int) float64 {
+	if i < 0 || i >= len(sd.pi) {
+		log.Fatalf("state %d is not between 0 and %d\n", i, len(sd.pi)-1)
+	}
+	return sd.pi[i]
+}
+
+// Distribution
+// Returns the probabilities of all states, indexed by state.
+func (sd *StationaryDistribution) Distribution() []float64 {
+	pi := make([]float64, len(sd.pi))
+	copy(pi, sd.pi)
+	return pi
+}
+
+// Iterations
+// Returns the number of power iterations done.
+func (sd *StationaryDistribution) Iterations() int {
+	return sd.iterations
+}
+
+// Converged
+// Returns true if the distribution settled within the tolerance before the iteration cap.
+func (sd *StationaryDistribution) Converged() bool {
+	return sd.converged
+}
@@ -0,0 +1,219 @@
+package page_rank
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+	D "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
+	symbolGraph "github.com/lee-hen/Algorithms/4_graphs/30_symbol_digraph"
+
+	"log"
+	"math"
+	"sort"
+)
+
+// PageRank models a random surfer who, at each step, follows a random link out of the current
+// page with probability d (the damping factor) and otherwise jumps to a random page. The rank of
+// a page is the long-run fraction of time the surfer spends there: the stationary distribution
+// of that Markov chain, which exists and is unique because the jumps make it irreducible and
+// aperiodic. A page with no links out (a dangling page) would trap the surfer's probability, so
+// from there the surfer always jumps.
+// Power iteration computes r <- d P^T r + (1 - d + d·dangling mass) t from the uniform start,
+// where P is the transition matrix of the links and t is the jump distribution; P is kept in
+// compressed sparse row form, so each iteration takes time proportional to E + V, and the error
+// shrinks by a factor of d per iteration.
+// Personalized PageRank jumps only to a seed set, ranking pages by their proximity to it.
+
+type PageRank struct {
+	rank       []float64 // rank[v] = PageRank of v
+	iterations int       // number of power iterations done
+	converged  bool      // did the ranks settle within the tolerance?
+}
+
+// Option
+// Configures a link-analysis computation.
+type Option func(*options)
+
+type options struct {
+	damping       float64
+	tolerance     float64
+	maxIterations int
+	seeds         []int
+}
+
+// WithDamping
+// Sets the probability of following a link rather than jumping (default 0.85).
+func WithDamping(d float64) Option {
+	return func(o *options) {
+		if d < 0 || d >= 1 {
+			log.Fatalln("damping factor must be in [0, 1)")
+		}
+		o.damping = d
+	}
+}
+
+// WithTolerance
+// Stops the iteration when the ranks change by less than tolerance in L1 norm (default 1e-10).
+func WithTolerance(tolerance float64) Option {
+	return func(o *options) {
+		if tolerance <= 0 {
+			log.Fatalln("tolerance must be positive")
+		}
+		o.tolerance = tolerance
+	}
+}
+
+// WithMaxIterations
+// Caps the number of power iterations (default 1000).
+func WithMaxIterations(iterations int) Option {
+	return func(o *options) {
+		if iterations < 1 {
+			log.Fatalln("max iterations must be at least 1")
+		}
+		o.maxIterations = iterations
+	}
+}
+
+// WithSeeds
+// Makes the surfer jump only to the given vertices, for personalized PageRank.
+func WithSeeds(seeds ...int) Option {
+	return func(o *options) {
+		if len(seeds) == 0 {
+			log.Fatalln("seed set must not be empty")
+		}
+		o.seeds = seeds
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{damping: 0.85, tolerance: 1e-10, maxIterations: 1000}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewPageRank
+// Computes the PageRank of every vertex of the digraph g. Parallel edges count as
+// several links.
+func NewPageRank(g *D.Digraph, opts ...Option) *PageRank {
+	o := newOptions(opts)
+
+	// the jump distribution: uniform over the seeds, or over all vertices
+	teleport := make([]float64, g.V)
+	if o.seeds == nil {
+		for v := range teleport {
+			teleport[v] = 1.0 / float64(g.V)
+		}
+	} else {
+		for _, v := range o.seeds {
+			if v < 0 || v >= g.V {
+				log.Fatalf("seed %d is not between 0 and %d\n", v, g.V-1)
+			}
+			teleport[v] += 1.0 / float64(len(o.seeds))
+		}
+	}
+
+	pt := transitions(g).Transpose()
+	pr := &PageRank{rank: make([]float64, g.V)}
+	copy(pr.rank, teleport)
+	if g.V == 0 {
+		pr.converged = true
+		return pr
+	}
+
+	for pr.iterations < o.maxIterations && !pr.converged {
+		next := pt.MulVec(pr.rank)
+
+		// the mass that did not follow a link: the jumps, and everything on dangling vertices
+		dangling := 0.0
+		for v := 0; v < g.V; v++ {
+			if g.OutDegree(v) == 0 {
+				dangling += pr.rank[v]
+			}
+		}
+		jump := 1 - o.damping + o.damping*dangling
+
+		change := 0.0
+		for v := range next {
+			next[v] = o.damping*next[v] + jump*teleport[v]
+			change += math.Abs(next[v] - pr.rank[v])
+		}
+		pr.rank = next
+		pr.iterations++
+		pr.converged = change < o.tolerance
+	}
+	return pr
+}
+
+// the transition matrix of the links: entry (v, w) is the fraction of the links out of v that go to w
+func transitions(g *D.Digraph) *sv.CSR {
+	p := sv.NewSparseMatrix(g.V, g.V)
+	for v := 0; v < g.V; v++ {
+		for _, w := range g.Adj(v) {
+			p.Put(v, w, p.Get(v, w)+1.0/float64(g.OutDegree(v)))
+		}
+	}
+	return p.CSR()
+}
+
+// RanksByName
+// Computes the PageRank of every vertex of the symbol digraph sg and returns the ranks
+// keyed by vertex name. Seeds are given by index, as returned by sg.IndexOf.
+func RanksByName(sg *symbolGraph.SymbolGraph, opts ...Option) map[string]float64 {
+	pr := NewPageRank(sg.Graph(), opts...)
+	ranks := make(map[string]float64, len(pr.rank))
+	for v, rank := range pr.rank {
+		ranks[sg.NameOf(v)] = rank
+	}
+	return ranks
+}
+
+// Rank
+// Returns the PageRank of vertex v; the ranks sum to 1.
+func (pr *PageRank) Rank(v int) float64 {
+	pr.validateVertex(v)
+	return pr.rank[v]
+}
+
+// Ranks
+// Returns the PageRanks of all vertices, indexed by vertex.
+func (pr *PageRank) Ranks() []float64 {
+	ranks := make([]float64, len(pr.rank))
+	copy(ranks, pr.rank)
+	return ranks
+}
+
+// Top
+// Returns the k vertices of highest rank, highest first.
+func (pr *PageRank) Top(k int) []int {
+	return top(pr.rank, k)
+}
+
+// Iterations
+// Returns the number of power iterations done.
+func (pr *PageRank) Iterations() int {
+	return pr.iterations
+}
+
+// Converged
+// Returns true if the ranks settled within the tolerance before the iteration cap.
+func (pr *PageRank) Converged() bool {
+	return pr.converged
+}
+
+func (pr *PageRank) validateVertex(v int) {
+	if v < 0 || v >= len(pr.rank) {
+		log.Fatalf("vertex %d is not between 0 and %d\n", v, len(pr.rank)-1)
+	}
+}
+
+// the k indices of the largest scores, largest first, ties by index
+func top(scores []float64, k int) []int {
+	vertices := make([]int, len(scores))
+	for v := range vertices {
+		vertices[v] = v
+	}
+	sort.SliceStable(vertices, func(i, j int) bool {
+		return scores[vertices[i]] > scores[vertices[j]]
+	})
+	return vertices[:min(k, len(vertices))]
+}
@@ -0,0 +1,118 @@
+package page_rank
+
+import (
+	sv "github.com/lee-hen/Algorithms/3_searching/08_sparse_vector"
+	D "github.com/lee-hen/Algorithms/4_graphs/13_digraph"
+	symbolGraph "github.com/lee-hen/Algorithms/4_graphs/30_symbol_digraph"
+	"github.com/stretchr/testify/require"
+
+	"strings"
+	"testing"
+)
+
+// tinyDG.txt; vertex 1 has no links out
+func tinyDG() *D.Digraph {
+	g := D.NewDigraph(13)
+	for _, e := range [][2]int{
+		{4, 2}, {2, 3}, {3, 2}, {6, 0}, {0, 1}, {2, 0}, {11, 12}, {12, 9}, {9, 10}, {9, 11}, {7, 9},
+		{10, 12}, {11, 4}, {4, 3}, {3, 5}, {6, 8}, {8, 6}, {5, 4}, {0, 5}, {6, 4}, {6, 9}, {7, 6},
+	} {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func sum(x []float64) float64 {
+	s := 0.0
+	for _, value := range x {
+		s += value
+	}
+	return s
+}
+
+func TestCase1(t *testing.T) {
+	pr := NewPageRank(tinyDG())
+	require.True(t, pr.Converged())
+	require.InDelta(t, 1, sum(pr.Ranks()), 1e-9)
+
+	// the solution of the linear system, with the rank of the dangling vertex spread evenly
+	expected := []float64{
+		0.075896, 0.046858, 0.123950, 0.123950, 0.133338, 0.099537, 0.040543,
+		0.014602, 0.023218, 0.106897, 0.060033, 0.060033, 0.091145,
+	}
+	for v, rank := range expected {
+		require.InDelta(t, rank, pr.Rank(v), 1e-6)
+	}
+	require.Equal(t, []int{4}, pr.Top(1))
+}
+
+func TestCase2(t *testing.T) {
+	// 6, 7 and 8 cannot be reached from 11
+	pr := NewPageRank(tinyDG(), WithSeeds(11))
+	require.True(t, pr.Converged())
+	require.InDelta(t, 1, sum(pr.Ranks()), 1e-9)
+	for v := 0; v < 13; v++ {
+		if v == 6 || v == 7 || v == 8 {
+			require.Zero(t, pr.Rank(v))
+		} else {
+			require.Positive(t, pr.Rank(v))
+		}
+	}
+
+	// a dangling seed keeps all of the mass
+	pr = NewPageRank(tinyDG(), WithSeeds(1))
+	require.InDelta(t, 1, pr.Rank(1), 1e-9)
+}
+
+func TestCase3(t *testing.T) {
+	h := NewHITS(tinyDG())
+	require.True(t, h.Converged())
+
+	hubs, authorities := 0.0, 0.0
+	for v := 0; v < 13; v++ {
+		hubs += h.Hub(v) * h.Hub(v)
+		authorities += h.Authority(v) * h.Authority(v)
+	}
+	require.InDelta(t, 1, hubs, 1e-9)
+	require.InDelta(t, 1, authorities, 1e-9)
+
+	// 1 links nowhere and 7 is linked from nowhere
+	require.Zero(t, h.Hub(1))
+	require.Zero(t, h.Authority(7))
+}
+
+func TestCase4(t *testing.T) {
+	// a chain of period 2: 0 -> 1, 1 -> 0 or 2, 2 -> 1
+	p := sv.NewSparseMatrix(3, 3)
+	p.Put(0, 1, 1)
+	p.Put(1, 0, 0.5)
+	p.Put(1, 2, 0.5)
+	p.Put(2, 1, 1)
+
+	sd := NewStationaryDistribution(p.CSR())
+	require.True(t, sd.Converged())
+	require.InDeltaSlice(t, []float64{0.25, 0.5, 0.25}, sd.Distribution(), 1e-9)
+}
+
+func TestCase5(t *testing.T) {
+	routes := "JFK MCO\nORD DEN\nORD HOU\nDFW PHX\nJFK ATL\nORD DFW\nORD PHX\nATL HOU\nDEN PHX\n" +
+		"PHX LAX\nJFK ORD\nDEN LAS\nDFW HOU\nORD ATL\nLAS LAX\nATL MCO\nHOU MCO\nLAS PHX\n"
+	sg := symbolGraph.New(strings.NewReader(routes), " ")
+
+	ranks := RanksByName(sg)
+	pr := NewPageRank(sg.Graph())
+	require.Len(t, ranks, sg.Graph().V)
+	for v := 0; v < sg.Graph().V; v++ {
+		require.Equal(t, pr.Rank(v), ranks[sg.NameOf(v)])
+	}
+
+	// no route leads back to JFK, so from JFK the surfer keeps jumping back
+	ranks = RanksByName(sg, WithSeeds(sg.IndexOf("JFK")))
+	top := ""
+	for name, rank := range ranks {
+		if top == "" || rank > ranks[top] {
+			top = name
+		}
+	}
+	require.Equal(t, "JFK", top)
+}
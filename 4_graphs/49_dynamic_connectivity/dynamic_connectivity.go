@@ -0,0 +1,308 @@
+package dynamic_connectivity
+
+import (
+	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
+
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+// Fully dynamic connectivity (Holm, de Lichtenberg and Thorup) keeps a spanning forest of a
+// graph whose edges come and go, in O(log² V) amortized time per update and O(log V) per query.
+// Every edge has a level, 0 when it is added, that only goes up, and the level-i forest F_i is
+// the spanning forest restricted to the tree edges of level at least i, so that F_0 is the
+// whole spanning forest. Invariant: every tree of F_i has at most V / 2^i vertices, so no level
+// exceeds lg V.
+// Removing a non-tree edge changes nothing. Removing a tree edge of level l splits a tree of
+// F_0 through F_l; a replacement edge, if any, is a non-tree edge of level at most l between
+// the two halves. From level l down to 0, the smaller half T of the tree at that level has at
+// most half the vertices allowed there, so the tree edges of T move up a level, and then the
+// non-tree edges at the level touching T are scanned: the first that leaves T reconnects the
+// halves, and every other one, with both ends in T, moves up a level. Each move of an edge
+// pays for its scan, and an edge moves at most lg V times.
+// The forests are Euler tour trees, in which each vertex is marked if it has non-tree edges
+// of the level and each arc if its edge is a tree edge of exactly the level, so that the
+// next edge to scan or move is found in O(log V).
+
+type DynamicConnectivity struct {
+	v, e    int
+	count   int              // number of connected components
+	forests []*forest        // forests[i] = F_i
+	nonTree [][]map[int]bool // nonTree[i][v] = other ends of the non-tree edges of level i at v
+	edges   map[[2]int]*edge // edges[{v, w}], v <= w, = the edges between v and w
+}
+
+// the parallel edges between two vertices, which are one edge for connectivity
+type edge struct {
+	count int  // number of parallel edges
+	level int  // level of the edge
+	tree  bool // is the edge in the spanning forest?
+}
+
+// Option
+// Configures a DynamicConnectivity at construction.
+type Option func(*options)
+
+type options struct {
+	seed int64
+}
+
+// WithSeed
+// Sets the seed of the random treap priorities of the Euler tour trees (default 0).
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// NewDynamicConnectivity
+// Initializes a dynamic connectivity structure for a graph with v vertices and no edges.
+func NewDynamicConnectivity(v int, opts ...Option) *DynamicConnectivity {
+	if v < 0 {
+		panic("number of vertices must be non-negative")
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := rand.New(rand.NewSource(o.seed))
+
+	levels := bits.Len(uint(v)) + 1
+	dc := &DynamicConnectivity{
+		v:       v,
+		count:   v,
+		forests: make([]*forest, levels),
+		nonTree: make([][]map[int]bool, levels),
+		edges:   make(map[[2]int]*edge),
+	}
+	for i := range dc.forests {
+		dc.forests[i] = newForest(v, r)
+		dc.nonTree[i] = make([]map[int]bool, v)
+	}
+	return dc
+}
+
+// Init
+// Initializes a dynamic connectivity structure with the vertices and edges of the graph g.
+func Init(g *graph.Graph, opts ...Option) *DynamicConnectivity {
+	dc := NewDynamicConnectivity(g.V, opts...)
+	for v := 0; v < g.V; v++ {
+		selfLoops := 0
+		for _, w := range g.Adj(v) {
+			if v < w {
+				dc.AddEdge(v, w)
+			} else if v == w {
+				// a self-loop appears twice in the adjacency list of v
+				if selfLoops%2 == 0 {
+					dc.AddEdge(v, w)
+				}
+				selfLoops++
+			}
+		}
+	}
+	return dc
+}
+
+// V
+// Returns the number of vertices.
+func (dc *DynamicConnectivity) V() int {
+	return dc.v
+}
+
+// E
+// Returns the number of edges, counting parallel edges and self-loops.
+func (dc *DynamicConnectivity) E() int {
+	return dc.e
+}
+
+// Count
+// Returns the number of connected components.
+func (dc *DynamicConnectivity) Count() int {
+	return dc.count
+}
+
+// Connected
+// Returns true if vertices v and w are in the same connected component.
+func (dc *DynamicConnectivity) Connected(v, w int) bool {
+	dc.validateVertex(v)
+	dc.validateVertex(w)
+	return dc.forests[0].connected(v, w)
+}
+
+// Size
+// Returns the number of vertices in the connected component containing vertex v.
+func (dc *DynamicConnectivity) Size(v int) int {
+	dc.validateVertex(v)
+	return dc.forests[0].tree(v).vertices
+}
+
+// HasEdge
+// Returns true if there is an edge v-w.
+func (dc *DynamicConnectivity) HasEdge(v, w int) bool {
+	dc.validateVertex(v)
+	dc.validateVertex(w)
+	_, ok := dc.edges[key(v, w)]
+	return ok
+}
+
+// AddEdge
+// Adds the undirected edge v-w.
+func (dc *DynamicConnectivity) AddEdge(v, w int) {
+	dc.validateVertex(v)
+	dc.validateVertex(w)
+	dc.e++
+
+	k := key(v, w)
+	if e, ok := dc.edges[k]; ok {
+		e.count++
+		return
+	}
+	e := &edge{count: 1}
+	dc.edges[k] = e
+	if v == w {
+		return
+	}
+
+	if dc.forests[0].connected(v, w) {
+		dc.addNonTree(0, v, w)
+	} else {
+		dc.addTree(e, v, w)
+		dc.count--
+	}
+}
+
+// RemoveEdge
+// Removes one undirected edge v-w, and returns false if there is none.
+func (dc *DynamicConnectivity) RemoveEdge(v, w int) bool {
+	dc.validateVertex(v)
+	dc.validateVertex(w)
+
+	k := key(v, w)
+	e, ok := dc.edges[k]
+	if !ok {
+		return false
+	}
+	dc.e--
+	if e.count--; e.count > 0 {
+		return true
+	}
+	delete(dc.edges, k)
+	if v == w {
+		return true
+	}
+
+	if !e.tree {
+		dc.removeNonTree(e.level, v, w)
+		return true
+	}
+	for i := 0; i <= e.level; i++ {
+		dc.forests[i].cut(v, w)
+	}
+	if !dc.replace(v, w, e.level) {
+		dc.count++
+	}
+	return true
+}
+
+// look for a replacement for the removed tree edge v-w of the given level, from that level
+// down, and return true if one reconnected v and w
+func (dc *DynamicConnectivity) replace(v, w, level int) bool {
+	for i := level; i >= 0; i-- {
+		f := dc.forests[i]
+		t, other := f.tree(v), f.tree(w)
+		if t.vertices > other.vertices {
+			t = other
+		}
+
+		// T may now be a tree of F_(i+1): move its tree edges of level i up
+		for x := findMarked(t, treeMark); x != nil; x = findMarked(t, treeMark) {
+			setMark(x, treeMark, false)
+			e := dc.edges[key(x.v, x.w)]
+			e.level++
+			setMark(dc.forests[i+1].link(x.v, x.w), treeMark, true)
+		}
+
+		// scan the non-tree edges of level i at T
+		for x := findMarked(t, nonTreeMark); x != nil; x = findMarked(t, nonTreeMark) {
+			u := x.v
+			for y := range dc.nonTree[i][u] {
+				dc.removeNonTree(i, u, y)
+				if f.tree(y) != t {
+					dc.addTree(dc.edges[key(u, y)], u, y)
+					return true
+				}
+				dc.addNonTree(i+1, u, y)
+			}
+		}
+	}
+	return false
+}
+
+// make v-w a tree edge of its level, linking it in F_0 through F_level
+func (dc *DynamicConnectivity) addTree(e *edge, v, w int) {
+	e.tree = true
+	for i := 0; i <= e.level; i++ {
+		vw := dc.forests[i].link(min(v, w), max(v, w))
+		if i == e.level {
+			setMark(vw, treeMark, true)
+		}
+	}
+}
+
+func (dc *DynamicConnectivity) addNonTree(level, v, w int) {
+	dc.edges[key(v, w)].level = level
+	for _, p := range [][2]int{{v, w}, {w, v}} {
+		adj := dc.nonTree[level][p[0]]
+		if adj == nil {
+			adj = make(map[int]bool)
+			dc.nonTree[level][p[0]] = adj
+		}
+		adj[p[1]] = true
+		if len(adj) == 1 {
+			setMark(dc.forests[level].vertex[p[0]], nonTreeMark, true)
+		}
+	}
+}
+
+func (dc *DynamicConnectivity) removeNonTree(level, v, w int) {
+	for _, p := range [][2]int{{v, w}, {w, v}} {
+		adj := dc.nonTree[level][p[0]]
+		delete(adj, p[1])
+		if len(adj) == 0 {
+			setMark(dc.forests[level].vertex[p[0]], nonTreeMark, false)
+		}
+	}
+}
+
+// Graph
+// Returns the graph of the edges present now.
+func (dc *DynamicConnectivity) Graph() *graph.Graph {
+	keys := make([][2]int, 0, len(dc.edges))
+	for k := range dc.edges {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+
+	g := graph.NewGraph(dc.v)
+	for _, k := range keys {
+		for i := 0; i < dc.edges[k].count; i++ {
+			g.AddEdge(k[0], k[1])
+		}
+	}
+	return g
+}
+
+func key(v, w int) [2]int {
+	return [2]int{min(v, w), max(v, w)}
+}
+
+func (dc *DynamicConnectivity) validateVertex(v int) {
+	if v < 0 || v >= dc.v {
+		panic(fmt.Sprintf("vertex %d is not between 0 and %d", v, dc.v-1))
+	}
+}
@@ -0,0 +1,179 @@
+package dynamic_connectivity
+
+import (
+	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
+	"github.com/stretchr/testify/require"
+
+	"math/rand"
+	"testing"
+)
+
+// the components of the graph on n vertices with the given edges, found by breadth-first
+// search: component[v] is the smallest vertex of the component of v
+func components(n int, edges [][2]int) []int {
+	adj := make([][]int, n)
+	for _, e := range edges {
+		adj[e[0]] = append(adj[e[0]], e[1])
+		adj[e[1]] = append(adj[e[1]], e[0])
+	}
+
+	component := make([]int, n)
+	for v := range component {
+		component[v] = -1
+	}
+	for s := 0; s < n; s++ {
+		if component[s] != -1 {
+			continue
+		}
+		component[s] = s
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range adj[v] {
+				if component[w] == -1 {
+					component[w] = s
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+	return component
+}
+
+// an edge to add: often a self-loop or a copy of an edge already there
+func randomEdge(r *rand.Rand, n int, edges [][2]int) [2]int {
+	switch x := r.Intn(10); {
+	case x == 0:
+		v := r.Intn(n)
+		return [2]int{v, v}
+	case x < 3 && len(edges) > 0:
+		e := edges[r.Intn(len(edges))]
+		return [2]int{e[1], e[0]}
+	default:
+		return [2]int{r.Intn(n), r.Intn(n)}
+	}
+}
+
+// remove one copy of the edge v-w, either way round, from edges
+func removeEdge(edges [][2]int, v, w int) ([][2]int, bool) {
+	for i, e := range edges {
+		if e == [2]int{v, w} || e == [2]int{w, v} {
+			return append(edges[:i], edges[i+1:]...), true
+		}
+	}
+	return edges, false
+}
+
+func TestCase1(t *testing.T) {
+	// random updates and queries against breadth-first search, with parallel edges and self-loops
+	for _, n := range []int{2, 10, 40} {
+		r := rand.New(rand.NewSource(int64(n)))
+		dc := NewDynamicConnectivity(n, WithSeed(int64(n)))
+		var edges [][2]int
+
+		for i := 0; i < 3000; i++ {
+			if r.Intn(2) == 0 || len(edges) == 0 {
+				e := randomEdge(r, n, edges)
+				dc.AddEdge(e[0], e[1])
+				edges = append(edges, e)
+			} else {
+				// remove an edge there, or one that may not be
+				v, w := r.Intn(n), r.Intn(n)
+				if r.Intn(4) != 0 {
+					e := edges[r.Intn(len(edges))]
+					v, w = e[1], e[0]
+				}
+				var removed bool
+				edges, removed = removeEdge(edges, v, w)
+				require.Equal(t, removed, dc.RemoveEdge(v, w))
+			}
+
+			component := components(n, edges)
+			count := 0
+			size := make(map[int]int)
+			for v, c := range component {
+				if v == c {
+					count++
+				}
+				size[c]++
+			}
+			require.Equal(t, count, dc.Count())
+			require.Equal(t, len(edges), dc.E())
+
+			for j := 0; j < 5; j++ {
+				v, w := r.Intn(n), r.Intn(n)
+				require.Equal(t, component[v] == component[w], dc.Connected(v, w))
+				require.Equal(t, size[component[v]], dc.Size(v))
+				_, present := removeEdge(append([][2]int(nil), edges...), v, w)
+				require.Equal(t, present, dc.HasEdge(v, w))
+			}
+		}
+		require.Equal(t, len(edges), dc.Graph().E)
+	}
+}
+
+func TestCase2(t *testing.T) {
+	// Init keeps the parallel edges and self-loops of the graph
+	g := graph.NewGraph(4)
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 0)
+	g.AddEdge(2, 2)
+	g.AddEdge(2, 2)
+	dc := Init(g)
+	require.Equal(t, 4, dc.E())
+	require.Equal(t, 3, dc.Count())
+
+	require.True(t, dc.RemoveEdge(0, 1))
+	require.True(t, dc.Connected(0, 1))
+	require.True(t, dc.RemoveEdge(1, 0))
+	require.False(t, dc.Connected(0, 1))
+	require.False(t, dc.RemoveEdge(0, 1))
+	require.True(t, dc.RemoveEdge(2, 2))
+	require.True(t, dc.HasEdge(2, 2))
+	require.Equal(t, 4, dc.Count())
+	require.Equal(t, 1, dc.E())
+}
+
+func TestCase3(t *testing.T) {
+	// the same random sequences answered offline, starting from a graph with parallel edges
+	// and self-loops
+	for _, n := range []int{2, 10, 40} {
+		r := rand.New(rand.NewSource(int64(n)))
+		g := graph.NewGraph(n)
+		var edges [][2]int
+		for i := 0; i < n; i++ {
+			e := randomEdge(r, n, edges)
+			g.AddEdge(e[0], e[1])
+			edges = append(edges, e)
+		}
+
+		o := NewOffline(g)
+		var expected []bool
+		for i := 0; i < 3000; i++ {
+			switch x := r.Intn(3); {
+			case x == 0 || len(edges) == 0:
+				e := randomEdge(r, n, edges)
+				o.AddEdge(e[0], e[1])
+				edges = append(edges, e)
+			case x == 1:
+				v, w := r.Intn(n), r.Intn(n)
+				if r.Intn(4) != 0 {
+					e := edges[r.Intn(len(edges))]
+					v, w = e[1], e[0]
+				}
+				var removed bool
+				edges, removed = removeEdge(edges, v, w)
+				require.Equal(t, removed, o.RemoveEdge(v, w))
+			default:
+				component := components(n, edges)
+				v, w := r.Intn(n), r.Intn(n)
+				require.Equal(t, len(expected), o.Connected(v, w))
+				expected = append(expected, component[v] == component[w])
+			}
+		}
+		require.Equal(t, expected, o.Solve())
+	}
+
+	require.Empty(t, NewOffline(graph.NewGraph(3)).Solve())
+}
@@ -0,0 +1,227 @@
+package dynamic_connectivity
+
+import (
+	"math/rand"
+)
+
+// An Euler tour tree keeps a tree as the cyclic sequence of its Euler tour, in which every
+// vertex appears as one node and every tree edge v-w as two arcs, v->w and w->v. Cutting the
+// arcs of an edge out of the tour leaves the tours of the two halves, and the tours of two
+// trees, each rotated to start at an endpoint, concatenate with the two new arcs into the tour
+// of the linked tree. The sequences are treaps ordered by position, with parent pointers to
+// find the position and the tree of a node, so link, cut and connected take O(log n) expected.
+// Every node also counts, over its subtree, the vertices and the nodes that carry each mark,
+// so that the size of a tree and a marked node in it take O(log n) to find.
+
+const (
+	nonTreeMark = iota // on a vertex with non-tree edges of the level of the forest
+	treeMark           // on an arc v->w, v < w, of a tree edge of the level of the forest
+	marks
+)
+
+type node struct {
+	left, right, parent *node
+	priority            int64 // heap ordered, larger on top
+
+	v, w     int // the vertex v if v == w, or the arc v->w
+	mark     [marks]bool
+	size     int        // number of nodes in subtree
+	vertices int        // number of vertex nodes in subtree
+	marked   [marks]int // number of nodes in subtree with each mark
+}
+
+func size(x *node) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+// recompute the counts of x from its children, and make x their parent
+func (x *node) update() {
+	x.size, x.vertices = 1, 0
+	if x.v == x.w {
+		x.vertices = 1
+	}
+	for k := range x.marked {
+		x.marked[k] = 0
+		if x.mark[k] {
+			x.marked[k] = 1
+		}
+	}
+	for _, child := range []*node{x.left, x.right} {
+		if child == nil {
+			continue
+		}
+		child.parent = x
+		x.size += child.size
+		x.vertices += child.vertices
+		for k := range x.marked {
+			x.marked[k] += child.marked[k]
+		}
+	}
+}
+
+// the root of the treap containing x
+func root(x *node) *node {
+	for x.parent != nil {
+		x = x.parent
+	}
+	return x
+}
+
+// the position of x in its sequence
+func index(x *node) int {
+	i := size(x.left)
+	for ; x.parent != nil; x = x.parent {
+		if x == x.parent.right {
+			i += size(x.parent.left) + 1
+		}
+	}
+	return i
+}
+
+// concatenate the sequences a and b
+func join(a, b *node) *node {
+	t := merge(a, b)
+	if t != nil {
+		t.parent = nil
+	}
+	return t
+}
+
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = merge(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = merge(a, b.left)
+	b.update()
+	return b
+}
+
+// split the sequence t into its first k nodes and the rest
+func split(t *node, k int) (*node, *node) {
+	l, r := divide(t, k)
+	if l != nil {
+		l.parent = nil
+	}
+	if r != nil {
+		r.parent = nil
+	}
+	return l, r
+}
+
+func divide(t *node, k int) (*node, *node) {
+	if t == nil {
+		return nil, nil
+	}
+	if size(t.left) >= k {
+		l, r := divide(t.left, k)
+		t.left = r
+		t.update()
+		return l, t
+	}
+	l, r := divide(t.right, k-size(t.left)-1)
+	t.right = l
+	t.update()
+	return t, r
+}
+
+// set or clear mark k on x, updating the counts up to the root
+func setMark(x *node, k int, on bool) {
+	x.mark[k] = on
+	for ; x != nil; x = x.parent {
+		x.update()
+	}
+}
+
+// a node with mark k in the treap t, or nil if there is none
+func findMarked(t *node, k int) *node {
+	if t.marked[k] == 0 {
+		return nil
+	}
+	for {
+		if t.left != nil && t.left.marked[k] > 0 {
+			t = t.left
+		} else if t.mark[k] {
+			return t
+		} else {
+			t = t.right
+		}
+	}
+}
+
+// a spanning forest of Euler tour trees
+type forest struct {
+	vertex []*node          // vertex[v] = node of vertex v
+	arcs   map[[2]int]*node // arcs[{v, w}] = arc v->w of tree edge v-w
+	rand   *rand.Rand
+}
+
+func newForest(v int, rand *rand.Rand) *forest {
+	f := &forest{
+		vertex: make([]*node, v),
+		arcs:   make(map[[2]int]*node),
+		rand:   rand,
+	}
+	for i := range f.vertex {
+		f.vertex[i] = f.newNode(i, i)
+	}
+	return f
+}
+
+func (f *forest) newNode(v, w int) *node {
+	x := &node{v: v, w: w, priority: f.rand.Int63()}
+	x.update()
+	return x
+}
+
+// the root of the tree containing vertex v
+func (f *forest) tree(v int) *node {
+	return root(f.vertex[v])
+}
+
+func (f *forest) connected(v, w int) bool {
+	return f.tree(v) == f.tree(w)
+}
+
+// rotate the tour of the tree containing v to start at v
+func (f *forest) reroot(v int) *node {
+	x := f.vertex[v]
+	before, after := split(root(x), index(x))
+	return join(after, before)
+}
+
+// link the trees containing v and w by the edge v-w, and return the arc v->w
+func (f *forest) link(v, w int) *node {
+	vw, wv := f.newNode(v, w), f.newNode(w, v)
+	f.arcs[[2]int{v, w}], f.arcs[[2]int{w, v}] = vw, wv
+	join(join(join(f.reroot(v), vw), f.reroot(w)), wv)
+	return vw
+}
+
+// cut the tree edge v-w
+func (f *forest) cut(v, w int) {
+	vw, wv := f.arcs[[2]int{v, w}], f.arcs[[2]int{w, v}]
+	delete(f.arcs, [2]int{v, w})
+	delete(f.arcs, [2]int{w, v})
+
+	// the tour is A first B second C, where B is the tour of one half and C A of the other
+	i, j := index(vw), index(wv)
+	if i > j {
+		i, j = j, i
+	}
+	rest, c := split(root(vw), j+1)
+	rest, _ = split(rest, j)
+	a, _ := split(rest, i+1)
+	a, _ = split(a, i)
+	join(a, c)
+}
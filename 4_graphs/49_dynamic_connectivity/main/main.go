@@ -0,0 +1,69 @@
+package main
+
+import (
+	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
+	dynamicConnectivity "github.com/lee-hen/Algorithms/4_graphs/49_dynamic_connectivity"
+
+	"fmt"
+)
+
+// 13
+// 13
+// 0 5
+// 4 3
+// 0 1
+// 9 12
+// 6 4
+// 5 4
+// 0 2
+// 11 12
+// 9 10
+// 0 6
+// 7 8
+// 9 11
+// 5 3
+
+// 3 components
+// remove 0-5: 3 components, 0 and 5 connected true
+// remove 0-6: 4 components, 0 and 5 connected false
+// remove 9-12: 4 components, 9 and 12 connected true
+// add 6-7: 3 components, 0 and 8 connected false
+// add 8-2: 2 components, 0 and 8 connected true
+// and the offline answers, the same
+func main() {
+	g := graph.InitGraph()
+	dc := dynamicConnectivity.Init(g)
+	offline := dynamicConnectivity.NewOffline(g)
+	fmt.Println(dc.Count(), "components")
+
+	updates := []struct {
+		remove bool
+		v, w   int
+		p, q   int
+	}{
+		{true, 0, 5, 0, 5},
+		{true, 0, 6, 0, 5},
+		{true, 9, 12, 9, 12},
+		{false, 6, 7, 0, 8},
+		{false, 8, 2, 0, 8},
+	}
+	var queries []int
+	for _, u := range updates {
+		verb := "add"
+		if u.remove {
+			verb = "remove"
+			dc.RemoveEdge(u.v, u.w)
+			offline.RemoveEdge(u.v, u.w)
+		} else {
+			dc.AddEdge(u.v, u.w)
+			offline.AddEdge(u.v, u.w)
+		}
+		fmt.Printf("%s %d-%d: %d components, %d and %d connected %t\n", verb, u.v, u.w, dc.Count(), u.p, u.q, dc.Connected(u.p, u.q))
+		queries = append(queries, offline.Connected(u.p, u.q))
+	}
+
+	answers := offline.Solve()
+	for i, u := range updates {
+		fmt.Printf("offline: %d and %d connected %t\n", u.p, u.q, answers[queries[i]])
+	}
+}
@@ -0,0 +1,152 @@
+package dynamic_connectivity
+
+import (
+	UF "github.com/lee-hen/Algorithms/1_fundamentals/12_uf"
+	graph "github.com/lee-hen/Algorithms/4_graphs/01_graph"
+
+	"fmt"
+)
+
+// When all the updates and queries are known in advance, divide and conquer over time answers
+// them with a union-find and no deletions at all. Every edge lives over an interval of the
+// sequence of queries, which a segment tree over the queries splits into O(log Q) nodes. A
+// depth-first walk of the segment tree unites the edges of each node on the way down, answers
+// the query of each leaf, and rolls the unions back on the way up, so that at every leaf the
+// union-find holds exactly the edges present at that query. That takes O((E + Q) log Q log V)
+// time in all, where E counts the edges added.
+
+type Offline struct {
+	v       int
+	queries [][2]int         // queries[i] = vertices of query i
+	alive   map[[2]int][]int // alive[{v, w}] = first queries of the edges v-w present now
+	spans   []span           // the edges removed, with their spans of queries
+}
+
+// an edge present from query first to just before query last
+type span struct {
+	v, w, first, last int
+}
+
+// NewOffline
+// Initializes an offline dynamic connectivity structure with the vertices and edges of the
+// graph g, to which updates and queries are then given in order.
+func NewOffline(g *graph.Graph) *Offline {
+	o := &Offline{v: g.V, alive: make(map[[2]int][]int)}
+	for v := 0; v < g.V; v++ {
+		selfLoops := 0
+		for _, w := range g.Adj(v) {
+			if v < w {
+				o.AddEdge(v, w)
+			} else if v == w {
+				// a self-loop appears twice in the adjacency list of v
+				if selfLoops%2 == 0 {
+					o.AddEdge(v, w)
+				}
+				selfLoops++
+			}
+		}
+	}
+	return o
+}
+
+// AddEdge
+// Adds the undirected edge v-w after the queries so far.
+func (o *Offline) AddEdge(v, w int) {
+	o.validateVertex(v)
+	o.validateVertex(w)
+	k := key(v, w)
+	o.alive[k] = append(o.alive[k], len(o.queries))
+}
+
+// RemoveEdge
+// Removes one undirected edge v-w after the queries so far, and returns false if there is none.
+func (o *Offline) RemoveEdge(v, w int) bool {
+	o.validateVertex(v)
+	o.validateVertex(w)
+	k := key(v, w)
+	firsts := o.alive[k]
+	if len(firsts) == 0 {
+		return false
+	}
+
+	o.spans = append(o.spans, span{v: v, w: w, first: firsts[len(firsts)-1], last: len(o.queries)})
+	if len(firsts) == 1 {
+		delete(o.alive, k)
+	} else {
+		o.alive[k] = firsts[:len(firsts)-1]
+	}
+	return true
+}
+
+// Connected
+// Asks whether vertices v and w are connected after the updates so far, and returns the
+// index of the query in the answers of Solve.
+func (o *Offline) Connected(v, w int) int {
+	o.validateVertex(v)
+	o.validateVertex(w)
+	o.queries = append(o.queries, [2]int{v, w})
+	return len(o.queries) - 1
+}
+
+// Solve
+// Returns the answers to the queries, indexed as returned by Connected.
+func (o *Offline) Solve() []bool {
+	q := len(o.queries)
+	answers := make([]bool, q)
+	if q == 0 {
+		return answers
+	}
+
+	// the edges still present live to the end
+	spans := append([]span(nil), o.spans...)
+	for k, firsts := range o.alive {
+		for _, first := range firsts {
+			spans = append(spans, span{v: k[0], w: k[1], first: first, last: q})
+		}
+	}
+
+	edges := make([][][2]int, 4*q) // edges[node] = edges living over all the queries of node
+	var insert func(node, lo, hi int, s span)
+	insert = func(node, lo, hi int, s span) {
+		if s.last <= lo || hi <= s.first {
+			return
+		}
+		if s.first <= lo && hi <= s.last {
+			edges[node] = append(edges[node], [2]int{s.v, s.w})
+			return
+		}
+		mid := lo + (hi-lo)/2
+		insert(2*node+1, lo, mid, s)
+		insert(2*node+2, mid, hi, s)
+	}
+	for _, s := range spans {
+		if s.first < s.last && s.v != s.w {
+			insert(0, 0, q, s)
+		}
+	}
+
+	uf := UF.NewUF(o.v, UF.WithRollback())
+	var walk func(node, lo, hi int)
+	walk = func(node, lo, hi int) {
+		checkpoint := uf.Checkpoint()
+		for _, e := range edges[node] {
+			uf.Union(e[0], e[1])
+		}
+		if hi-lo == 1 {
+			answers[lo] = uf.Connected(o.queries[lo][0], o.queries[lo][1])
+		} else {
+			mid := lo + (hi-lo)/2
+			walk(2*node+1, lo, mid)
+			walk(2*node+2, mid, hi)
+		}
+		uf.RollbackTo(checkpoint)
+	}
+	walk(0, 0, q)
+	return answers
+}
+
+func (o *Offline) validateVertex(v int) {
+	if v < 0 || v >= o.v {
+		panic(fmt.Sprintf("vertex %d is not between 0 and %d", v, o.v-1))
+	}
+}
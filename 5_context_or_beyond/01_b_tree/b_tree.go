@@ -6,48 +6,113 @@ import (
 	"strings"
 )
 
-const M = 4 // max children per B-tree node = M-1 (must be even and greater than 2)
+// M
+// default max children per B-tree node = M-1 (must be even and greater than 2)
+const M = 4
 
 type BTree struct {
-	root *Node // root of the B-tree
+	store store  // pages holding the nodes
+	root  PageID // root of the B-tree
+	order int    // max children per B-tree node = order-1
 	// height of the B-tree
 	// number of key-value pairs in the B-tree
 	height, n int
 }
 
 type Node struct {
-	m int      // number of children
+	m        int      // number of children
 	children []*Entry // the array of children
 }
 
-type Data interface {}
+type Data interface{}
 
+// internal nodes: only use key and next
+// external nodes: only use key and value
 type Entry struct {
-	key string
+	key   string
 	value Data
-	next *Node  // helper field to iterate over array entries
+	next  PageID // helper field to iterate over array entries
 }
 
-func newNode(k int) *Node {
+func newNode(k, order int) *Node {
 	return &Node{
-		m: k,
-		children: make([]*Entry, M),
+		m:        k,
+		children: make([]*Entry, order),
 	}
 }
 
-func newEntry(key string, value Data, next *Node) *Entry{
-	return &Entry {
+func newEntry(key string, value Data, next PageID) *Entry {
+	return &Entry{
 		key,
 		value,
 		next,
 	}
 }
 
+// Option
+// Configures a BTree at construction.
+type Option func(*options)
+
+type options struct {
+	order     int
+	pageSize  int
+	cacheSize int
+}
+
+// WithOrder
+// Sets the max children per node to m-1; m must be even and greater than 2.
+// A disk-backed B-tree keeps the order it was created with.
+func WithOrder(m int) Option {
+	return func(o *options) {
+		if m <= 2 || m%2 != 0 {
+			log.Fatalln("B-tree order must be even and greater than 2")
+		}
+		o.order = m
+	}
+}
+
+// WithPageSize
+// Sets the size in bytes of a page of a disk-backed B-tree; every node must fit in one page,
+// so Put rejects an entry too large for a node of order-1 such entries.
+// A disk-backed B-tree keeps the page size it was created with.
+func WithPageSize(bytes int) Option {
+	return func(o *options) {
+		if bytes < headerSize {
+			log.Fatalf("page size must be at least %d bytes\n", headerSize)
+		}
+		o.pageSize = bytes
+	}
+}
+
+// WithCacheSize
+// Sets the number of pages a disk-backed B-tree keeps in its buffer pool between operations.
+func WithCacheSize(pages int) Option {
+	return func(o *options) {
+		if pages < 1 {
+			log.Fatalln("cache size must be at least one page")
+		}
+		o.cacheSize = pages
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{order: M, pageSize: 4096, cacheSize: 64}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // NewBTree
-// Initializes an empty B-tree.
-func NewBTree() *BTree {
-	return &BTree {
-		root: newNode(0),
+// Initializes an empty B-tree held in memory. Its methods never return an error.
+func NewBTree(opts ...Option) *BTree {
+	o := newOptions(opts)
+	s := newMemStore()
+	root, _ := s.alloc(newNode(0, o.order))
+	return &BTree{
+		store: s,
+		root:  root,
+		order: o.order,
 	}
 }
 
@@ -58,7 +123,7 @@ func (tree *BTree) IsEmpty() bool {
 }
 
 // Size
-//Returns the number of key-value pairs in this symbol table.
+// Returns the number of key-value pairs in this symbol table.
 func (tree *BTree) Size() int {
 	return tree.n
 }
@@ -69,88 +134,175 @@ func (tree *BTree) Height() int {
 	return tree.height
 }
 
+// Order
+// Returns the max children per node plus one.
+func (tree *BTree) Order() int {
+	return tree.order
+}
+
+// Flush
+// Writes the modified pages and the tree header of a disk-backed B-tree to its file.
+func (tree *BTree) Flush() error {
+	return tree.store.flush(tree.meta())
+}
+
+// Close
+// Flushes a disk-backed B-tree and closes its file.
+func (tree *BTree) Close() error {
+	return tree.store.close(tree.meta())
+}
+
+func (tree *BTree) meta() meta {
+	return meta{root: tree.root, order: tree.order, height: tree.height, n: tree.n}
+}
+
+// hand the pages read by an operation back to the store, keeping the first error of the operation
+func (tree *BTree) release(err *error) {
+	if e := tree.store.release(); *err == nil {
+		*err = e
+	}
+}
+
+// index of the child whose subtree would hold key
+func (h *Node) child(key string) int {
+	j := 0
+	for j+1 < h.m && key >= h.children[j+1].key {
+		j++
+	}
+	return j
+}
+
 // Get
-// Returns the value associated with the given key.
-func (tree *BTree) Get(key string) Data {
+// Returns the value associated with the given key, or nil if there is none.
+func (tree *BTree) Get(key string) (value Data, err error) {
 	if key == "" {
 		log.Fatalln("argument to get() is null")
 	}
+	defer tree.release(&err)
 
-	return tree.root.search(key, tree.height)
-}
-
-func (h *Node) search(key string, ht int) Data {
-	children := h.children
+	h, err := tree.store.read(tree.root)
+	for ht := tree.height; ht > 0 && err == nil; ht-- {
+		h, err = tree.store.read(h.children[h.child(key)].next)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// external node
-	if ht == 0 {
-		for j := 0; j < h.m; j++ {
-			if key == children[j].key {
-				return children[j].value
-			}
-		}
-	} else {  // internal node
-		for j := 0; j < h.m; j++ {
-			if j == h.m-1 || key < children[j+1].key {
-				return children[j].next.search(key, ht-1)
-			}
+	for j := 0; j < h.m; j++ {
+		if key == h.children[j].key {
+			return h.children[j].value, nil
 		}
 	}
+	return nil, nil
+}
 
-	return nil
+// Contains
+// Does this symbol table contain the given key?
+func (tree *BTree) Contains(key string) (bool, error) {
+	value, err := tree.Get(key)
+	return value != nil, err
 }
 
 // Put
 // Inserts the key-value pair into the symbol table, overwriting the old value
-// with the new value if the key is already in the symbol table.
-func (tree *BTree) Put(key string, value Data) {
+// with the new value if the key is already in the symbol table. An entry too large
+// for the pages of a disk-backed B-tree is rejected with ErrEntryTooLarge, leaving
+// the tree unchanged.
+func (tree *BTree) Put(key string, value Data) (err error) {
 	if key == "" {
 		log.Fatalln("argument key to put() is null")
 	}
+	if value == nil {
+		log.Fatalln("argument value to put() is null")
+	}
+	if err = tree.store.fits(key, value); err != nil {
+		return err
+	}
+	defer tree.release(&err)
 
-	u := tree.root.insert(key, value, tree.height)
-	tree.n++
-	if u == nil {
-		return
+	u, added, err := tree.insert(tree.root, key, value, tree.height)
+	if err != nil {
+		return err
+	}
+	if added {
+		tree.n++
+	}
+	if u == nilPage {
+		return nil
 	}
 
 	// need to split root
-	t := newNode(2)
-	t.children[0] = newEntry(tree.root.children[0].key, nil, tree.root)
-	t.children[1] = newEntry(u.children[0].key, nil, u)
-	tree.root = t
+	h, err := tree.store.read(tree.root)
+	if err != nil {
+		return err
+	}
+	x, err := tree.store.read(u)
+	if err != nil {
+		return err
+	}
+	t := newNode(2, tree.order)
+	t.children[0] = newEntry(h.children[0].key, nil, tree.root)
+	t.children[1] = newEntry(x.children[0].key, nil, u)
+	root, err := tree.store.alloc(t)
+	if err != nil {
+		return err
+	}
+	tree.root = root
 	tree.height++
+	return nil
 }
 
-func (h *Node) insert(key string, value Data, ht int) *Node {
+// insert the key-value pair in the subtree rooted at page id; returns the page of the
+// new right sibling if the node was split, and whether the key was not already there
+func (tree *BTree) insert(id PageID, key string, value Data, ht int) (PageID, bool, error) {
+	h, err := tree.store.read(id)
+	if err != nil {
+		return nilPage, false, err
+	}
 	var j int
-	t := newEntry(key, value, nil)
+	t := newEntry(key, value, nilPage)
+	added := true
 
 	// external node
 	if ht == 0 {
 		for j = 0; j < h.m; j++ {
-			if key <= h.children[j].key {
+			if key == h.children[j].key {
+				h.children[j].value = value
+				tree.store.write(id, h)
+				return nilPage, false, nil
+			}
+			if key < h.children[j].key {
 				break
 			}
 		}
-	} else {  // internal node
-		for j = 0; j < h.m; j++ {
-			if j == h.m-1 || key < h.children[j+1].key {
-				x := h.children[j]
-				j++
-				u := x.next.insert(key, value, ht-1)
-
-				if u == nil {
-					return nil
-				}
+	} else { // internal node
+		j = h.child(key)
+		var u PageID
+		u, added, err = tree.insert(h.children[j].next, key, value, ht-1)
+		if err != nil {
+			return nilPage, false, err
+		}
 
-				t.key = u.children[0].key
-				t.value = nil
-				t.next = u
+		// the key may be the new smallest key of the child
+		child, err := tree.store.read(h.children[j].next)
+		if err != nil {
+			return nilPage, false, err
+		}
+		h.children[j].key = child.children[0].key
+		if u == nilPage {
+			tree.store.write(id, h)
+			return nilPage, added, nil
+		}
 
-				break
-			}
+		x, err := tree.store.read(u)
+		if err != nil {
+			return nilPage, false, err
 		}
+		t.key = x.children[0].key
+		t.value = nil
+		t.next = u
+		j++
 	}
 
 	for i := h.m; i > j; i-- {
@@ -160,31 +312,375 @@ func (h *Node) insert(key string, value Data, ht int) *Node {
 	h.children[j] = t
 	h.m++
 
-	if h.m == M {
-		return split(h)
+	if h.m == tree.order {
+		u, err := tree.split(id, h)
+		return u, added, err
 	}
 
-	return nil
+	tree.store.write(id, h)
+	return nilPage, added, nil
 }
 
 // split node in half
-func split(h *Node) *Node {
-	t := newNode(M/2)
-	h.m = M/2
-	for j := 0; j < M/2; j++ {
-		t.children[j] = h.children[M/2+j]
-		h.children[M/2+j] = nil
+func (tree *BTree) split(id PageID, h *Node) (PageID, error) {
+	half := tree.order / 2
+	t := newNode(half, tree.order)
+	h.m = half
+	for j := 0; j < half; j++ {
+		t.children[j] = h.children[half+j]
+		h.children[half+j] = nil
+	}
+	tree.store.write(id, h)
+	return tree.store.alloc(t)
+}
+
+// Del
+// Removes the key and its associated value from the symbol table
+// (if the key is in the symbol table).
+func (tree *BTree) Del(key string) (err error) {
+	if key == "" {
+		log.Fatalln("argument to delete() is null")
+	}
+	defer tree.release(&err)
+
+	removed, err := tree.remove(tree.root, key, tree.height)
+	if err != nil || !removed {
+		return err
+	}
+	tree.n--
+
+	// an internal root left with one child is replaced by the child
+	root, err := tree.store.read(tree.root)
+	if err != nil {
+		return err
+	}
+	if tree.height > 0 && root.m == 1 {
+		if err = tree.store.free(tree.root); err != nil {
+			return err
+		}
+		tree.root = root.children[0].next
+		tree.height--
 	}
-	return t
+	return nil
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (tree *BTree) DelMin() error {
+	min, err := tree.Min()
+	if err != nil {
+		return err
+	}
+	return tree.Del(min)
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (tree *BTree) DelMax() error {
+	max, err := tree.Max()
+	if err != nil {
+		return err
+	}
+	return tree.Del(max)
+}
+
+// remove the key from the subtree rooted at page id; a node left with fewer than order/2
+// children borrows one from a sibling or is merged with it by its parent
+func (tree *BTree) remove(id PageID, key string, ht int) (bool, error) {
+	h, err := tree.store.read(id)
+	if err != nil {
+		return false, err
+	}
+
+	// external node
+	if ht == 0 {
+		for j := 0; j < h.m; j++ {
+			if key == h.children[j].key {
+				copy(h.children[j:], h.children[j+1:h.m])
+				h.m--
+				h.children[h.m] = nil
+				tree.store.write(id, h)
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// internal node
+	j := h.child(key)
+	if removed, err := tree.remove(h.children[j].next, key, ht-1); err != nil || !removed {
+		return false, err
+	}
+
+	// the key may have been the smallest key of the child
+	child, err := tree.store.read(h.children[j].next)
+	if err != nil {
+		return false, err
+	}
+	h.children[j].key = child.children[0].key
+	if child.m < tree.order/2 {
+		if err = tree.rebalance(h, j); err != nil {
+			return false, err
+		}
+	}
+	tree.store.write(id, h)
+	return true, nil
 }
 
-// Returns a string representation of this B-tree (for debugging).
+// restore the occupancy of child j of h, which has one child too few
+func (tree *BTree) rebalance(h *Node, j int) error {
+	half := tree.order / 2
+	id := h.children[j].next
+	x, err := tree.store.read(id)
+	if err != nil {
+		return err
+	}
+
+	// borrow the largest child of the left sibling
+	if j > 0 {
+		leftID := h.children[j-1].next
+		left, err := tree.store.read(leftID)
+		if err != nil {
+			return err
+		}
+		if left.m > half {
+			copy(x.children[1:x.m+1], x.children[:x.m])
+			x.children[0] = left.children[left.m-1]
+			x.m++
+			left.m--
+			left.children[left.m] = nil
+
+			h.children[j].key = x.children[0].key
+			tree.store.write(leftID, left)
+			tree.store.write(id, x)
+			return nil
+		}
+	}
+
+	// borrow the smallest child of the right sibling
+	if j+1 < h.m {
+		rightID := h.children[j+1].next
+		right, err := tree.store.read(rightID)
+		if err != nil {
+			return err
+		}
+		if right.m > half {
+			x.children[x.m] = right.children[0]
+			x.m++
+			copy(right.children[:right.m-1], right.children[1:right.m])
+			right.m--
+			right.children[right.m] = nil
+
+			h.children[j+1].key = right.children[0].key
+			tree.store.write(rightID, right)
+			tree.store.write(id, x)
+			return nil
+		}
+	}
+
+	// both siblings have exactly order/2 children: merge with one of them
+	if j > 0 {
+		return tree.merge(h, j-1)
+	}
+	return tree.merge(h, j)
+}
+
+// merge child i+1 of h into child i; together they have at most order-1 children
+func (tree *BTree) merge(h *Node, i int) error {
+	leftID, rightID := h.children[i].next, h.children[i+1].next
+	left, err := tree.store.read(leftID)
+	if err != nil {
+		return err
+	}
+	right, err := tree.store.read(rightID)
+	if err != nil {
+		return err
+	}
+
+	copy(left.children[left.m:], right.children[:right.m])
+	left.m += right.m
+	tree.store.write(leftID, left)
+	if err = tree.store.free(rightID); err != nil {
+		return err
+	}
+
+	copy(h.children[i+1:], h.children[i+2:h.m])
+	h.m--
+	h.children[h.m] = nil
+	return nil
+}
+
+// Ordered symbol table methods.
+
+// Min
+// Returns the smallest key in the symbol table.
+func (tree *BTree) Min() (key string, err error) {
+	if tree.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+	defer tree.release(&err)
+
+	h, err := tree.store.read(tree.root)
+	if err != nil {
+		return "", err
+	}
+	return h.children[0].key, nil
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (tree *BTree) Max() (key string, err error) {
+	if tree.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+	defer tree.release(&err)
+
+	h, err := tree.store.read(tree.root)
+	for ht := tree.height; ht > 0 && err == nil; ht-- {
+		h, err = tree.store.read(h.children[h.m-1].next)
+	}
+	if err != nil {
+		return "", err
+	}
+	return h.children[h.m-1].key, nil
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (tree *BTree) Floor(key string) (floor string, err error) {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+	defer tree.release(&err)
+
+	// every key of child j is at least the key of entry j
+	h, err := tree.store.read(tree.root)
+	if err != nil || h.m == 0 || key < h.children[0].key {
+		return "", err
+	}
+	for ht := tree.height; ht > 0 && err == nil; ht-- {
+		h, err = tree.store.read(h.children[h.child(key)].next)
+	}
+	if err != nil {
+		return "", err
+	}
+	return h.children[h.child(key)].key, nil
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (tree *BTree) Ceiling(key string) (ceiling string, err error) {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+	defer tree.release(&err)
+
+	// the smallest key of the closest right sibling subtree on the search path
+	h, err := tree.store.read(tree.root)
+	for ht := tree.height; ht > 0 && err == nil; ht-- {
+		j := h.child(key)
+		if j+1 < h.m {
+			ceiling = h.children[j+1].key
+		}
+		h, err = tree.store.read(h.children[j].next)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for j := 0; j < h.m; j++ {
+		if h.children[j].key >= key {
+			return h.children[j].key, nil
+		}
+	}
+	return ceiling, nil
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (tree *BTree) Keys() ([]string, error) {
+	if tree.IsEmpty() {
+		return []string{}, nil
+	}
+	min, err := tree.Min()
+	if err != nil {
+		return nil, err
+	}
+	max, err := tree.Max()
+	if err != nil {
+		return nil, err
+	}
+	return tree.KeysBetween(min, max)
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (tree *BTree) KeysBetween(lo, hi string) (keys []string, err error) {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+	defer tree.release(&err)
+
+	keys = make([]string, 0)
+	if err = tree.keys(tree.root, tree.height, &keys, lo, hi); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (tree *BTree) keys(id PageID, ht int, keys *[]string, lo, hi string) error {
+	h, err := tree.store.read(id)
+	if err != nil {
+		return err
+	}
+	for j := 0; j < h.m; j++ {
+		if h.children[j].key > hi {
+			return nil
+		}
+
+		if ht == 0 {
+			if h.children[j].key >= lo {
+				*keys = append(*keys, h.children[j].key)
+			}
+		} else if j+1 == h.m || h.children[j+1].key > lo {
+			// keys of child j are in [children[j].key, children[j+1].key)
+			if err = tree.keys(h.children[j].next, ht-1, keys, lo, hi); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (tree *BTree) SizeBetween(lo, hi string) (int, error) {
+	if lo > hi {
+		return 0, nil
+	}
+	keys, err := tree.KeysBetween(lo, hi)
+	return len(keys), err
+}
+
+// Returns a string representation of this B-tree (for debugging), or the error reading it.
 func (tree *BTree) String() string {
-	return tree.root.string(tree.height, "") + "\n"
+	s, err := tree.string(tree.root, tree.height, "")
+	tree.release(&err)
+	if err != nil {
+		return err.Error()
+	}
+	return s + "\n"
 }
 
-func (h *Node) string(ht int, indent string) string {
+func (tree *BTree) string(id PageID, ht int, indent string) (string, error) {
 	s := strings.Builder{}
+	h, err := tree.store.read(id)
+	if err != nil {
+		return "", err
+	}
 	children := h.children
 
 	if ht == 0 {
@@ -194,8 +690,72 @@ func (h *Node) string(ht int, indent string) string {
 	} else {
 		for j := 0; j < h.m; j++ {
 			s.WriteString(indent + "(" + children[j].key + " " + fmt.Sprintf("%v", ht) + ")\n")
-			s.WriteString(children[j].next.string(ht-1, indent + "     "))
+			sub, err := tree.string(children[j].next, ht-1, indent+"     ")
+			if err != nil {
+				return "", err
+			}
+			s.WriteString(sub)
+		}
+	}
+	return s.String(), nil
+}
+
+// Check
+// integrity check of the B-tree: keys in order, separator keys equal to the smallest key
+// of their subtree, every non-root node at least half full, all leaves at the same depth
+func Check(tree *BTree) bool {
+	n, ok, err := tree.check(tree.root, tree.height, true)
+	tree.release(&err)
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+	if !ok {
+		fmt.Println("Not a B-tree")
+	}
+	if n != tree.n {
+		fmt.Println("Size not consistent")
+	}
+	return ok && n == tree.n
+}
+
+// returns the number of keys in the subtree and whether it is a valid B-tree
+func (tree *BTree) check(id PageID, ht int, root bool) (int, bool, error) {
+	h, err := tree.store.read(id)
+	if err != nil {
+		return 0, false, err
+	}
+	if h.m >= tree.order || (!root && h.m < tree.order/2) || (root && ht > 0 && h.m < 2) {
+		return 0, false, nil
+	}
+
+	n := 0
+	for j := 0; j < h.m; j++ {
+		if j > 0 && h.children[j-1].key >= h.children[j].key {
+			return 0, false, nil
+		}
+		if ht == 0 {
+			n++
+			continue
+		}
+
+		child, err := tree.store.read(h.children[j].next)
+		if err != nil {
+			return 0, false, err
+		}
+		if child.children[0].key != h.children[j].key {
+			return 0, false, nil
+		}
+		// keys of child j must be less than the next separator
+		if j+1 < h.m && child.children[child.m-1].key >= h.children[j+1].key {
+			return 0, false, nil
+		}
+
+		size, ok, err := tree.check(h.children[j].next, ht-1, false)
+		if err != nil || !ok {
+			return 0, false, err
 		}
+		n += size
 	}
-	return s.String()
+	return n, true, nil
 }
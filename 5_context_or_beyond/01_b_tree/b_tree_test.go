@@ -0,0 +1,184 @@
+package b_tree
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// apply random puts and deletes to the tree and to a map, checking the tree against the map
+func exercise(t *testing.T, tree *BTree, r *rand.Rand, ops int, values map[string]int) {
+	for i := 0; i < ops; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1000))
+		switch r.Intn(3) {
+		case 0:
+			require.NoError(t, tree.Del(key))
+			delete(values, key)
+		default:
+			require.NoError(t, tree.Put(key, i))
+			values[key] = i
+		}
+	}
+	require.True(t, Check(tree))
+	require.Equal(t, len(values), tree.Size())
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		got, err := tree.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, got)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	require.Equal(t, keys, keysOf(t, tree))
+}
+
+func keysOf(t *testing.T, tree *BTree) []string {
+	keys, err := tree.Keys()
+	require.NoError(t, err)
+	return keys
+}
+
+func TestCase1(t *testing.T) {
+	for _, order := range []int{4, 6, 32} {
+		r := rand.New(rand.NewSource(int64(order)))
+		tree := NewBTree(WithOrder(order))
+		values := make(map[string]int)
+		exercise(t, tree, r, 5000, values)
+
+		keys := keysOf(t, tree)
+		for i := 0; i < 200; i++ {
+			key := fmt.Sprintf("%04d", r.Intn(1100))
+			floor, ceiling := "", ""
+			for _, k := range keys {
+				if k <= key {
+					floor = k
+				}
+				if k >= key && ceiling == "" {
+					ceiling = k
+				}
+			}
+			got, err := tree.Floor(key)
+			require.NoError(t, err)
+			require.Equal(t, floor, got)
+			got, err = tree.Ceiling(key)
+			require.NoError(t, err)
+			require.Equal(t, ceiling, got)
+		}
+
+		min, err := tree.Min()
+		require.NoError(t, err)
+		require.Equal(t, keys[0], min)
+		max, err := tree.Max()
+		require.NoError(t, err)
+		require.Equal(t, keys[len(keys)-1], max)
+		between, err := tree.KeysBetween(keys[10], keys[20])
+		require.NoError(t, err)
+		require.Equal(t, keys[10:21], between)
+		size, err := tree.SizeBetween(keys[10], keys[20])
+		require.NoError(t, err)
+		require.Equal(t, 11, size)
+
+		// delete everything, shrinking the tree back to a single leaf
+		for !tree.IsEmpty() {
+			if tree.Size()%2 == 0 {
+				require.NoError(t, tree.DelMin())
+			} else {
+				require.NoError(t, tree.DelMax())
+			}
+		}
+		require.True(t, Check(tree))
+		require.Equal(t, 0, tree.Height())
+	}
+}
+
+func TestCase2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	r := rand.New(rand.NewSource(1))
+	values := make(map[string]int)
+
+	tree, err := OpenBTree(path, WithOrder(16), WithPageSize(1024), WithCacheSize(4))
+	require.NoError(t, err)
+	exercise(t, tree, r, 5000, values)
+	require.NoError(t, tree.Close())
+
+	// reopen with other options: the order and page size come from the file
+	for i := 0; i < 3; i++ {
+		tree, err = OpenBTree(path, WithCacheSize(8))
+		require.NoError(t, err)
+		require.Equal(t, 16, tree.Order())
+		require.Equal(t, len(values), tree.Size())
+		require.True(t, Check(tree))
+		exercise(t, tree, r, 2000, values)
+		require.NoError(t, tree.Close())
+	}
+}
+
+func TestCase3(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree, err := OpenBTree(path, WithPageSize(4096))
+	require.NoError(t, err)
+
+	// three entries of 2000 bytes do not fit in a page of 4096
+	value := strings.Repeat("x", 2000)
+	for i := 0; i < 4; i++ {
+		require.ErrorIs(t, tree.Put(fmt.Sprintf("%d", i), value), ErrEntryTooLarge)
+	}
+	require.Equal(t, 0, tree.Size())
+	require.True(t, Check(tree))
+
+	// around the limit, every accepted entry is written and read back
+	values := make(map[string]string)
+	rejected := 0
+	for n := 1200; n < 1500; n += 3 {
+		key := fmt.Sprintf("%04d", n)
+		err = tree.Put(key, strings.Repeat("y", n))
+		if errors.Is(err, ErrEntryTooLarge) {
+			rejected++
+			continue
+		}
+		require.NoError(t, err)
+		values[key] = strings.Repeat("y", n)
+	}
+	require.NotZero(t, rejected)
+	require.NotEmpty(t, values)
+	require.NoError(t, tree.Close())
+
+	tree, err = OpenBTree(path, WithCacheSize(1))
+	require.NoError(t, err)
+	require.True(t, Check(tree))
+	for key, value := range values {
+		got, err := tree.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, got)
+	}
+	require.NoError(t, tree.Close())
+}
+
+func TestCase4(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree, err := OpenBTree(path, WithCacheSize(1))
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, tree.Put(fmt.Sprintf("%04d", i), i))
+	}
+	require.NoError(t, tree.Flush())
+
+	// the I/O error is returned, by this operation and every later one
+	require.NoError(t, tree.store.(*fileStore).file.Close())
+	_, err = tree.Get("0050")
+	require.ErrorIs(t, err, os.ErrClosed)
+	require.ErrorIs(t, tree.Put("0100", 100), os.ErrClosed)
+	require.ErrorIs(t, tree.Del("0001"), os.ErrClosed)
+	_, err = tree.Keys()
+	require.ErrorIs(t, err, os.ErrClosed)
+	require.False(t, Check(tree))
+	require.ErrorIs(t, tree.Close(), os.ErrClosed)
+}
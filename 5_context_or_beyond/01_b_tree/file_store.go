@@ -0,0 +1,382 @@
+package b_tree
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// A B-tree on disk is a file of fixed-size pages. Page 0 is the header; every other page holds
+// one node, gob-encoded and prefixed with its length, or, once freed by a merge, a zero length
+// and the next page of the free list. Values are encoded as interface values, so their concrete
+// types must be registered with gob.Register unless they are basic types such as string or int.
+// Nodes are read through a buffer pool holding the most recently used pages; changed pages are
+// written back when they are evicted and by Flush and Close. There is no write-ahead log: the
+// file is consistent after Flush or Close, not after a crash in between.
+//
+// Every node must fit in its page, so Put rejects with ErrEntryTooLarge an entry that would make
+// a node of order-1 entries that large overflow. After an I/O error, every operation on the tree
+// returns that error and Flush and Close no longer write the header: the file is left as a crash
+// would leave it.
+
+const headerSize = 64
+
+var magic = [8]byte{'b', '-', 't', 'r', 'e', 'e', 0, 1}
+
+// ErrNotBTree is returned by OpenBTree for a file that was not written by a disk-backed B-tree.
+var ErrNotBTree = errors.New("b_tree: not a B-tree file")
+
+// ErrEntryTooLarge is returned by Put for a key-value pair too large for the pages of the tree.
+var ErrEntryTooLarge = errors.New("b_tree: entry too large for a page")
+
+// room kept in a page for the count prefixing the encoded node, which grows with the node
+const countSize = 8
+
+// the header page
+type header struct {
+	Magic                                             [8]byte
+	PageSize, Order, Root, Height, N, Pages, FreeHead int64
+}
+
+// the encoded form of a node
+type page struct {
+	Keys   []string
+	Values []Data   // external nodes
+	Next   []PageID // internal nodes
+}
+
+// a page in the buffer pool
+type frame struct {
+	id    PageID
+	node  *Node
+	dirty bool
+}
+
+type fileStore struct {
+	file     *os.File
+	pageSize int
+	order    int
+	pages    PageID // number of pages in the file, header included
+	freeHead PageID
+	maxEntry int   // max size in bytes of an encoded entry
+	err      error // the first I/O error
+
+	capacity int
+	frames   map[PageID]*list.Element
+	lru      *list.List // front is the most recently used
+}
+
+// OpenBTree
+// Opens the disk-backed B-tree in the file at path, creating an empty one if the file does not exist
+// or is empty. The order and page size of an existing tree are read from its header.
+func OpenBTree(path string, opts ...Option) (*BTree, error) {
+	o := newOptions(opts)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &fileStore{
+		file:     file,
+		capacity: o.cacheSize,
+		frames:   make(map[PageID]*list.Element),
+		lru:      list.New(),
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	if info.Size() == 0 {
+		s.pageSize, s.order = o.pageSize, o.order
+		s.pages, s.freeHead = 1, nilPage
+		if err = s.setMaxEntry(); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		tree := &BTree{store: s, order: o.order}
+		if tree.root, err = s.alloc(newNode(0, o.order)); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		if err = tree.Flush(); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return tree, nil
+	}
+
+	var h header
+	if err = binary.Read(io.NewSectionReader(file, 0, headerSize), binary.LittleEndian, &h); err != nil || h.Magic != magic {
+		_ = file.Close()
+		return nil, ErrNotBTree
+	}
+
+	s.pageSize, s.order = int(h.PageSize), int(h.Order)
+	s.pages, s.freeHead = PageID(h.Pages), PageID(h.FreeHead)
+	if err = s.setMaxEntry(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &BTree{
+		store:  s,
+		root:   PageID(h.Root),
+		order:  int(h.Order),
+		height: int(h.Height),
+		n:      int(h.N),
+	}, nil
+}
+
+func (s *fileStore) offset(id PageID) int64 {
+	return int64(id) * int64(s.pageSize)
+}
+
+// keep the first I/O error, which fails every later operation
+func (s *fileStore) fail(err error) error {
+	if s.err == nil {
+		s.err = err
+	}
+	return err
+}
+
+func (s *fileStore) read(id PageID) (*Node, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if e, ok := s.frames[id]; ok {
+		s.lru.MoveToFront(e)
+		return e.Value.(*frame).node, nil
+	}
+
+	h, err := s.readPage(id)
+	if err != nil {
+		return nil, s.fail(err)
+	}
+	s.frames[id] = s.lru.PushFront(&frame{id: id, node: h})
+	return h, nil
+}
+
+func (s *fileStore) write(id PageID, h *Node) {
+	if e, ok := s.frames[id]; ok {
+		f := e.Value.(*frame)
+		f.node, f.dirty = h, true
+		s.lru.MoveToFront(e)
+		return
+	}
+	s.frames[id] = s.lru.PushFront(&frame{id: id, node: h, dirty: true})
+}
+
+func (s *fileStore) alloc(h *Node) (PageID, error) {
+	if s.err != nil {
+		return nilPage, s.err
+	}
+	id := s.freeHead
+	if id == nilPage {
+		id = s.pages
+		s.pages++
+	} else {
+		buf := make([]byte, 12)
+		if _, err := s.file.ReadAt(buf, s.offset(id)); err != nil {
+			return nilPage, s.fail(err)
+		}
+		s.freeHead = PageID(binary.LittleEndian.Uint64(buf[4:]))
+	}
+
+	s.write(id, h)
+	return id, nil
+}
+
+func (s *fileStore) free(id PageID) error {
+	if s.err != nil {
+		return s.err
+	}
+	if e, ok := s.frames[id]; ok {
+		s.lru.Remove(e)
+		delete(s.frames, id)
+	}
+
+	buf := make([]byte, s.pageSize)
+	binary.LittleEndian.PutUint64(buf[4:], uint64(s.freeHead))
+	if _, err := s.file.WriteAt(buf, s.offset(id)); err != nil {
+		return s.fail(err)
+	}
+	s.freeHead = id
+	return nil
+}
+
+// Returns an error unless a node of order-1 entries as large as the key-value pair fits in
+// a page. The entry is encoded as the only one of an external and of an internal node; the
+// size of the node less the size of an empty node bounds what the entry adds to any node.
+func (s *fileStore) fits(key string, value Data) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	external, err := encode(page{Keys: []string{key}, Values: []Data{value}})
+	if err != nil {
+		return err
+	}
+	internal, err := encode(page{Keys: []string{key}, Next: []PageID{math.MaxInt64}})
+	if err != nil {
+		return err
+	}
+
+	size := max(len(external), len(internal)) - s.emptySize()
+	if size > s.maxEntry {
+		return fmt.Errorf("%w: %d bytes, at most %d in pages of %d bytes with order %d",
+			ErrEntryTooLarge, size, s.maxEntry, s.pageSize, s.order)
+	}
+	return nil
+}
+
+// the size of an encoded node without entries
+func (s *fileStore) emptySize() int {
+	b, _ := encode(page{})
+	return len(b)
+}
+
+func (s *fileStore) setMaxEntry() error {
+	s.maxEntry = (s.pageSize - s.emptySize() - countSize) / (s.order - 1)
+	if s.maxEntry <= 0 {
+		return fmt.Errorf("b_tree: pages of %d bytes cannot hold nodes of order %d", s.pageSize, s.order)
+	}
+	return nil
+}
+
+// evict the least recently used pages beyond the capacity of the buffer pool
+func (s *fileStore) release() error {
+	if s.err != nil {
+		return s.err
+	}
+	for s.lru.Len() > s.capacity {
+		e := s.lru.Back()
+		f := e.Value.(*frame)
+		if f.dirty {
+			if err := s.writePage(f.id, f.node); err != nil {
+				return s.fail(err)
+			}
+		}
+		s.lru.Remove(e)
+		delete(s.frames, f.id)
+	}
+	return nil
+}
+
+func (s *fileStore) flush(m meta) error {
+	if s.err != nil {
+		return s.err
+	}
+	for e := s.lru.Front(); e != nil; e = e.Next() {
+		f := e.Value.(*frame)
+		if !f.dirty {
+			continue
+		}
+		if err := s.writePage(f.id, f.node); err != nil {
+			return s.fail(err)
+		}
+		f.dirty = false
+	}
+
+	h := header{
+		Magic:    magic,
+		PageSize: int64(s.pageSize),
+		Order:    int64(m.order),
+		Root:     int64(m.root),
+		Height:   int64(m.height),
+		N:        int64(m.n),
+		Pages:    int64(s.pages),
+		FreeHead: int64(s.freeHead),
+	}
+	buf := bytes.Buffer{}
+	if err := binary.Write(&buf, binary.LittleEndian, h); err != nil {
+		return err
+	}
+	if _, err := s.file.WriteAt(buf.Bytes(), 0); err != nil {
+		return s.fail(err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return s.fail(err)
+	}
+	return nil
+}
+
+func (s *fileStore) close(m meta) error {
+	if err := s.flush(m); err != nil {
+		_ = s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+func (s *fileStore) readPage(id PageID) (*Node, error) {
+	buf := make([]byte, s.pageSize)
+	if _, err := s.file.ReadAt(buf, s.offset(id)); err != nil {
+		return nil, err
+	}
+
+	n := binary.LittleEndian.Uint32(buf)
+	if n == 0 || int(n) > s.pageSize-4 {
+		return nil, fmt.Errorf("b_tree: page %d is not a node", id)
+	}
+
+	var p page
+	if err := gob.NewDecoder(bytes.NewReader(buf[4 : 4+n])).Decode(&p); err != nil {
+		return nil, err
+	}
+
+	h := newNode(len(p.Keys), s.order)
+	for j, key := range p.Keys {
+		if len(p.Next) > 0 {
+			h.children[j] = newEntry(key, nil, p.Next[j])
+		} else {
+			h.children[j] = newEntry(key, p.Values[j], nilPage)
+		}
+	}
+	return h, nil
+}
+
+// the page encoded with its length in front
+func encode(p page) ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.Write(make([]byte, 4))
+	if err := gob.NewEncoder(&buf).Encode(p); err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	binary.LittleEndian.PutUint32(b, uint32(len(b)-4))
+	return b, nil
+}
+
+func (s *fileStore) writePage(id PageID, h *Node) error {
+	p := page{Keys: make([]string, h.m)}
+	for j := 0; j < h.m; j++ {
+		p.Keys[j] = h.children[j].key
+		if h.children[j].next != nilPage {
+			p.Next = append(p.Next, h.children[j].next)
+		} else {
+			p.Values = append(p.Values, h.children[j].value)
+		}
+	}
+
+	buf, err := encode(p)
+	if err != nil {
+		return err
+	}
+	if len(buf) > s.pageSize {
+		return fmt.Errorf("b_tree: node of %d bytes does not fit in a page of %d bytes", len(buf), s.pageSize)
+	}
+
+	// write whole pages so that every page before the end of the file can be read in full
+	b := make([]byte, s.pageSize)
+	copy(b, buf)
+	_, err = s.file.WriteAt(b, s.offset(id))
+	return err
+}
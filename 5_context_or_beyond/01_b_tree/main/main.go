@@ -7,7 +7,12 @@ import (
 )
 
 func main() {
+	// an in-memory B-tree never returns an error
 	st := btree.NewBTree()
+	get := func(key string) btree.Data {
+		value, _ := st.Get(key)
+		return value
+	}
 	st.Put("www.cs.princeton.edu", "128.112.136.12")
 	st.Put("www.cs.princeton.edu", "128.112.136.11")
 	st.Put("www.princeton.edu",    "128.112.128.15")
@@ -27,16 +32,33 @@ func main() {
 	st.Put("www.yahoo.com",        "216.109.118.65")
 
 
-	fmt.Println("cs.princeton.edu: ", st.Get("www.cs.princeton.edu"))
-	fmt.Println("hardvardsucks.com:", st.Get("www.harvardsucks.com"))
-	fmt.Println("simpsons.com:     ", st.Get("www.simpsons.com"))
-	fmt.Println("apple.com:        ", st.Get("www.apple.com"))
-	fmt.Println("ebay.com:         ", st.Get("www.ebay.com"))
-	fmt.Println("dell.com:         ", st.Get("www.dell.com"))
+	fmt.Println("cs.princeton.edu: ", get("www.cs.princeton.edu"))
+	fmt.Println("hardvardsucks.com:", get("www.harvardsucks.com"))
+	fmt.Println("simpsons.com:     ", get("www.simpsons.com"))
+	fmt.Println("apple.com:        ", get("www.apple.com"))
+	fmt.Println("ebay.com:         ", get("www.ebay.com"))
+	fmt.Println("dell.com:         ", get("www.dell.com"))
 	fmt.Println()
 
 	fmt.Println("size:   ", st.Size())
 	fmt.Println("height:  ", st.Height())
 	fmt.Println(st)
 	fmt.Println()
+
+	floor, _ := st.Floor("www.d")
+	ceiling, _ := st.Ceiling("www.d")
+	keys, _ := st.KeysBetween("www.c", "www.f")
+	fmt.Println("floor(www.d):      ", floor)
+	fmt.Println("ceiling(www.d):    ", ceiling)
+	fmt.Println("keys [www.c, www.f]:", keys)
+	fmt.Println()
+
+	st.Del("www.cnn.com")
+	st.Del("www.dell.com")
+	st.Del("www.ebay.com")
+	st.DelMin()
+	st.DelMax()
+	fmt.Println("size:   ", st.Size())
+	fmt.Println("height:  ", st.Height())
+	fmt.Println(st)
 }
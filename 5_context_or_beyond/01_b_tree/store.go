@@ -0,0 +1,82 @@
+package b_tree
+
+// PageID
+// The number of the page holding a node.
+type PageID int64
+
+// no page; next of the entries of external nodes
+const nilPage PageID = -1
+
+// the tree fields saved in the header of a disk-backed B-tree
+type meta struct {
+	root             PageID
+	order, height, n int
+}
+
+// store
+// The pages holding the nodes of a B-tree. A node returned by read stays valid, and is the
+// same node for the same page, until release is called at the end of every tree operation;
+// a changed node is handed back with write. Before an entry is put in a node, fits checks
+// that a node full of entries that large still fits in a page.
+type store interface {
+	read(id PageID) (*Node, error)
+	write(id PageID, h *Node)
+	alloc(h *Node) (PageID, error)
+	free(id PageID) error
+	fits(key string, value Data) error
+	release() error
+	flush(m meta) error
+	close(m meta) error
+}
+
+// memStore
+// Keeps every node in memory; pages are indices into a slice. It never fails.
+type memStore struct {
+	nodes    []*Node
+	freeList []PageID
+}
+
+func newMemStore() *memStore {
+	return &memStore{}
+}
+
+func (s *memStore) read(id PageID) (*Node, error) {
+	return s.nodes[id], nil
+}
+
+func (s *memStore) write(id PageID, h *Node) {
+	s.nodes[id] = h
+}
+
+func (s *memStore) alloc(h *Node) (PageID, error) {
+	if n := len(s.freeList); n > 0 {
+		id := s.freeList[n-1]
+		s.freeList = s.freeList[:n-1]
+		s.nodes[id] = h
+		return id, nil
+	}
+	s.nodes = append(s.nodes, h)
+	return PageID(len(s.nodes) - 1), nil
+}
+
+func (s *memStore) free(id PageID) error {
+	s.nodes[id] = nil
+	s.freeList = append(s.freeList, id)
+	return nil
+}
+
+func (s *memStore) fits(string, Data) error {
+	return nil
+}
+
+func (s *memStore) release() error {
+	return nil
+}
+
+func (s *memStore) flush(meta) error {
+	return nil
+}
+
+func (s *memStore) close(meta) error {
+	return nil
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type AVLTree struct {
-	root *Node // The root node.
+	root   *Node        // The root node.
+	monoid *util.Monoid // The aggregate kept in each node, nil for none.
 }
 
 // NewAVLTree
@@ -17,6 +18,13 @@ func NewAVLTree() *AVLTree {
 	return &AVLTree{}
 }
 
+// NewAugmentedAVLTree
+// Initializes an empty symbol table that keeps, in each node, the values of its subtree
+// combined in key order under the monoid, for Aggregate and AggregateBetween.
+func NewAugmentedAVLTree(monoid util.Monoid) *AVLTree {
+	return &AVLTree{monoid: &monoid}
+}
+
 // Node
 // An inner node of the AVL tree.
 type Node struct {
@@ -29,10 +37,13 @@ type Node struct {
 	// left subtree
 	// right subtree
 	Left, Right *Node
+
+	// combination of the values in the subtree under the monoid of the tree, if it has one
+	aggregate int
 }
 
 func newNode(key string, value, height, size int) *Node {
-	return &Node{Key: key, Value: value, height: height, size: size}
+	return &Node{Key: key, Value: value, height: height, size: size, aggregate: value}
 }
 
 // IsEmpty
@@ -63,6 +74,22 @@ func (tree *AVLTree) Height() int {
 	return height(tree.root)
 }
 
+// Returns the aggregate of the subtree under monoid.
+func aggregate(x *Node, monoid *util.Monoid) int {
+	if x == nil {
+		return monoid.Identity
+	}
+	return x.aggregate
+}
+
+// Recomputes the aggregate of the node from its children and its own value under m, if not nil.
+func (x *Node) updateAggregate(m *util.Monoid) {
+	if m == nil {
+		return
+	}
+	x.aggregate = m.Combine(m.Combine(aggregate(x.Left, m), x.Value), aggregate(x.Right, m))
+}
+
 // Returns the height of the subtree.
 func height(x *Node) int {
 	if x == nil {
@@ -116,43 +143,45 @@ func (tree *AVLTree) Put(key string, value int) {
 		log.Fatalln("first argument to put() is null")
 	}
 
-	tree.root = put(tree.root, key, value)
+	tree.root = put(tree.root, key, value, tree.monoid)
 }
 
 // Inserts the key-value pair in the subtree. It overrides the old value
 // with the new value.
-func put(x *Node, key string, value int) *Node {
+func put(x *Node, key string, value int, m *util.Monoid) *Node {
 	if x == nil {
 		return newNode(key, value, 0, 1)
 	}
 
 	if key < x.Key {
-		x.Left = put(x.Left, key, value)
+		x.Left = put(x.Left, key, value, m)
 	} else if key > x.Key {
-		x.Right = put(x.Right, key, value)
+		x.Right = put(x.Right, key, value, m)
 	} else {
 		x.Value = value
+		x.updateAggregate(m)
 		return x
 	}
 
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
+	x.updateAggregate(m)
 
-	return balance(x)
+	return balance(x, m)
 }
 
 // Restores the AVL tree property of the subtree.
-func balance(x *Node) *Node {
+func balance(x *Node, m *util.Monoid) *Node {
 	if balanceFactor(x) < -1 {
 		if balanceFactor(x.Right) > 0 {
-			x.Right = x.Right.rotateRight()
+			x.Right = x.Right.rotateRight(m)
 		}
-		x = x.rotateLeft()
+		x = x.rotateLeft(m)
 	} else if balanceFactor(x) > 1 {
 		if balanceFactor(x.Left) < 0 {
-			x.Left = x.Left.rotateLeft()
+			x.Left = x.Left.rotateLeft(m)
 		}
-		x = x.rotateRight()
+		x = x.rotateRight(m)
 	}
 
 	return x
@@ -168,7 +197,7 @@ func balanceFactor(x *Node) int {
 }
 
 // Rotates the given subtree to the right.
-func (x *Node) rotateRight() *Node {
+func (x *Node) rotateRight(m *util.Monoid) *Node {
 	y := x.Left
 	x.Left = y.Right
 	y.Right = x
@@ -176,11 +205,13 @@ func (x *Node) rotateRight() *Node {
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
 	y.height = 1 + util.Max(height(y.Left), height(y.Right))
+	y.aggregate = x.aggregate
+	x.updateAggregate(m)
 	return y
 }
 
 // Rotates the given subtree to the left.
-func (x *Node) rotateLeft() *Node {
+func (x *Node) rotateLeft(m *util.Monoid) *Node {
 	y := x.Right
 	x.Right = y.Left
 	y.Left = x
@@ -188,6 +219,8 @@ func (x *Node) rotateLeft() *Node {
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
 	y.height = 1 + util.Max(height(y.Left), height(y.Right))
+	y.aggregate = x.aggregate
+	x.updateAggregate(m)
 	return y
 }
 
@@ -203,16 +236,16 @@ func (tree *AVLTree) Del(key string) {
 		return
 	}
 
-	tree.root = del(tree.root, key)
+	tree.root = del(tree.root, key, tree.monoid)
 }
 
 // Removes the specified key and its associated value from the given
 // subtree.
-func del(x *Node, key string) *Node {
+func del(x *Node, key string, m *util.Monoid) *Node {
 	if key < x.Key {
-		x.Left = del(x.Left, key)
+		x.Left = del(x.Left, key, m)
 	} else if key > x.Key {
-		x.Right = del(x.Right, key)
+		x.Right = del(x.Right, key, m)
 	} else {
 		if x.Left == nil {
 			return x.Right
@@ -221,14 +254,15 @@ func del(x *Node, key string) *Node {
 		} else {
 			y := x
 			x = min(y.Right)
-			x.Right = delMin(y.Right)
+			x.Right = delMin(y.Right, m)
 			x.Left = y.Left
 		}
 	}
 
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
-	return balance(x)
+	x.updateAggregate(m)
+	return balance(x, m)
 }
 
 // DelMin
@@ -238,20 +272,21 @@ func (tree *AVLTree) DelMin() {
 		log.Fatalln("called deleteMin() with empty symbol table")
 	}
 
-	tree.root = delMin(tree.root)
+	tree.root = delMin(tree.root, tree.monoid)
 }
 
 // Removes the smallest key and associated value from the given subtree.
-func delMin(x *Node) *Node {
+func delMin(x *Node, m *util.Monoid) *Node {
 	if x.Left == nil {
 		return x.Right
 	}
 
-	x.Left = delMin(x.Left)
+	x.Left = delMin(x.Left, m)
 
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
-	return balance(x)
+	x.updateAggregate(m)
+	return balance(x, m)
 }
 
 // DelMax
@@ -260,20 +295,21 @@ func (tree *AVLTree) DelMax() {
 	if tree.IsEmpty() {
 		log.Fatalln("called deleteMax() with empty symbol table")
 	}
-	tree.root = delMax(tree.root)
+	tree.root = delMax(tree.root, tree.monoid)
 }
 
 // Removes the largest key and associated value from the given subtree.
-func delMax(x *Node) *Node {
+func delMax(x *Node, m *util.Monoid) *Node {
 	if x.Right == nil {
 		return x.Left
 	}
 
-	x.Right = delMax(x.Right)
+	x.Right = delMax(x.Right, m)
 
 	x.size = 1 + size(x.Left) + size(x.Right)
 	x.height = 1 + util.Max(height(x.Left), height(x.Right))
-	return balance(x)
+	x.updateAggregate(m)
+	return balance(x, m)
 }
 
 // Min
@@ -556,6 +592,76 @@ func (tree *AVLTree) SizeBetween(lo, hi string) int {
 	}
 }
 
+// Aggregate
+// Returns the values of all keys combined in key order under the monoid of the symbol table.
+func (tree *AVLTree) Aggregate() int {
+	if tree.monoid == nil {
+		log.Fatalln("calls aggregate() on a symbol table without a monoid")
+	}
+	return aggregate(tree.root, tree.monoid)
+}
+
+// AggregateBetween
+// Returns the values of the keys between lo and hi (inclusive) combined in key order
+// under the monoid of the symbol table, visiting O(log n) nodes.
+func (tree *AVLTree) AggregateBetween(lo, hi string) int {
+	if lo == "" {
+		log.Fatalln("first argument to aggregateBetween() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to aggregateBetween() is null")
+	}
+	if tree.monoid == nil {
+		log.Fatalln("calls aggregateBetween() on a symbol table without a monoid")
+	}
+
+	m := tree.monoid
+	x := tree.root
+	// find the highest node in [lo, hi]; the range splits there
+	for x != nil && (x.Key < lo || x.Key > hi) {
+		if x.Key < lo {
+			x = x.Right
+		} else {
+			x = x.Left
+		}
+	}
+	if x == nil {
+		return m.Identity
+	}
+
+	return m.Combine(m.Combine(aggregateFrom(x.Left, lo, m), x.Value), aggregateTo(x.Right, hi, m))
+}
+
+// Combines the values of the keys >= lo in the subtree.
+func aggregateFrom(x *Node, lo string, m *util.Monoid) int {
+	result := m.Identity
+	for x != nil {
+		if x.Key < lo {
+			x = x.Right
+		} else {
+			// x and its right subtree are in range, in front of what was collected
+			result = m.Combine(m.Combine(x.Value, aggregate(x.Right, m)), result)
+			x = x.Left
+		}
+	}
+	return result
+}
+
+// Combines the values of the keys <= hi in the subtree.
+func aggregateTo(x *Node, hi string, m *util.Monoid) int {
+	result := m.Identity
+	for x != nil {
+		if x.Key > hi {
+			x = x.Left
+		} else {
+			// x and its left subtree are in range, behind what was collected
+			result = m.Combine(result, m.Combine(aggregate(x.Left, m), x.Value))
+			x = x.Right
+		}
+	}
+	return result
+}
+
 func Check(tree *AVLTree) bool {
 	if !tree.isBST() {
 		fmt.Println("Symmetric order not consistent")
@@ -573,7 +679,11 @@ func Check(tree *AVLTree) bool {
 		fmt.Println("Ranks not consistent")
 	}
 
-	return tree.isBST() && tree.isAVL() && tree.isSizeConsistent() && tree.isRankConsistent()
+	if !tree.isAggregateConsistent() {
+		fmt.Println("Subtree aggregates not consistent")
+	}
+
+	return tree.isBST() && tree.isAVL() && tree.isSizeConsistent() && tree.isRankConsistent() && tree.isAggregateConsistent()
 }
 
 func (tree *AVLTree) isAVL() bool {
@@ -642,3 +752,22 @@ func (tree *AVLTree) isRankConsistent() bool {
 
 	return true
 }
+
+func (tree *AVLTree) isAggregateConsistent() bool {
+	if tree.monoid == nil {
+		return true
+	}
+	return isAggregateConsistent(tree.root, tree.monoid)
+}
+
+func isAggregateConsistent(x *Node, m *util.Monoid) bool {
+	if x == nil {
+		return true
+	}
+
+	if x.aggregate != m.Combine(m.Combine(aggregate(x.Left, m), x.Value), aggregate(x.Right, m)) {
+		return false
+	}
+
+	return isAggregateConsistent(x.Left, m) && isAggregateConsistent(x.Right, m)
+}
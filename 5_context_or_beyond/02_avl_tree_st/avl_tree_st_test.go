@@ -1,8 +1,12 @@
 package avl_tree_st
 
 import (
+	"github.com/lee-hen/Algorithms/util"
 	"github.com/stretchr/testify/require"
 
+	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -18,3 +22,178 @@ func TestCase1(t *testing.T) {
 
 	require.True(t, Check(st))
 }
+
+func TestCase2(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, m := range []util.Monoid{util.SumMonoid, util.MaxMonoid, util.MinMonoid} {
+		st := NewAugmentedAVLTree(m)
+		values := make(map[string]int)
+		for i := 0; i < 2000; i++ {
+			key := fmt.Sprintf("%03d", r.Intn(500))
+			switch r.Intn(8) {
+			case 0:
+				st.Del(key)
+				delete(values, key)
+			case 1:
+				if !st.IsEmpty() {
+					delete(values, st.Min())
+					st.DelMin()
+				}
+			case 2:
+				if !st.IsEmpty() {
+					delete(values, st.Max())
+					st.DelMax()
+				}
+			default:
+				values[key] = r.Intn(1000) - 500
+				st.Put(key, values[key])
+			}
+		}
+		require.True(t, Check(st))
+
+		for i := 0; i < 200; i++ {
+			lo, hi := fmt.Sprintf("%03d", r.Intn(500)), fmt.Sprintf("%03d", r.Intn(500))
+			expected := m.Identity
+			for _, key := range st.KeysBetween(lo, hi) {
+				expected = m.Combine(expected, values[key])
+			}
+			require.Equal(t, expected, st.AggregateBetween(lo, hi))
+		}
+
+		expected := m.Identity
+		for _, value := range values {
+			expected = m.Combine(expected, value)
+		}
+		require.Equal(t, expected, st.Aggregate())
+	}
+}
+
+func TestCase3(t *testing.T) {
+	for n := 0; n <= 300; n++ {
+		keys := make([]string, n)
+		values := make([]int, n)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("%03d", i)
+			values[i] = i
+		}
+		tree := NewAVLTreeFromSorted(keys, values)
+		require.True(t, Check(tree))
+		require.Equal(t, keys, tree.Keys())
+		lg := -1
+		for k := n; k > 0; k /= 2 {
+			lg++
+		}
+		require.Equal(t, lg, tree.Height())
+
+		// the same tree, augmented
+		augmented := NewAugmentedAVLTreeFromSorted(util.MaxMonoid, keys, values)
+		require.True(t, Check(augmented))
+		if n > 0 {
+			require.Equal(t, n-1, augmented.Aggregate())
+			require.Equal(t, n/2, augmented.AggregateBetween("000", fmt.Sprintf("%03d", n/2)))
+		}
+	}
+
+	r := rand.New(rand.NewSource(2))
+	tree := NewAugmentedAVLTree(util.SumMonoid)
+	sum := 0
+	for i := 0; i < 1000; i++ {
+		tree.Put(fmt.Sprintf("%04d", i), i)
+		sum += i
+	}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%04d", r.Intn(1100))
+		right := tree.Split(key)
+		require.True(t, Check(tree))
+		require.True(t, Check(right))
+		if !tree.IsEmpty() {
+			require.Less(t, tree.Max(), key)
+		}
+		if !right.IsEmpty() {
+			require.GreaterOrEqual(t, right.Min(), key)
+		}
+		require.Equal(t, sum, tree.Aggregate()+right.Aggregate())
+
+		tree = Join(tree, right)
+		require.True(t, Check(tree))
+		require.True(t, right.IsEmpty())
+		require.Equal(t, 1000, tree.Size())
+		require.Equal(t, sum, tree.Aggregate())
+	}
+
+	// a table built from sorted keys can be joined with one built by puts
+	more := NewAugmentedAVLTreeFromSorted(util.SumMonoid, []string{"1000", "1001", "1002"}, []int{5, 7, 9})
+	tree = Join(tree, more)
+	require.True(t, Check(tree))
+	require.Equal(t, sum+21, tree.Aggregate())
+	require.Equal(t, 16, tree.AggregateBetween("1001", "1002"))
+}
+
+func TestCase4(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	random := func(n, universe int) map[string]int {
+		values := make(map[string]int)
+		for i := 0; i < n; i++ {
+			values[fmt.Sprintf("%04d", r.Intn(universe))] = r.Intn(1000)
+		}
+		return values
+	}
+	fromMap := func(values map[string]int) *AVLTree {
+		tree := NewAVLTree()
+		for key, value := range values {
+			tree.Put(key, value)
+		}
+		return tree
+	}
+	sorted := func(keys map[string]bool) []string {
+		all := make([]string, 0, len(keys))
+		for key := range keys {
+			all = append(all, key)
+		}
+		sort.Strings(all)
+		return all
+	}
+
+	for _, sizes := range [][2]int{{0, 50}, {50, 0}, {10, 1000}, {1000, 10}, {500, 500}, {300, 2000}} {
+		av, bv := random(sizes[0], 2000), random(sizes[1], 2000)
+		union, intersection, difference := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+		for key := range av {
+			union[key] = true
+			if _, ok := bv[key]; ok {
+				intersection[key] = true
+			} else {
+				difference[key] = true
+			}
+		}
+		for key := range bv {
+			union[key] = true
+		}
+
+		tree := Union(fromMap(av), fromMap(bv))
+		require.True(t, Check(tree))
+		require.Equal(t, sorted(union), tree.Keys())
+		for key := range union {
+			value, _ := tree.Get(key)
+			if v, ok := av[key]; ok {
+				require.Equal(t, v, value)
+			} else {
+				require.Equal(t, bv[key], value)
+			}
+		}
+
+		tree = Intersection(fromMap(av), fromMap(bv))
+		require.True(t, Check(tree))
+		require.Equal(t, sorted(intersection), tree.Keys())
+		for key := range intersection {
+			value, _ := tree.Get(key)
+			require.Equal(t, av[key], value)
+		}
+
+		a, b := fromMap(av), fromMap(bv)
+		tree = Difference(a, b)
+		require.True(t, Check(tree))
+		require.Equal(t, sorted(difference), tree.Keys())
+		require.True(t, a.IsEmpty())
+		require.True(t, b.IsEmpty())
+	}
+}
@@ -0,0 +1,52 @@
+package avl_tree_st
+
+import (
+	"github.com/lee-hen/Algorithms/3_searching/27_cursor"
+
+	"iter"
+)
+
+// Entry
+// Returns the key and the value of the node, for 27_cursor.TreeCursor.
+func (x *Node) Entry() (string, int) {
+	return x.Key, x.Value
+}
+
+// Children
+// Returns the left and right subtrees of the node, for 27_cursor.TreeCursor.
+func (x *Node) Children() (*Node, *Node) {
+	return x.Left, x.Right
+}
+
+// Cursor
+// Returns a cursor that is not positioned yet; call First, Last, Seek or SeekFloor.
+// It keeps the path from the root to the current node, so Next and Prev take amortized
+// constant time and Seek takes time proportional to the height. Modifying the symbol table
+// invalidates its cursors.
+func (tree *AVLTree) Cursor() *cursor.TreeCursor[*Node] {
+	return cursor.NewTreeCursor(tree.root)
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order.
+func (tree *AVLTree) All() iter.Seq2[string, int] {
+	return cursor.All(tree.Cursor)
+}
+
+// Backward
+// Returns an iterator over the key-value pairs in descending key order.
+func (tree *AVLTree) Backward() iter.Seq2[string, int] {
+	return cursor.Backward(tree.Cursor)
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+func (tree *AVLTree) Range(lo, hi string) iter.Seq2[string, int] {
+	return cursor.Range(tree.Cursor, lo, hi)
+}
+
+// RangeReverse
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in descending order.
+func (tree *AVLTree) RangeReverse(lo, hi string) iter.Seq2[string, int] {
+	return cursor.RangeReverse(tree.Cursor, lo, hi)
+}
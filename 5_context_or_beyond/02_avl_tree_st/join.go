@@ -0,0 +1,220 @@
+package avl_tree_st
+
+import (
+	"github.com/lee-hen/Algorithms/util"
+
+	"log"
+)
+
+// Split and join.
+// Joining two AVL trees around a middle node walks down the spine of the taller one to a
+// subtree at most one taller than the shorter one, hangs the middle node there and
+// rebalances on the way up as put does, so it takes time proportional to the difference
+// of the heights. Split cuts the search path and joins the pieces back on the way up;
+// the costs telescope to O(log n). Union, intersection and difference split one tree by
+// the root of the other and recur on both sides (Blelloch, Ferizovic and Sun), which takes
+// O(m log(n/m + 1)) time for trees of sizes m <= n. All of them reuse the nodes of their
+// arguments.
+
+// NewAVLTreeFromSorted
+// Initializes a symbol table with the given keys, which must be in strictly increasing
+// order, and values, in linear time. The tree is perfectly balanced: the sequence of
+// compares done in a search is the one binary search would do.
+func NewAVLTreeFromSorted(keys []string, values []int) *AVLTree {
+	return fromSorted(keys, values, nil)
+}
+
+// NewAugmentedAVLTreeFromSorted
+// Initializes a symbol table augmented with the monoid, as NewAugmentedAVLTree does, with
+// the given keys, which must be in strictly increasing order, and values, in linear time.
+func NewAugmentedAVLTreeFromSorted(monoid util.Monoid, keys []string, values []int) *AVLTree {
+	return fromSorted(keys, values, &monoid)
+}
+
+func fromSorted(keys []string, values []int, m *util.Monoid) *AVLTree {
+	if len(keys) != len(values) {
+		log.Fatalln("keys and values have different lengths")
+	}
+	for i := range keys {
+		if keys[i] == "" {
+			log.Fatalln("calls newAVLTreeFromSorted() with a null key")
+		}
+		if i > 0 && keys[i-1] >= keys[i] {
+			log.Fatalln("keys are not in strictly increasing order")
+		}
+	}
+
+	return &AVLTree{root: build(keys, values, m), monoid: m}
+}
+
+// Builds a perfectly balanced subtree over the keys.
+func build(keys []string, values []int, m *util.Monoid) *Node {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	mid := len(keys) / 2
+	x := newNode(keys[mid], values[mid], 0, len(keys))
+	x.Left = build(keys[:mid], values[:mid], m)
+	x.Right = build(keys[mid+1:], values[mid+1:], m)
+	x.height = 1 + util.Max(height(x.Left), height(x.Right))
+	x.updateAggregate(m)
+	return x
+}
+
+// Split
+// Moves the keys greater than or equal to key into a new symbol table, which it returns,
+// and keeps the smaller keys. Takes O(log n) time.
+func (tree *AVLTree) Split(key string) *AVLTree {
+	if key == "" {
+		log.Fatalln("argument to split() is null")
+	}
+
+	l, mid, r := split(tree.root, key, tree.monoid)
+	if mid != nil {
+		r = join(nil, mid, r, tree.monoid)
+	}
+	tree.root = l
+	return &AVLTree{root: r, monoid: tree.monoid}
+}
+
+// Join
+// Returns the symbol table with the keys of left and right, all of which must be smaller
+// in left than in right, leaving both empty. Takes O(log n) time.
+func Join(left, right *AVLTree) *AVLTree {
+	checkMonoids(left, right)
+	if !left.IsEmpty() && !right.IsEmpty() && left.Max() >= right.Min() {
+		log.Fatalln("calls join() with keys that are not all greater")
+	}
+
+	root := join2(left.root, right.root, left.monoid)
+	left.root, right.root = nil, nil
+	return &AVLTree{root: root, monoid: left.monoid}
+}
+
+// Union
+// Returns the symbol table with the keys of a or b, leaving both empty. The value of
+// a key in both is the one in a.
+func Union(a, b *AVLTree) *AVLTree {
+	checkMonoids(a, b)
+	root := union(a.root, b.root, a.monoid)
+	a.root, b.root = nil, nil
+	return &AVLTree{root: root, monoid: a.monoid}
+}
+
+// Intersection
+// Returns the symbol table with the keys of a that are also in b, and their values in a,
+// leaving both empty.
+func Intersection(a, b *AVLTree) *AVLTree {
+	checkMonoids(a, b)
+	root := intersection(a.root, b.root, a.monoid)
+	a.root, b.root = nil, nil
+	return &AVLTree{root: root, monoid: a.monoid}
+}
+
+// Difference
+// Returns the symbol table with the keys of a that are not in b, and their values in a,
+// leaving both empty.
+func Difference(a, b *AVLTree) *AVLTree {
+	checkMonoids(a, b)
+	root := difference(a.root, b.root, a.monoid)
+	a.root, b.root = nil, nil
+	return &AVLTree{root: root, monoid: a.monoid}
+}
+
+// The nodes of both trees end up in one, so they must agree on keeping aggregates.
+func checkMonoids(a, b *AVLTree) {
+	if a == b {
+		log.Fatalln("calls a set operation with the same symbol table twice")
+	}
+	if (a.monoid == nil) != (b.monoid == nil) {
+		log.Fatalln("calls a set operation with an augmented and a plain symbol table")
+	}
+}
+
+// Recomputes the size, height and aggregate of the node from its children.
+func (x *Node) update(m *util.Monoid) *Node {
+	x.size = 1 + size(x.Left) + size(x.Right)
+	x.height = 1 + util.Max(height(x.Left), height(x.Right))
+	x.updateAggregate(m)
+	return x
+}
+
+// Joins the subtrees l and r, whose keys are smaller and larger than the key of x.
+func join(l, x, r *Node, m *util.Monoid) *Node {
+	if height(l) > height(r)+1 {
+		l.Right = join(l.Right, x, r, m)
+		return balance(l.update(m), m)
+	}
+	if height(r) > height(l)+1 {
+		r.Left = join(l, x, r.Left, m)
+		return balance(r.update(m), m)
+	}
+
+	x.Left, x.Right = l, r
+	return x.update(m)
+}
+
+// Joins the subtrees l and r without a middle node.
+func join2(l, r *Node, m *util.Monoid) *Node {
+	if r == nil {
+		return l
+	}
+
+	x := min(r)
+	return join(l, x, delMin(r, m), m)
+}
+
+// Splits the subtree into the keys less than key, the node with the key if there is
+// one, and the keys greater than key.
+func split(x *Node, key string, m *util.Monoid) (*Node, *Node, *Node) {
+	if x == nil {
+		return nil, nil, nil
+	}
+
+	if key < x.Key {
+		l, mid, r := split(x.Left, key, m)
+		return l, mid, join(r, x, x.Right, m)
+	}
+	if key > x.Key {
+		l, mid, r := split(x.Right, key, m)
+		return join(x.Left, x, l, m), mid, r
+	}
+	return x.Left, x, x.Right
+}
+
+func union(a, b *Node, m *util.Monoid) *Node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	l, _, r := split(b, a.Key, m)
+	al, ar := a.Left, a.Right
+	return join(union(al, l, m), a, union(ar, r, m), m)
+}
+
+func intersection(a, b *Node, m *util.Monoid) *Node {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	l, mid, r := split(b, a.Key, m)
+	al, ar := a.Left, a.Right
+	if mid != nil {
+		return join(intersection(al, l, m), a, intersection(ar, r, m), m)
+	}
+	return join2(intersection(al, l, m), intersection(ar, r, m), m)
+}
+
+func difference(a, b *Node, m *util.Monoid) *Node {
+	if a == nil || b == nil {
+		return a
+	}
+
+	l, _, r := split(a, b.Key, m)
+	bl, br := b.Left, b.Right
+	return join2(difference(l, bl, m), difference(r, br, m), m)
+}
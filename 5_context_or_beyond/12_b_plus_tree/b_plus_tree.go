@@ -0,0 +1,652 @@
+package b_plus_tree
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// A B+ tree keeps every key-value pair in its leaves, which are linked in key order in both
+// directions, and only separator keys in its internal nodes: all keys in children[i] are less
+// than keys[i], and all keys in children[i+1] are greater than or equal to it. A range scan
+// descends once to the first leaf and then follows the leaf links, so reporting k keys takes
+// O(log n + k) time with no further descents, unlike 01_b_tree where values sit in the
+// entries along the search path.
+// Every node holds at most order-1 keys and, except the root, at least (order-1)/2 keys.
+// Internal nodes also keep the number of keys in their subtree for Rank and Select.
+
+type BPlusTree struct {
+	root   *node
+	order  int     // max children per internal node
+	fill   float64 // fraction of a node filled by BulkLoad
+	height int
+}
+
+type node struct {
+	keys []string
+
+	// leaf
+	values     []int
+	prev, next *node // neighbouring leaves in key order
+
+	// internal node
+	children []*node
+	size     int // number of keys in the subtree
+}
+
+func (x *node) isLeaf() bool {
+	return x.children == nil
+}
+
+func size(x *node) int {
+	if x.isLeaf() {
+		return len(x.keys)
+	}
+	return x.size
+}
+
+// recompute the subtree count of an internal node
+func (x *node) updateSize() {
+	if x.isLeaf() {
+		return
+	}
+	x.size = 0
+	for _, child := range x.children {
+		x.size += size(child)
+	}
+}
+
+// index of the child whose subtree would hold key
+func (x *node) child(key string) int {
+	lo, hi := 0, len(x.keys)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if key < x.keys[mid] {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// index of the first key greater than or equal to key
+func (x *node) search(key string) int {
+	lo, hi := 0, len(x.keys)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if x.keys[mid] < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Option
+// Configures a BPlusTree at construction.
+type Option func(*options)
+
+type options struct {
+	order int
+	fill  float64
+}
+
+// WithOrder
+// Sets the max children per internal node, and the max keys per leaf plus one; at least 3.
+func WithOrder(m int) Option {
+	return func(o *options) {
+		if m < 3 {
+			log.Fatalln("B+ tree order must be at least 3")
+		}
+		o.order = m
+	}
+}
+
+// WithFillFactor
+// Sets the fraction of each node that BulkLoad fills, in (0, 1]. Nodes are never filled below
+// half, and leaving room speeds up later inserts between the loaded keys.
+func WithFillFactor(f float64) Option {
+	return func(o *options) {
+		if f <= 0 || f > 1 {
+			log.Fatalln("fill factor must be in (0, 1]")
+		}
+		o.fill = f
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{order: 64, fill: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewBPlusTree
+// Initializes an empty symbol table.
+func NewBPlusTree(opts ...Option) *BPlusTree {
+	o := newOptions(opts)
+	return &BPlusTree{
+		root:  &node{},
+		order: o.order,
+		fill:  o.fill,
+	}
+}
+
+func (tree *BPlusTree) maxKeys() int {
+	return tree.order - 1
+}
+
+func (tree *BPlusTree) minKeys() int {
+	return (tree.order - 1) / 2
+}
+
+// IsEmpty
+// Is this symbol table empty?
+func (tree *BPlusTree) IsEmpty() bool {
+	return tree.Size() == 0
+}
+
+// Size
+// Returns the number of key-value pairs in this symbol table.
+func (tree *BPlusTree) Size() int {
+	return size(tree.root)
+}
+
+// Height
+// Returns the number of internal levels above the leaves.
+func (tree *BPlusTree) Height() int {
+	return tree.height
+}
+
+// the leaf whose key range holds key
+func (tree *BPlusTree) leaf(key string) *node {
+	x := tree.root
+	for !x.isLeaf() {
+		x = x.children[x.child(key)]
+	}
+	return x
+}
+
+// Get
+// Returns the value associated with the given key.
+func (tree *BPlusTree) Get(key string) (int, bool) {
+	if key == "" {
+		log.Fatalln("calls get() with a null key")
+	}
+
+	x := tree.leaf(key)
+	if i := x.search(key); i < len(x.keys) && x.keys[i] == key {
+		return x.values[i], true
+	}
+	return 0, false
+}
+
+// Contains
+// Does this symbol table contain the given key?
+func (tree *BPlusTree) Contains(key string) bool {
+	_, found := tree.Get(key)
+	return found
+}
+
+// Put
+// Inserts the specified key-value pair into the symbol table, overwriting the old
+// value with the new value if the symbol table already contains the specified key.
+func (tree *BPlusTree) Put(key string, value int) {
+	if key == "" {
+		log.Fatalln("first argument to put() is null")
+	}
+
+	sep, right := tree.insert(tree.root, key, value)
+	if right == nil {
+		return
+	}
+
+	// need to split root
+	root := &node{keys: []string{sep}, children: []*node{tree.root, right}}
+	root.updateSize()
+	tree.root = root
+	tree.height++
+}
+
+// insert the key-value pair in the subtree rooted at x; if x splits, returns
+// the smallest key of the new right sibling and the sibling
+func (tree *BPlusTree) insert(x *node, key string, value int) (string, *node) {
+	if x.isLeaf() {
+		i := x.search(key)
+		if i < len(x.keys) && x.keys[i] == key {
+			x.values[i] = value
+			return "", nil
+		}
+
+		x.keys = append(x.keys, "")
+		copy(x.keys[i+1:], x.keys[i:])
+		x.keys[i] = key
+		x.values = append(x.values, 0)
+		copy(x.values[i+1:], x.values[i:])
+		x.values[i] = value
+
+		if len(x.keys) > tree.maxKeys() {
+			return splitLeaf(x)
+		}
+		return "", nil
+	}
+
+	i := x.child(key)
+	sep, right := tree.insert(x.children[i], key, value)
+	if right != nil {
+		x.keys = append(x.keys, "")
+		copy(x.keys[i+1:], x.keys[i:])
+		x.keys[i] = sep
+		x.children = append(x.children, nil)
+		copy(x.children[i+2:], x.children[i+1:])
+		x.children[i+1] = right
+	}
+	x.updateSize()
+
+	if len(x.keys) > tree.maxKeys() {
+		return splitInternal(x)
+	}
+	return "", nil
+}
+
+// split a leaf in half and link the new right half after it
+func splitLeaf(x *node) (string, *node) {
+	mid := len(x.keys) / 2
+	right := &node{
+		keys:   append([]string(nil), x.keys[mid:]...),
+		values: append([]int(nil), x.values[mid:]...),
+		prev:   x,
+		next:   x.next,
+	}
+	x.keys, x.values = x.keys[:mid:mid], x.values[:mid:mid]
+	if x.next != nil {
+		x.next.prev = right
+	}
+	x.next = right
+	return right.keys[0], right
+}
+
+// split an internal node in half, moving the middle key up
+func splitInternal(x *node) (string, *node) {
+	mid := len(x.keys) / 2
+	sep := x.keys[mid]
+	right := &node{
+		keys:     append([]string(nil), x.keys[mid+1:]...),
+		children: append([]*node(nil), x.children[mid+1:]...),
+	}
+	x.keys, x.children = x.keys[:mid:mid], x.children[:mid+1:mid+1]
+	x.updateSize()
+	right.updateSize()
+	return sep, right
+}
+
+// Del
+// Removes the specified key and its associated value from this symbol table
+// (if the key is in this symbol table).
+func (tree *BPlusTree) Del(key string) {
+	if key == "" {
+		log.Fatalln("argument to delete() is null")
+	}
+
+	if !tree.remove(tree.root, key) {
+		return
+	}
+
+	// an internal root left with one child is replaced by the child
+	if !tree.root.isLeaf() && len(tree.root.keys) == 0 {
+		tree.root = tree.root.children[0]
+		tree.height--
+	}
+}
+
+// DelMin
+// Removes the smallest key and associated value from the symbol table.
+func (tree *BPlusTree) DelMin() {
+	tree.Del(tree.Min())
+}
+
+// DelMax
+// Removes the largest key and associated value from the symbol table.
+func (tree *BPlusTree) DelMax() {
+	tree.Del(tree.Max())
+}
+
+// remove the key from the subtree rooted at x; a child left with too few keys
+// borrows one from a sibling or is merged with it
+func (tree *BPlusTree) remove(x *node, key string) bool {
+	if x.isLeaf() {
+		i := x.search(key)
+		if i == len(x.keys) || x.keys[i] != key {
+			return false
+		}
+		x.keys = append(x.keys[:i], x.keys[i+1:]...)
+		x.values = append(x.values[:i], x.values[i+1:]...)
+		return true
+	}
+
+	// a separator equal to the removed key still separates the children correctly
+	i := x.child(key)
+	if !tree.remove(x.children[i], key) {
+		return false
+	}
+	if len(x.children[i].keys) < tree.minKeys() {
+		tree.rebalance(x, i)
+	}
+	x.updateSize()
+	return true
+}
+
+// restore the occupancy of child i of x, which has one key too few
+func (tree *BPlusTree) rebalance(x *node, i int) {
+	child := x.children[i]
+
+	if i > 0 && len(x.children[i-1].keys) > tree.minKeys() {
+		left := x.children[i-1]
+		last := len(left.keys) - 1
+		if child.isLeaf() {
+			child.keys = append([]string{left.keys[last]}, child.keys...)
+			child.values = append([]int{left.values[last]}, child.values...)
+			left.keys, left.values = left.keys[:last], left.values[:last]
+			x.keys[i-1] = child.keys[0]
+		} else {
+			// rotate through the parent
+			child.keys = append([]string{x.keys[i-1]}, child.keys...)
+			child.children = append([]*node{left.children[last+1]}, child.children...)
+			x.keys[i-1] = left.keys[last]
+			left.keys, left.children = left.keys[:last], left.children[:last+1]
+		}
+		left.updateSize()
+		child.updateSize()
+		return
+	}
+
+	if i+1 < len(x.children) && len(x.children[i+1].keys) > tree.minKeys() {
+		right := x.children[i+1]
+		if child.isLeaf() {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.keys, right.values = right.keys[1:], right.values[1:]
+			x.keys[i] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, x.keys[i])
+			child.children = append(child.children, right.children[0])
+			x.keys[i] = right.keys[0]
+			right.keys, right.children = right.keys[1:], right.children[1:]
+		}
+		right.updateSize()
+		child.updateSize()
+		return
+	}
+
+	// both siblings have the minimum number of keys: merge with one of them
+	if i > 0 {
+		merge(x, i-1)
+	} else {
+		merge(x, i)
+	}
+}
+
+// merge child i+1 of x into child i
+func merge(x *node, i int) {
+	left, right := x.children[i], x.children[i+1]
+	if left.isLeaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+	} else {
+		left.keys = append(append(left.keys, x.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+		left.updateSize()
+	}
+
+	x.keys = append(x.keys[:i], x.keys[i+1:]...)
+	x.children = append(x.children[:i+1], x.children[i+2:]...)
+}
+
+// Ordered symbol table methods.
+
+// the leftmost and rightmost leaves
+func (tree *BPlusTree) first() *node {
+	x := tree.root
+	for !x.isLeaf() {
+		x = x.children[0]
+	}
+	return x
+}
+
+func (tree *BPlusTree) last() *node {
+	x := tree.root
+	for !x.isLeaf() {
+		x = x.children[len(x.children)-1]
+	}
+	return x
+}
+
+// Min
+// Returns the smallest key in the symbol table.
+func (tree *BPlusTree) Min() string {
+	if tree.IsEmpty() {
+		log.Fatalln("calls min() with empty symbol table")
+	}
+	return tree.first().keys[0]
+}
+
+// Max
+// Returns the largest key in the symbol table.
+func (tree *BPlusTree) Max() string {
+	if tree.IsEmpty() {
+		log.Fatalln("calls max() with empty symbol table")
+	}
+	x := tree.last()
+	return x.keys[len(x.keys)-1]
+}
+
+// Floor
+// Returns the largest key in the symbol table less than or equal to key, or "" if there is none.
+func (tree *BPlusTree) Floor(key string) string {
+	if key == "" {
+		log.Fatalln("argument to floor() is null")
+	}
+
+	x := tree.leaf(key)
+	i := x.search(key)
+	if i < len(x.keys) && x.keys[i] == key {
+		return key
+	}
+	if i > 0 {
+		return x.keys[i-1]
+	}
+	// the leaf holds no smaller key; the previous leaf ends with the floor
+	if x.prev != nil {
+		return x.prev.keys[len(x.prev.keys)-1]
+	}
+	return ""
+}
+
+// Ceiling
+// Returns the smallest key in the symbol table greater than or equal to key, or "" if there is none.
+func (tree *BPlusTree) Ceiling(key string) string {
+	if key == "" {
+		log.Fatalln("argument to ceiling() is null")
+	}
+
+	x := tree.leaf(key)
+	if i := x.search(key); i < len(x.keys) {
+		return x.keys[i]
+	}
+	if x.next != nil {
+		return x.next.keys[0]
+	}
+	return ""
+}
+
+// Rank
+// Return the number of keys in the symbol table strictly less than key.
+func (tree *BPlusTree) Rank(key string) int {
+	if key == "" {
+		log.Fatalln("argument to rank() is null")
+	}
+
+	rank := 0
+	x := tree.root
+	for !x.isLeaf() {
+		i := x.child(key)
+		for _, child := range x.children[:i] {
+			rank += size(child)
+		}
+		x = x.children[i]
+	}
+	return rank + x.search(key)
+}
+
+// Select
+// Return the key in the symbol table of a given rank.
+func (tree *BPlusTree) Select(rank int) string {
+	if rank < 0 || rank >= tree.Size() {
+		log.Fatalf("argument to select() is invalid: %d\n", rank)
+	}
+
+	x := tree.root
+	for !x.isLeaf() {
+		i := 0
+		for rank >= size(x.children[i]) {
+			rank -= size(x.children[i])
+			i++
+		}
+		x = x.children[i]
+	}
+	return x.keys[rank]
+}
+
+// Keys
+// Returns all keys in the symbol table in order.
+func (tree *BPlusTree) Keys() []string {
+	keys := make([]string, 0, tree.Size())
+	for x := tree.first(); x != nil; x = x.next {
+		keys = append(keys, x.keys...)
+	}
+	return keys
+}
+
+// KeysBetween
+// Returns all keys in the symbol table in the given range, in order.
+func (tree *BPlusTree) KeysBetween(lo, hi string) []string {
+	if lo == "" {
+		log.Fatalln("first argument to keys() is null")
+	}
+	if hi == "" {
+		log.Fatalln("second argument to keys() is null")
+	}
+
+	keys := make([]string, 0)
+	for key := range tree.Range(lo, hi) {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SizeBetween
+// Returns the number of keys in the symbol table in the given range.
+func (tree *BPlusTree) SizeBetween(lo, hi string) int {
+	if lo > hi {
+		return 0
+	}
+	if tree.Contains(hi) {
+		return tree.Rank(hi) - tree.Rank(lo) + 1
+	}
+	return tree.Rank(hi) - tree.Rank(lo)
+}
+
+// Returns a string representation of this B+ tree (for debugging).
+func (tree *BPlusTree) String() string {
+	s := strings.Builder{}
+	var print func(x *node, indent string)
+	print = func(x *node, indent string) {
+		if x.isLeaf() {
+			for i, key := range x.keys {
+				s.WriteString(fmt.Sprintf("%s%s %d\n", indent, key, x.values[i]))
+			}
+			return
+		}
+		for i, child := range x.children {
+			if i > 0 {
+				s.WriteString(fmt.Sprintf("%s(%s)\n", indent, x.keys[i-1]))
+			}
+			print(child, indent+"     ")
+		}
+	}
+	print(tree.root, "")
+	return s.String()
+}
+
+// Check
+// integrity check of the B+ tree: keys within their separators, node occupancy,
+// all leaves at the same depth, subtree counts, and the leaf links
+func Check(tree *BPlusTree) bool {
+	if !tree.isBPlusTree(tree.root, "", "", 0, true) {
+		fmt.Println("Not a B+ tree")
+		return false
+	}
+
+	if !tree.isLinked() {
+		fmt.Println("Leaf links not consistent")
+		return false
+	}
+	return true
+}
+
+// are the keys of the subtree rooted at x in [lo, hi) ("" for no bound), sorted, with
+// valid occupancy and counts, and all leaves at depth height?
+func (tree *BPlusTree) isBPlusTree(x *node, lo, hi string, depth int, root bool) bool {
+	if len(x.keys) > tree.maxKeys() || (!root && len(x.keys) < tree.minKeys()) {
+		return false
+	}
+	for i, key := range x.keys {
+		if (lo != "" && key < lo) || (hi != "" && key >= hi) || (i > 0 && x.keys[i-1] >= key) {
+			return false
+		}
+	}
+
+	if x.isLeaf() {
+		return depth == tree.height && len(x.values) == len(x.keys)
+	}
+
+	if len(x.children) != len(x.keys)+1 || (root && len(x.keys) == 0) {
+		return false
+	}
+	n := 0
+	for i, child := range x.children {
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = x.keys[i-1]
+		}
+		if i < len(x.keys) {
+			childHi = x.keys[i]
+		}
+		if !tree.isBPlusTree(child, childLo, childHi, depth+1, false) {
+			return false
+		}
+		n += size(child)
+	}
+	return n == x.size
+}
+
+// do the leaf links visit every key in order, forwards and backwards?
+func (tree *BPlusTree) isLinked() bool {
+	n := 0
+	var prev *node
+	for x := tree.first(); x != nil; x = x.next {
+		if x.prev != prev || (prev != nil && prev.keys[len(prev.keys)-1] >= x.keys[0]) {
+			return false
+		}
+		n += len(x.keys)
+		prev = x
+	}
+	return prev == tree.last() && n == tree.Size()
+}
@@ -0,0 +1,134 @@
+package b_plus_tree
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCase1(t *testing.T) {
+	test := "S E A R C H E X A M P L E"
+	keys := strings.Split(test, " ")
+
+	tree := NewBPlusTree(WithOrder(3))
+	for i := 0; i < len(keys); i++ {
+		tree.Put(keys[i], i)
+	}
+	require.True(t, Check(tree))
+
+	require.Equal(t, []string{"A", "C", "E", "H", "L", "M", "P", "R", "S", "X"}, tree.Keys())
+	value, _ := tree.Get("E")
+	require.Equal(t, 12, value)
+	require.Equal(t, "H", tree.Floor("I"))
+	require.Equal(t, "L", tree.Ceiling("I"))
+	require.Equal(t, "", tree.Floor("0"))
+	require.Equal(t, "", tree.Ceiling("Y"))
+	require.Equal(t, 4, tree.Rank("I"))
+	require.Equal(t, "L", tree.Select(4))
+	require.Equal(t, []string{"H", "L", "M"}, tree.KeysBetween("F", "N"))
+	require.Equal(t, 3, tree.SizeBetween("F", "N"))
+
+	var reverse []string
+	for key := range tree.RangeReverse("D", "M") {
+		reverse = append(reverse, key)
+	}
+	require.Equal(t, []string{"M", "L", "H", "E"}, reverse)
+
+	var backward []string
+	for key := range tree.Backward() {
+		backward = append(backward, key)
+	}
+	require.Equal(t, []string{"X", "S", "R", "P", "M", "L", "H", "E", "C", "A"}, backward)
+
+	tree.Del("M")
+	tree.DelMin()
+	tree.DelMax()
+	require.True(t, Check(tree))
+	require.Equal(t, []string{"C", "E", "H", "L", "P", "R", "S"}, tree.Keys())
+}
+
+func TestCase2(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 16} {
+		r := rand.New(rand.NewSource(int64(order)))
+		tree := NewBPlusTree(WithOrder(order))
+		values := make(map[string]int)
+		for i := 0; i < 5000; i++ {
+			key := fmt.Sprintf("%04d", r.Intn(1000))
+			if r.Intn(3) == 0 {
+				tree.Del(key)
+				delete(values, key)
+			} else {
+				tree.Put(key, i)
+				values[key] = i
+			}
+		}
+		require.True(t, Check(tree))
+		require.Equal(t, len(values), tree.Size())
+
+		keys := make([]string, 0, len(values))
+		for key, value := range values {
+			v, found := tree.Get(key)
+			require.True(t, found)
+			require.Equal(t, value, v)
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		require.Equal(t, keys, tree.Keys())
+		for i, key := range keys {
+			require.Equal(t, i, tree.Rank(key))
+			require.Equal(t, key, tree.Select(i))
+		}
+
+		for tree.Size() > 0 {
+			tree.DelMin()
+		}
+		require.True(t, Check(tree))
+		require.Equal(t, 0, tree.Height())
+	}
+}
+
+func TestCase3(t *testing.T) {
+	// an ordered stream with one duplicate and a few late keys appended
+	n := 10000
+	keys := make([]string, 0, n+3)
+	values := make([]int, 0, n+3)
+	for i := 0; i < n; i++ {
+		keys = append(keys, fmt.Sprintf("t%06d", 2*i))
+		values = append(values, i)
+	}
+	keys = append(keys, "t000001", "t000003", "t000000")
+	values = append(values, -1, -3, -100)
+
+	for _, fill := range []float64{1, 0.7, 0.1} {
+		for _, order := range []int{3, 4, 64} {
+			tree := BulkLoad(keys, values, WithOrder(order), WithFillFactor(fill))
+			require.True(t, Check(tree))
+			require.Equal(t, n+2, tree.Size())
+
+			value, _ := tree.Get("t000000")
+			require.Equal(t, -100, value)
+			value, _ = tree.Get("t000003")
+			require.Equal(t, -3, value)
+
+			var scan []string
+			for key, value := range tree.Range("t000100", "t000110") {
+				require.Equal(t, key, fmt.Sprintf("t%06d", 2*value))
+				scan = append(scan, key)
+			}
+			require.Equal(t, []string{"t000100", "t000102", "t000104", "t000106", "t000108", "t000110"}, scan)
+
+			// the loaded tree keeps working as a dynamic tree
+			for i := 0; i < 1000; i++ {
+				tree.Put(fmt.Sprintf("t%06d", 2*i+1), i)
+				tree.Del(fmt.Sprintf("t%06d", 4*i))
+			}
+			require.True(t, Check(tree))
+		}
+	}
+
+	require.True(t, BulkLoad(nil, nil).IsEmpty())
+}
@@ -0,0 +1,120 @@
+package b_plus_tree
+
+import (
+	timSort "github.com/lee-hen/Algorithms/2_sorting/32_tim_sort"
+
+	"log"
+)
+
+// BulkLoad
+// Builds a B+ tree from the key-value pairs bottom up in linear time after sorting: the pairs
+// are packed into leaves filled to the fill factor, and each level of internal nodes is built
+// from the one below. The pairs are sorted by key with Timsort (32_tim_sort), which takes a
+// single linear pass when they already arrive in order, as a stream of time-series keys does,
+// and is stable, so the last of several pairs with the same key wins, as with repeated Puts.
+func BulkLoad(keys []string, values []int, opts ...Option) *BPlusTree {
+	if len(keys) != len(values) {
+		log.Fatalln("keys and values must have the same length")
+	}
+
+	tree := NewBPlusTree(opts...)
+	for _, key := range keys {
+		if key == "" {
+			log.Fatalln("calls bulkLoad() with a null key")
+		}
+	}
+
+	index := make([]int, len(keys))
+	for i := range index {
+		index[i] = i
+	}
+	timSort.SortFunc(index, func(x, y int) bool {
+		return keys[x] < keys[y]
+	})
+
+	// keep the last pair of each run of equal keys
+	sortedKeys := make([]string, 0, len(keys))
+	sortedValues := make([]int, 0, len(keys))
+	for i, j := range index {
+		if i+1 < len(index) && keys[index[i+1]] == keys[j] {
+			continue
+		}
+		sortedKeys = append(sortedKeys, keys[j])
+		sortedValues = append(sortedValues, values[j])
+	}
+	if len(sortedKeys) == 0 {
+		return tree
+	}
+
+	// pack the leaves
+	groups := tree.groups(len(sortedKeys), tree.minKeys(), tree.maxKeys())
+	level := make([]*node, len(groups))
+	mins := make([]string, len(groups)) // smallest key of each subtree
+	lo := 0
+	for i, n := range groups {
+		level[i] = &node{
+			keys:   sortedKeys[lo : lo+n : lo+n],
+			values: sortedValues[lo : lo+n : lo+n],
+		}
+		mins[i] = sortedKeys[lo]
+		if i > 0 {
+			level[i].prev, level[i-1].next = level[i-1], level[i]
+		}
+		lo += n
+	}
+
+	// build the internal levels, counting children rather than keys
+	for len(level) > 1 {
+		groups = tree.groups(len(level), tree.minKeys()+1, tree.order)
+		parents := make([]*node, len(groups))
+		parentMins := make([]string, len(groups))
+		lo = 0
+		for i, n := range groups {
+			x := &node{
+				keys:     append([]string(nil), mins[lo+1:lo+n]...),
+				children: level[lo : lo+n : lo+n],
+			}
+			x.updateSize()
+			parents[i], parentMins[i] = x, mins[lo]
+			lo += n
+		}
+		level, mins = parents, parentMins
+		tree.height++
+	}
+
+	tree.root = level[0]
+	return tree
+}
+
+// sizes of the nodes that n items are split into: as close to the fill factor as possible
+// while keeping every node between min and max items, spread evenly
+func (tree *BPlusTree) groups(n, min, max int) []int {
+	target := int(tree.fill * float64(max))
+	if target < min {
+		target = min
+	}
+	if target < 1 {
+		target = 1
+	}
+
+	// with k nodes of n/k or n/k+1 items, k must be in [ceil(n/max), floor(n/min)]
+	k := (n + target - 1) / target
+	if upper := n / min; min > 0 && k > upper {
+		k = upper
+	}
+	if lower := (n + max - 1) / max; k < lower {
+		k = lower
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	groups := make([]int, k)
+	for i := range groups {
+		groups[i] = n / k
+		if i < n%k {
+			groups[i]++
+		}
+	}
+	return groups
+}
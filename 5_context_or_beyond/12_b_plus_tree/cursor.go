@@ -0,0 +1,156 @@
+package b_plus_tree
+
+import "iter"
+
+// Cursor
+// A position in the symbol table that moves in key order along the leaf links.
+// Next and Prev take constant time and Seek takes time proportional to the height.
+// Modifying the symbol table invalidates its cursors.
+type Cursor struct {
+	tree *BPlusTree
+	leaf *node // nil if the cursor is not positioned
+	i    int   // index of the current key in leaf
+}
+
+// Cursor
+// Returns a cursor that is not positioned yet; call First, Last, Seek or SeekFloor.
+func (tree *BPlusTree) Cursor() *Cursor {
+	return &Cursor{tree: tree}
+}
+
+// Valid
+// Returns true if the cursor is positioned at a key.
+func (c *Cursor) Valid() bool {
+	return c.leaf != nil
+}
+
+// Key
+// Returns the key at the cursor.
+func (c *Cursor) Key() string {
+	return c.leaf.keys[c.i]
+}
+
+// Value
+// Returns the value at the cursor.
+func (c *Cursor) Value() int {
+	return c.leaf.values[c.i]
+}
+
+// First
+// Moves to the smallest key.
+func (c *Cursor) First() bool {
+	c.leaf, c.i = c.tree.first(), 0
+	return c.settle()
+}
+
+// Last
+// Moves to the largest key.
+func (c *Cursor) Last() bool {
+	c.leaf = c.tree.last()
+	c.i = len(c.leaf.keys) - 1
+	return c.settle()
+}
+
+// Seek
+// Moves to the smallest key greater than or equal to key.
+func (c *Cursor) Seek(key string) bool {
+	c.leaf = c.tree.leaf(key)
+	c.i = c.leaf.search(key)
+	if c.i == len(c.leaf.keys) {
+		c.leaf, c.i = c.leaf.next, 0
+	}
+	return c.settle()
+}
+
+// SeekFloor
+// Moves to the largest key less than or equal to key.
+func (c *Cursor) SeekFloor(key string) bool {
+	c.leaf = c.tree.leaf(key)
+	c.i = c.leaf.search(key)
+	if c.i < len(c.leaf.keys) && c.leaf.keys[c.i] == key {
+		return true
+	}
+	return c.Prev()
+}
+
+// Next
+// Moves to the next larger key.
+func (c *Cursor) Next() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.i++
+	if c.i == len(c.leaf.keys) {
+		c.leaf, c.i = c.leaf.next, 0
+	}
+	return c.settle()
+}
+
+// Prev
+// Moves to the next smaller key.
+func (c *Cursor) Prev() bool {
+	if !c.Valid() {
+		return false
+	}
+	c.i--
+	if c.i < 0 {
+		c.leaf = c.leaf.prev
+		if c.leaf != nil {
+			c.i = len(c.leaf.keys) - 1
+		}
+	}
+	return c.settle()
+}
+
+// invalidate the cursor if it is past either end; only an empty root leaf has no keys
+func (c *Cursor) settle() bool {
+	if c.leaf != nil && (c.i < 0 || c.i >= len(c.leaf.keys)) {
+		c.leaf = nil
+	}
+	return c.Valid()
+}
+
+// All
+// Returns an iterator over the key-value pairs in ascending key order.
+func (tree *BPlusTree) All() iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		for x := tree.first(); x != nil; x = x.next {
+			for i, key := range x.keys {
+				if !yield(key, x.values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Backward
+// Returns an iterator over the key-value pairs in descending key order.
+func (tree *BPlusTree) Backward() iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := tree.Cursor()
+		for ok := c.Last(); ok && yield(c.Key(), c.Value()); ok = c.Prev() {
+		}
+	}
+}
+
+// Range
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in ascending order.
+// It descends to lo once and then follows the leaf links.
+func (tree *BPlusTree) Range(lo, hi string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := tree.Cursor()
+		for ok := c.Seek(lo); ok && c.Key() <= hi && yield(c.Key(), c.Value()); ok = c.Next() {
+		}
+	}
+}
+
+// RangeReverse
+// Returns an iterator over the key-value pairs with keys in [lo, hi] in descending order.
+func (tree *BPlusTree) RangeReverse(lo, hi string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		c := tree.Cursor()
+		for ok := c.SeekFloor(hi); ok && c.Key() >= lo && yield(c.Key(), c.Value()); ok = c.Prev() {
+		}
+	}
+}
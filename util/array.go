@@ -0,0 +1,34 @@
+package util
+
+// Array
+// The operations the compare-based sorts in 2_sorting perform on the array they sort, so that
+// one implementation sorts a plain []int as well as an instrumented array that counts and
+// traces every compare, exchange and write (2_sorting/33_sort_trace).
+type Array interface {
+	Len() int
+	Get(i int) int
+	Set(i, v int)
+	Less(i, j int) bool      // is a[i] < a[j]?
+	LessValue(v, w int) bool // is v < w? For an element held outside the array (half exchanges, merges).
+	Exch(i, j int)
+}
+
+func (p IntSlice) Get(i int) int           { return p[i] }
+func (p IntSlice) Set(i, v int)            { p[i] = v }
+func (p IntSlice) Less(i, j int) bool      { return p[i] < p[j] }
+func (p IntSlice) LessValue(v, w int) bool { return v < w }
+func (p IntSlice) Exch(i, j int)           { p[i], p[j] = p[j], p[i] }
+
+type lessFunc struct {
+	IntSlice
+	less func(i, j int) bool
+}
+
+func (p lessFunc) Less(i, j int) bool { return p.less(i, j) }
+
+// LessFunc
+// Returns the Array over a in which a[i] < a[j] when less(i, j) is true.
+// LessValue keeps the natural order.
+func LessFunc(a []int, less func(i, j int) bool) Array {
+	return lessFunc{IntSlice: a, less: less}
+}
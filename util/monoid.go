@@ -0,0 +1,30 @@
+package util
+
+import "math"
+
+// Monoid
+// An associative operation on ints with its identity element. The balanced search trees
+// keep the combination of the values in each subtree, in key order, under a monoid
+// so that a range of keys can be aggregated in logarithmic time.
+type Monoid struct {
+	Identity int
+	Combine  func(a, b int) int
+}
+
+// SumMonoid
+// Aggregates the sum of the values.
+var SumMonoid = Monoid{Identity: 0, Combine: func(a, b int) int {
+	return a + b
+}}
+
+// MaxMonoid
+// Aggregates the largest value, math.MinInt for none.
+var MaxMonoid = Monoid{Identity: math.MinInt, Combine: func(a, b int) int {
+	return Max(a, b)
+}}
+
+// MinMonoid
+// Aggregates the smallest value, math.MaxInt for none.
+var MinMonoid = Monoid{Identity: math.MaxInt, Combine: func(a, b int) int {
+	return Min(a, b)
+}}
@@ -0,0 +1,190 @@
+package util
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Random
+// A seedable source of random numbers with the samplers of the book's StdRandom. Everything
+// drawn from a Random depends on its seed alone, so an experiment replays from its seed;
+// the methods of rand.Rand, such as Intn, Float64 and Perm, are available as well.
+// A Random is not safe for concurrent use, except for the Default one.
+type Random struct {
+	*rand.Rand
+	seed int64
+}
+
+// NewRandom
+// Returns a Random seeded with seed.
+func NewRandom(seed int64) *Random {
+	return &Random{Rand: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// a rand.Source that can be shared by goroutines
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// reseeds the source and records the seed in *record, under the lock of the source
+func (s *lockedSource) reseed(seed int64, record *int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+	*record = seed
+}
+
+var defaultSource, defaultRandom = func() (*lockedSource, *Random) {
+	seed := time.Now().UnixNano()
+	src := &lockedSource{src: rand.NewSource(seed)}
+	return src, &Random{Rand: rand.New(src), seed: seed}
+}()
+
+// Default
+// Returns the Random used by the functions of util and the generators when none is given:
+// seeded once with the time the program started, and safe for concurrent use.
+func Default() *Random {
+	return defaultRandom
+}
+
+// Seed
+// Reseeds the Default Random, to replay everything drawn from it since.
+func Seed(seed int64) {
+	defaultSource.reseed(seed, &defaultRandom.seed)
+}
+
+// InitialSeed
+// Returns the seed that the Random was created with, or for the Default Random, the seed
+// last given to the function Seed.
+func (r *Random) InitialSeed() int64 {
+	if r == defaultRandom {
+		defaultSource.mu.Lock()
+		defer defaultSource.mu.Unlock()
+	}
+	return r.seed
+}
+
+// Uniform
+// Returns a random real number uniformly in [a, b).
+func (r *Random) Uniform(a, b float64) float64 {
+	if !(a < b) {
+		log.Fatalf("invalid range: [%g, %g)", a, b)
+	}
+	return a + r.Float64()*(b-a)
+}
+
+// UniformInt
+// Returns a random integer uniformly in [a, b).
+func (r *Random) UniformInt(a, b int) int {
+	if b <= a {
+		log.Fatalf("invalid range: [%d, %d)", a, b)
+	}
+	return a + r.Intn(b-a)
+}
+
+// Bernoulli
+// Returns a random boolean from a Bernoulli distribution with success probability p.
+func (r *Random) Bernoulli(p float64) bool {
+	if !(p >= 0.0 && p <= 1.0) {
+		log.Fatalln("probability p must be between 0.0 and 1.0: ", p)
+	}
+	return r.Float64() < p
+}
+
+// Gaussian
+// Returns a random real number from a Gaussian distribution with mean mu and standard
+// deviation sigma.
+func (r *Random) Gaussian(mu, sigma float64) float64 {
+	return mu + sigma*r.NormFloat64()
+}
+
+// Discrete
+// Returns a random integer from the discrete distribution in which i has probability
+// probabilities[i]. The probabilities must be nonnegative and sum to 1.
+func (r *Random) Discrete(probabilities []float64) int {
+	const epsilon = 1e-14
+	sum := 0.0
+	for i, p := range probabilities {
+		if !(p >= 0.0) {
+			log.Fatalf("probability %d is negative: %g", i, p)
+		}
+		sum += p
+	}
+	if sum > 1.0+epsilon || sum < 1.0-epsilon {
+		log.Fatalf("probabilities sum to %g, not 1", sum)
+	}
+
+	// the loop ends unless rounding left the sum just short of x
+	for {
+		x := r.Float64()
+		sum = 0.0
+		for i, p := range probabilities {
+			sum += p
+			if sum > x {
+				return i
+			}
+		}
+	}
+}
+
+// Exponential
+// Returns a random real number from an exponential distribution with rate lambda.
+func (r *Random) Exponential(lambda float64) float64 {
+	if !(lambda > 0.0) {
+		log.Fatalln("lambda must be positive: ", lambda)
+	}
+	return r.ExpFloat64() / lambda
+}
+
+// Poisson
+// Returns a random integer from a Poisson distribution with mean lambda: the number of
+// arrivals of rate 1 before time lambda, counted in O(lambda) time.
+func (r *Random) Poisson(lambda float64) int {
+	if !(lambda > 0.0) || math.IsInf(lambda, 0) {
+		log.Fatalln("lambda must be positive and finite: ", lambda)
+	}
+
+	k := 0
+	for t := r.ExpFloat64(); t < lambda; t += r.ExpFloat64() {
+		k++
+	}
+	return k
+}
+
+// ShuffleSlice
+// Rearranges the elements of the slice in uniformly random order (Knuth).
+func (r *Random) ShuffleSlice(slice Interface) {
+	n := slice.Len()
+	for i := 0; i < n; i++ {
+		// choose index uniformly in [0, i]
+		slice.Swap(i, r.Intn(i+1))
+	}
+}
+
+// ShuffleIntSlice
+// Rearranges the elements of a in uniformly random order.
+func (r *Random) ShuffleIntSlice(a []int) {
+	r.ShuffleSlice(IntSlice(a))
+}
+
+// ShuffleStringSlice
+// Rearranges the elements of a in uniformly random order.
+func (r *Random) ShuffleStringSlice(a []string) {
+	r.ShuffleSlice(StringSlice(a))
+}
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"sort"
+	"sync"
+	"testing"
+)
+
+// a sample of every sampler, in order
+func draw(r *Random) []float64 {
+	var x []float64
+	for i := 0; i < 20; i++ {
+		x = append(x,
+			r.Uniform(-1, 1),
+			float64(r.UniformInt(0, 100)),
+			r.Gaussian(0, 1),
+			float64(r.Discrete([]float64{0.25, 0.25, 0.5})),
+			r.Exponential(2),
+			float64(r.Poisson(5)),
+		)
+		if r.Bernoulli(0.5) {
+			x = append(x, 1)
+		}
+	}
+	a := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r.ShuffleIntSlice(a)
+	for _, v := range a {
+		x = append(x, float64(v))
+	}
+	return x
+}
+
+func TestCase1(t *testing.T) {
+	// the same seed replays the same numbers
+	r := NewRandom(42)
+	require.Equal(t, int64(42), r.InitialSeed())
+	require.Equal(t, draw(NewRandom(42)), draw(r))
+	require.NotEqual(t, draw(NewRandom(42)), draw(NewRandom(43)))
+
+	Seed(7)
+	require.Equal(t, int64(7), Default().InitialSeed())
+	x := draw(Default())
+	Seed(7)
+	require.Equal(t, x, draw(Default()))
+	require.Equal(t, x, draw(NewRandom(7)))
+
+	// the Default Random may be reseeded and drawn from by several goroutines
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				Seed(int64(g))
+				Default().Intn(10)
+				Default().InitialSeed()
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// the mean and variance of n values of f
+func moments(n int, f func() float64) (mean, variance float64) {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = f()
+		mean += x[i]
+	}
+	mean /= float64(n)
+	for _, v := range x {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, variance / float64(n-1)
+}
+
+func TestCase2(t *testing.T) {
+	const n = 200000
+	r := NewRandom(1)
+
+	mean, variance := moments(n, func() float64 {
+		x := r.Uniform(2, 5)
+		require.True(t, x >= 2 && x < 5)
+		return x
+	})
+	require.InDelta(t, 3.5, mean, 0.01)
+	require.InDelta(t, 0.75, variance, 0.01)
+
+	mean, _ = moments(n, func() float64 {
+		x := r.UniformInt(-3, 4)
+		require.True(t, x >= -3 && x < 4)
+		return float64(x)
+	})
+	require.InDelta(t, 0, mean, 0.02)
+
+	mean, _ = moments(n, func() float64 {
+		if r.Bernoulli(0.3) {
+			return 1
+		}
+		return 0
+	})
+	require.InDelta(t, 0.3, mean, 0.005)
+
+	mean, variance = moments(n, func() float64 {
+		return r.Gaussian(10, 2)
+	})
+	require.InDelta(t, 10, mean, 0.02)
+	require.InDelta(t, 4, variance, 0.05)
+
+	counts := make([]int, 3)
+	for i := 0; i < n; i++ {
+		counts[r.Discrete([]float64{0.5, 0.3, 0.2})]++
+	}
+	require.InDelta(t, 0.5, float64(counts[0])/n, 0.005)
+	require.InDelta(t, 0.3, float64(counts[1])/n, 0.005)
+	require.InDelta(t, 0.2, float64(counts[2])/n, 0.005)
+
+	mean, variance = moments(n, func() float64 {
+		x := r.Exponential(4)
+		require.True(t, x >= 0)
+		return x
+	})
+	require.InDelta(t, 0.25, mean, 0.003)
+	require.InDelta(t, 0.0625, variance, 0.002)
+
+	mean, variance = moments(n, func() float64 {
+		x := r.Poisson(3)
+		require.True(t, x >= 0)
+		return float64(x)
+	})
+	require.InDelta(t, 3, mean, 0.02)
+	require.InDelta(t, 3, variance, 0.05)
+}
+
+func TestCase3(t *testing.T) {
+	// a shuffle is a permutation, and every permutation of 4 is about as likely
+	r := NewRandom(3)
+	index := map[string]int{}
+	counts := make([]int, 24)
+	for i := 0; i < 24000; i++ {
+		a := []string{"a", "b", "c", "d"}
+		r.ShuffleStringSlice(a)
+		key := a[0] + a[1] + a[2] + a[3]
+		if _, ok := index[key]; !ok {
+			index[key] = len(index)
+		}
+		counts[index[key]]++
+
+		sort.Strings(a)
+		require.Equal(t, []string{"a", "b", "c", "d"}, a)
+	}
+	require.Len(t, index, 24)
+
+	_, df, p := ChiSquare(counts)
+	require.Equal(t, 23, df)
+	require.Greater(t, p, 0.001)
+}
@@ -0,0 +1,61 @@
+package util
+
+import "math"
+
+// ChiSquare
+// Pearson's chi-square statistic of the observed counts against the uniform distribution over
+// len(observed) buckets, with its degrees of freedom and upper-tail p-value. A p-value near 0
+// means the counts are too uneven to come from a uniform hash, near 1 suspiciously even.
+// The test wants an expected count of at least 5 per bucket, so when there are fewer counts than
+// that consecutive buckets are added up into groups that each expect at least 5, and the test runs
+// over the groups. The p-value uses the Wilson-Hilferty normal approximation, which is good for df >= 3.
+func ChiSquare(observed []int) (stat float64, df int, p float64) {
+	k := len(observed)
+	total := 0
+	for _, o := range observed {
+		total += o
+	}
+	if total == 0 {
+		return 0, 0, 1
+	}
+
+	// buckets per group, so that every group expects at least 5; the last group takes the remainder
+	width := int(math.Ceil(minExpected * float64(k) / float64(total)))
+	groups := k / width
+	if groups < 2 {
+		return 0, 0, 1
+	}
+
+	for g := 0; g < groups; g++ {
+		lo, hi := g*width, (g+1)*width
+		if g == groups-1 {
+			hi = k
+		}
+		o := 0
+		for _, count := range observed[lo:hi] {
+			o += count
+		}
+		expected := float64(total) * float64(hi-lo) / float64(k)
+		d := float64(o) - expected
+		stat += d * d / expected
+	}
+
+	df = groups - 1
+	return stat, df, ChiSquareUpperTail(stat, df)
+}
+
+// the smallest expected count per group for ChiSquare
+const minExpected = 5
+
+// ChiSquareUpperTail
+// The probability that a chi-square variable with df degrees of freedom is at least x,
+// by the Wilson-Hilferty approximation.
+func ChiSquareUpperTail(x float64, df int) float64 {
+	if x <= 0 {
+		return 1
+	}
+	k := float64(df)
+	v := 2 / (9 * k)
+	z := (math.Cbrt(x/k) - (1 - v)) / math.Sqrt(v)
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
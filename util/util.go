@@ -5,9 +5,6 @@ import (
 	directedEdge "github.com/lee-hen/Algorithms/4_graphs/22_directed_edge"
 
 	"hash/crc32"
-	"log"
-	"math/rand"
-	"time"
 )
 
 func Max(arg int, rest ...int) int {
@@ -88,21 +85,18 @@ type Interface interface {
 }
 
 func ShuffleStringSlice(a []string) {
-	ShuffleSlice(StringSlice(a))
+	defaultRandom.ShuffleStringSlice(a)
 }
 
 func ShuffleIntSlice(a []int) {
-	ShuffleSlice(IntSlice(a))
+	defaultRandom.ShuffleIntSlice(a)
 }
 
+// ShuffleSlice
+// Rearranges the elements of the slice in uniformly random order, drawing from the
+// Default Random.
 func ShuffleSlice(slice Interface) {
-	n := slice.Len()
-	rand.Seed(time.Now().UnixNano())
-
-	for i := 0; i < n; i++ {
-		r := rand.Intn(n)
-		slice.Swap(i, r)
-	}
+	defaultRandom.ShuffleSlice(slice)
 }
 
 func ReverseStringSlice(a []string) {
@@ -173,13 +167,9 @@ func String(s string) int {
 
 // Bernoulli
 // Returns a random boolean from a Bernoulli distribution with success
-// probability p
+// probability p, drawing from the Default Random.
 func Bernoulli(p float64) bool {
-	if !(p >= 0.0 && p <= 1.0) {
-		log.Fatalln("probability p must be between 0.0 and 1.0: ", p)
-	}
-	rand.Seed(time.Now().UnixNano())
-	return rand.Float64() < p
+	return defaultRandom.Bernoulli(p)
 }
 
 type DirectedEdgeStack []*directedEdge.Edge